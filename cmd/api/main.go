@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,23 +12,39 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/npkanaka/meeting-scheduler/internal/config"
+	"github.com/npkanaka/meeting-scheduler/internal/graphqlapi"
+	"github.com/npkanaka/meeting-scheduler/internal/grpcserver"
 	"github.com/npkanaka/meeting-scheduler/internal/handlers"
+	"github.com/npkanaka/meeting-scheduler/internal/jobs"
 	"github.com/npkanaka/meeting-scheduler/internal/middleware" // Import the middleware package
+	"github.com/npkanaka/meeting-scheduler/internal/notification"
+	"github.com/npkanaka/meeting-scheduler/internal/pubsub"
 	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/search"
 	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/npkanaka/meeting-scheduler/internal/telemetry"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 func main() {
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+
+	// Set up tracing
+	shutdownTracing, err := telemetry.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
 	}
 
 	// Connect to database
-	db, err := connectDB(cfg.Database.DSN)
+	db, err := connectDB(cfg.Database)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -37,19 +54,173 @@ func main() {
 	timeslotRepo := repository.NewGormTimeSlotRepository(db)
 	userRepo := repository.NewGormUserRepository(db)
 	availabilityRepo := repository.NewGormAvailabilityRepository(db)
+	outboxRepo := repository.NewGormOutboxRepository(db)
+	webhookRepo := repository.NewGormWebhookRepository(db)
+	participantRepo := repository.NewGormParticipantRepository(db)
+	notificationRepo := repository.NewGormNotificationRepository(db)
+	decisionRepo := repository.NewGormDecisionRepository(db)
+	shareLinkRepo := repository.NewGormShareLinkRepository(db)
+	guestRepo := repository.NewGormGuestRepository(db)
+	voteRepo := repository.NewGormVoteRepository(db)
+	resourceRepo := repository.NewGormResourceRepository(db)
+	resourceBookingRepo := repository.NewGormResourceBookingRepository(db)
+	organizationRepo := repository.NewGormOrganizationRepository(db)
+	groupRepo := repository.NewGormGroupRepository(db)
+	eventGroupRepo := repository.NewGormEventGroupRepository(db)
+	auditRepo := repository.NewGormAuditRepository(db)
+	searchRepo := newSearchIndex(cfg.Search, db)
+	transactor := repository.NewGormTransactor(db)
 
 	// Initialize services
-	eventService := service.NewEventService(eventRepo)
-	timeslotService := service.NewTimeSlotService(timeslotRepo, eventRepo)
-	availabilityService := service.NewAvailabilityService(availabilityRepo, eventRepo, userRepo)
-	recommendationService := service.NewRecommendationService(eventRepo, timeslotRepo, availabilityRepo, userRepo)
+	resourceService := service.NewResourceService(resourceRepo, resourceBookingRepo, transactor)
+	eventService := service.NewEventService(eventRepo, timeslotRepo, transactor, outboxRepo, auditRepo, searchRepo, resourceService)
+	timeslotService := service.NewTimeSlotService(timeslotRepo, eventRepo, transactor, outboxRepo, auditRepo, resourceService)
+	availabilityService := service.NewAvailabilityService(availabilityRepo, eventRepo, userRepo, transactor, outboxRepo, auditRepo)
+	voteService := service.NewVoteService(voteRepo, timeslotRepo, eventRepo, userRepo, transactor, outboxRepo)
+	groupService := service.NewGroupService(groupRepo, eventGroupRepo, participantRepo, eventRepo, userRepo, transactor, outboxRepo)
+	recommendationService := service.NewRecommendationService(eventRepo, timeslotRepo, availabilityRepo, userRepo, guestRepo, voteRepo, participantRepo, resourceService, groupService)
+	participantService := service.NewParticipantService(participantRepo, eventRepo, userRepo, availabilityRepo, guestRepo, voteRepo, transactor, outboxRepo)
+	webhookService := service.NewWebhookService(webhookRepo, eventRepo, userRepo, service.WebhookConfig{
+		AllowInsecureURLs: cfg.Webhooks.AllowInsecureURLs,
+	})
+	auditService := service.NewAuditService(auditRepo, eventRepo)
+	userService := service.NewUserService(userRepo, transactor, auditRepo)
+	organizationService := service.NewOrganizationService(organizationRepo, userRepo, transactor, auditRepo, service.TokenConfig{
+		Secret: cfg.Auth.Secret,
+		TTL:    cfg.Auth.TokenTTL,
+	})
+	shareService := service.NewShareService(
+		shareLinkRepo, guestRepo, eventRepo, timeslotRepo, availabilityRepo, transactor, outboxRepo, auditRepo,
+		service.ShareConfig{
+			Secret:     cfg.ShareLinks.Secret,
+			DefaultTTL: cfg.ShareLinks.DefaultTTL,
+			MaxTTL:     cfg.ShareLinks.MaxTTL,
+		},
+	)
+	deadlineScheduler := service.NewDeadlineScheduler(
+		eventRepo, decisionRepo, recommendationService, timeslotService, transactor, outboxRepo, auditRepo,
+		service.DeadlineSchedulerConfig{BatchSize: cfg.Scheduler.BatchSize},
+	)
+
+	if cfg.Search.Backend == "memory" {
+		// The memory index starts out empty, so it is filled from the events table
+		indexed, err := eventService.RebuildSearchIndex(tenant.System(context.Background()))
+		if err != nil {
+			log.Fatalf("Failed to build the search index: %v", err)
+		}
+		log.Printf("Indexed %d events for search", indexed)
+	}
+
+	// Background work runs as recurring jobs; the runner drains them on shutdown
+	jobRunner := jobs.NewRunner(newJobStore(cfg.Jobs, db), jobs.Config{
+		PollInterval:   cfg.Jobs.PollInterval,
+		Concurrency:    cfg.Jobs.Concurrency,
+		Timeout:        cfg.Jobs.Timeout,
+		Lease:          cfg.Jobs.Lease,
+		MaxAttempts:    cfg.Jobs.MaxAttempts,
+		InitialBackoff: cfg.Jobs.InitialBackoff,
+		MaxBackoff:     cfg.Jobs.MaxBackoff,
+	})
+	var outboxHandlers []service.OutboxHandler
+	if cfg.FeatureEnabled("webhooks") {
+		outboxHandlers = append(outboxHandlers, webhookService)
+		dispatcher := service.NewWebhookDispatcher(webhookRepo, service.WebhookDispatcherConfig{
+			BatchSize:         cfg.Webhooks.BatchSize,
+			RequestTimeout:    cfg.Webhooks.RequestTimeout,
+			MaxAttempts:       cfg.Webhooks.MaxAttempts,
+			InitialBackoff:    cfg.Webhooks.InitialBackoff,
+			MaxBackoff:        cfg.Webhooks.MaxBackoff,
+			AllowInsecureURLs: cfg.Webhooks.AllowInsecureURLs,
+		})
+		jobRunner.Every("webhooks.deliver", cfg.Webhooks.PollInterval, func(ctx context.Context) error {
+			_, err := dispatcher.DeliverDue(ctx)
+			return err
+		})
+	}
+	if cfg.FeatureEnabled("notifications") {
+		templates, err := notification.LoadTemplates(cfg.Notifications.TemplateDir)
+		if err != nil {
+			log.Fatalf("Failed to load notification templates: %v", err)
+		}
+		notificationService := service.NewNotificationService(
+			notificationRepo, eventRepo, timeslotRepo, participantRepo, availabilityRepo, voteRepo, userRepo, templates,
+			service.NotificationConfig{
+				BaseURL:      cfg.Notifications.BaseURL,
+				ReminderLead: cfg.Notifications.ReminderLead,
+			},
+		)
+		outboxHandlers = append(outboxHandlers, notificationService)
+		dispatcher := service.NewNotificationDispatcher(notificationRepo, newMailer(cfg.Notifications), service.NotificationDispatcherConfig{
+			From:           cfg.Notifications.From,
+			BatchSize:      cfg.Notifications.BatchSize,
+			SendTimeout:    cfg.Notifications.SMTP.Timeout,
+			MaxAttempts:    cfg.Notifications.MaxAttempts,
+			InitialBackoff: cfg.Notifications.InitialBackoff,
+			MaxBackoff:     cfg.Notifications.MaxBackoff,
+		})
+		jobRunner.Every("notifications.send", cfg.Notifications.PollInterval, func(ctx context.Context) error {
+			_, err := dispatcher.SendDue(ctx)
+			return err
+		})
+		jobRunner.Every("notifications.remind", cfg.Notifications.ReminderInterval, func(ctx context.Context) error {
+			_, err := notificationService.QueueReminders(ctx, time.Now())
+			return err
+		})
+	}
+	var streamService *service.StreamService
+	var broker pubsub.Broker
+	if cfg.FeatureEnabled("stream") {
+		broker, err = newBroker(cfg, db)
+		if err != nil {
+			log.Fatalf("Failed to set up the stream broker: %v", err)
+		}
+		streamService = service.NewStreamService(broker, recommendationService)
+		outboxHandlers = append(outboxHandlers, streamService)
+	}
+	if cfg.FeatureEnabled("auto_finalize") {
+		jobRunner.Every("events.process_deadlines", cfg.Scheduler.Interval, func(ctx context.Context) error {
+			_, err := deadlineScheduler.ProcessDue(ctx, time.Now())
+			return err
+		})
+	}
+	trashPurger := service.NewTrashPurger(eventRepo, timeslotRepo, availabilityRepo, transactor, auditRepo, service.TrashPurgerConfig{
+		Retention: cfg.Trash.Retention,
+	})
+	jobRunner.Every("trash.purge", cfg.Trash.PurgeInterval, func(ctx context.Context) error {
+		_, err := trashPurger.PurgeExpired(ctx, time.Now())
+		return err
+	})
+	idempotencyRepo := repository.NewGormIdempotencyRepository(db)
+	jobRunner.Every("idempotency.purge", cfg.Idempotency.PurgeInterval, func(ctx context.Context) error {
+		_, err := idempotencyRepo.Purge(ctx, time.Now())
+		return err
+	})
+	relay := service.NewOutboxRelay(transactor, outboxRepo, service.OutboxRelayConfig{
+		BatchSize:      cfg.Webhooks.OutboxBatchSize,
+		MaxAttempts:    cfg.Webhooks.OutboxMaxAttempts,
+		InitialBackoff: cfg.Webhooks.OutboxInitialBackoff,
+		MaxBackoff:     cfg.Webhooks.OutboxMaxBackoff,
+	}, outboxHandlers...)
+	jobRunner.Every("outbox.relay", cfg.Webhooks.OutboxPollInterval, relay.RelayPending)
+	go jobRunner.Run(context.Background())
 
 	// Initialize handlers
 	eventHandler := handlers.NewEventHandler(eventService)
 	timeslotHandler := handlers.NewTimeSlotHandler(timeslotService)
 	availabilityHandler := handlers.NewAvailabilityHandler(availabilityService)
+	voteHandler := handlers.NewVoteHandler(voteService)
+	resourceHandler := handlers.NewResourceHandler(resourceService)
 	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
-	healthHandler := handlers.NewHealthHandler(db)
+	healthHandler := handlers.NewHealthHandler(db, cfg)
+	participantHandler := handlers.NewParticipantHandler(participantService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	decisionHandler := handlers.NewDecisionHandler(deadlineScheduler)
+	jobHandler := handlers.NewJobHandler(jobRunner)
+	shareHandler := handlers.NewShareHandler(shareService)
+	userHandler := handlers.NewUserHandler(userService)
+	groupHandler := handlers.NewGroupHandler(groupService)
+	organizationHandler := handlers.NewOrganizationHandler(organizationService)
+	auditHandler := handlers.NewAuditHandler(auditService)
 
 	// Create and configure Gin router
 	router := gin.Default()
@@ -57,40 +228,195 @@ func main() {
 	// Apply middleware
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Tracing())
 	router.Use(middleware.RequestLogger()) // Use your middleware
-	router.Use(middleware.CORS())          // Use your CORS middleware
+	router.Use(middleware.CORS(middleware.CORSConfig{
+		AllowedOrigins:    cfg.CORS.AllowedOrigins,
+		CredentialOrigins: cfg.CORS.CredentialOrigins,
+		AllowedMethods:    cfg.CORS.AllowedMethods,
+		AllowedHeaders:    cfg.CORS.AllowedHeaders,
+		ExposedHeaders:    cfg.CORS.ExposedHeaders,
+		MaxAge:            cfg.CORS.MaxAge,
+	}))
+	// Rate limits and idempotency keys run after authentication, so routes
+	// that need a bearer token are limited and keyed per user and the others
+	// per client IP. Requests with a missing or bad token never reach those
+	// limits, so token routes are also limited per client IP beforehand.
+	var rateLimit, perIP []gin.HandlerFunc
+	if cfg.RateLimit.Enabled {
+		limits := newRateLimitConfig(cfg.RateLimit)
+		rateLimit = append(rateLimit, middleware.RateLimit(limits))
+		perIP = append(perIP, middleware.RateLimit(middleware.RateLimitConfig{
+			Store:   limits.Store,
+			Name:    "ip",
+			Default: middleware.RateLimitBudget{Rate: cfg.RateLimit.PerIP.Rate, Burst: cfg.RateLimit.PerIP.Burst},
+		}))
+	}
+	perCaller := append(rateLimit, middleware.Idempotency(middleware.IdempotencyConfig{
+		Store: idempotencyRepo,
+		TTL:   cfg.Idempotency.TTL,
+		Lease: cfg.Idempotency.Lease,
+	}))
+	public := router.Group("/", perCaller...)
 
 	// Register routes
 	// Health check route
-	router.GET("/health", healthHandler.Check)
+	public.GET("/health", healthHandler.Check)
+	public.GET("/livez", healthHandler.Live)
+	public.GET("/readyz", healthHandler.Ready)
+	if cfg.FeatureEnabled("health_details") {
+		public.GET("/health/details", middleware.AdminOnly(cfg.Admin.Token), healthHandler.Details)
+	}
+
+	// Admin routes for background jobs
+	public.GET("/admin/jobs/dead", middleware.AdminOnly(cfg.Admin.Token), jobHandler.ListDead)
+	public.POST("/admin/jobs/:id/retry", middleware.AdminOnly(cfg.Admin.Token), jobHandler.Retry)
+
+	// Organization administration; tokens act for the user's organization
+	public.POST("/admin/organizations", middleware.AdminOnly(cfg.Admin.Token), organizationHandler.Create)
+	public.GET("/admin/organizations", middleware.AdminOnly(cfg.Admin.Token), organizationHandler.List)
+	public.POST("/admin/organizations/:id/users", middleware.AdminOnly(cfg.Admin.Token), organizationHandler.CreateUser)
+	public.POST("/admin/tokens", middleware.AdminOnly(cfg.Admin.Token), organizationHandler.IssueToken)
+
+	// Audit trail across organizations
+	public.GET("/admin/audit", middleware.AdminOnly(cfg.Admin.Token), auditHandler.List)
+
+	// Every route below needs a bearer token and only sees the data of the
+	// caller's organization
+	if cfg.Auth.Secret == "" {
+		log.Println("Tenant routes are disabled: no auth secret is configured")
+	}
+	api := router.Group("/", append(append(perIP, middleware.Authenticate(cfg.Auth.Secret)), perCaller...)...)
 
 	// Event routes
-	router.POST("/events", eventHandler.Create)
-	router.GET("/events", eventHandler.List)
-	router.GET("/events/:id", eventHandler.Get)
-	router.PUT("/events/:id", eventHandler.Update)
-	router.DELETE("/events/:id", eventHandler.Delete)
+	api.POST("/events", eventHandler.Create)
+	api.GET("/events", eventHandler.List)
+	api.GET("/events/:id", eventHandler.Get)
+	api.PUT("/events/:id", eventHandler.Update)
+	api.DELETE("/events/:id", eventHandler.Delete)
+	api.POST("/events/:id/restore", eventHandler.Restore)
+	api.POST("/events/:id/publish", eventHandler.Publish)
+	api.GET("/events/:id/decisions", decisionHandler.List)
+	api.GET("/events/:id/history", auditHandler.History)
+	api.GET("/search", eventHandler.Search)
+
+	// GraphQL queries over events, time slots, availability, users and recommendations
+	if cfg.FeatureEnabled("graphql") {
+		executor, err := graphqlapi.NewExecutor(graphqlapi.Services{
+			Events:          eventService,
+			TimeSlots:       timeslotService,
+			Availability:    availabilityService,
+			Users:           userService,
+			Recommendations: recommendationService,
+		}, graphqlapi.Limits{MaxDepth: cfg.GraphQL.MaxDepth, MaxComplexity: cfg.GraphQL.MaxComplexity})
+		if err != nil {
+			log.Fatalf("Failed to set up GraphQL: %v", err)
+		}
+		graphqlHandler := handlers.NewGraphQLHandler(executor)
+		api.POST("/graphql", graphqlHandler.Query)
+	}
+
+	// User routes; the default buffer pads every meeting the user is recommended for
+	api.GET("/users/:id", userHandler.Get)
+	api.PUT("/users/:id/preferences", userHandler.UpdatePreferences)
+
+	// Participant routes
+	api.POST("/events/:id/participants", participantHandler.Add)
+	api.GET("/events/:id/participants", participantHandler.List)
+	api.DELETE("/events/:id/participants/:userId", participantHandler.Remove)
+
+	// Group routes; invited groups expand to their members until the event is finalized
+	api.POST("/groups", groupHandler.Create)
+	api.GET("/groups", groupHandler.List)
+	api.GET("/groups/:id", groupHandler.Get)
+	api.PUT("/groups/:id", groupHandler.Update)
+	api.DELETE("/groups/:id", groupHandler.Delete)
+	api.POST("/groups/:id/members", groupHandler.AddMember)
+	api.DELETE("/groups/:id/members/:memberId", groupHandler.RemoveMember)
+	api.POST("/events/:id/groups", groupHandler.Invite)
+	api.GET("/events/:id/groups", groupHandler.ListEventGroups)
+	api.DELETE("/events/:id/groups/:groupId", groupHandler.Uninvite)
 
 	// Time slot routes - using :id consistently instead of :eventId
-	router.POST("/events/:id/timeslots", timeslotHandler.Create)
-	router.GET("/events/:id/timeslots", timeslotHandler.List)
-	router.PUT("/timeslots/:id", timeslotHandler.Update)
-	router.DELETE("/timeslots/:id", timeslotHandler.Delete)
+	api.POST("/events/:id/timeslots", timeslotHandler.Create)
+	api.GET("/events/:id/timeslots", timeslotHandler.List)
+	api.GET("/timeslots/:id", timeslotHandler.Get)
+	api.PUT("/timeslots/:id", timeslotHandler.Update)
+	api.DELETE("/timeslots/:id", timeslotHandler.Delete)
+	api.POST("/timeslots/:id/restore", timeslotHandler.Restore)
+	api.POST("/timeslots/:id/finalize", timeslotHandler.Finalize)
 
 	// Availability routes - using :id consistently instead of :eventId
-	router.POST("/events/:id/availability", availabilityHandler.Create)
-	router.GET("/events/:id/availability", availabilityHandler.GetEventAvailability)
-	router.GET("/events/:id/availability/:userId", availabilityHandler.GetUserAvailability)
-	router.PUT("/events/:id/availability/:userId", availabilityHandler.Update)
-	router.DELETE("/availability/:id", availabilityHandler.Delete)
+	api.POST("/events/:id/availability", availabilityHandler.Create)
+	api.GET("/events/:id/availability", availabilityHandler.GetEventAvailability)
+	api.GET("/events/:id/availability/:userId", availabilityHandler.GetUserAvailability)
+	api.PUT("/events/:id/availability/:userId", availabilityHandler.Update)
+	api.GET("/availability/:id", availabilityHandler.Get)
+	api.DELETE("/availability/:id", availabilityHandler.Delete)
+	api.POST("/availability/:id/restore", availabilityHandler.Restore)
+
+	// Vote routes for scoring slots from yes/no/maybe answers
+	api.GET("/events/:id/votes", voteHandler.List)
+	api.PUT("/timeslots/:id/votes/:userId", voteHandler.Cast)
+	api.DELETE("/timeslots/:id/votes/:userId", voteHandler.Retract)
+
+	// Resource catalog routes; events requiring a resource book one when finalized
+	api.POST("/resources", resourceHandler.Create)
+	api.GET("/resources", resourceHandler.List)
+	api.GET("/resources/:id", resourceHandler.Get)
+	api.PUT("/resources/:id", resourceHandler.Update)
+	api.DELETE("/resources/:id", resourceHandler.Delete)
+	api.GET("/resources/:id/bookings", resourceHandler.ListBookings)
+	api.POST("/resources/:id/bookings", resourceHandler.Block)
+	api.DELETE("/resource-bookings/:id", resourceHandler.CancelBooking)
 
 	// Recommendation routes - using :id consistently instead of :eventId
-	router.GET("/events/:id/recommendations", recommendationHandler.GetRecommendations)
+	api.GET("/events/:id/recommendations", recommendationHandler.GetRecommendations)
+
+	// Live update routes
+	if cfg.FeatureEnabled("stream") {
+		streamHandler := handlers.NewStreamHandler(streamService, cfg.Stream.Heartbeat)
+		api.GET("/events/:id/stream", streamHandler.Stream)
+	}
+
+	// Share link routes; guests reach only the event their token was issued for
+	if cfg.FeatureEnabled("share_links") {
+		if cfg.ShareLinks.Secret == "" {
+			log.Println("Share links are disabled: no signing secret is configured")
+		} else {
+			api.POST("/events/:id/share-links", shareHandler.Create)
+			api.GET("/events/:id/share-links", shareHandler.List)
+			api.DELETE("/events/:id/share-links/:linkId", shareHandler.Revoke)
+			public.GET("/share/:token", shareHandler.GetEvent)
+			public.POST("/share/:token/availability", shareHandler.SubmitAvailability)
+		}
+	}
+
+	// Webhook routes
+	if cfg.FeatureEnabled("webhooks") {
+		api.POST("/webhooks", webhookHandler.Create)
+		api.GET("/webhooks", webhookHandler.List)
+		api.DELETE("/webhooks/:id", webhookHandler.Delete)
+		api.GET("/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
+		api.POST("/webhook-deliveries/:id/replay", webhookHandler.Replay)
+	}
 
 	// Start server
 	srv := &http.Server{
-		Addr:    ":" + cfg.Server.Port,
-		Handler: router,
+		Addr:         ":" + cfg.Server.Port,
+		Handler:      router,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+	if broker != nil {
+		// Open streams would otherwise hold up shutdown until it times out
+		srv.RegisterOnShutdown(func() {
+			if err := broker.Close(); err != nil {
+				log.Printf("Failed to close the stream broker: %v", err)
+			}
+		})
 	}
 
 	// Start the server in a goroutine
@@ -101,25 +427,147 @@ func main() {
 		}
 	}()
 
+	// The gRPC API calls the same services on its own port
+	var grpcServer *grpc.Server
+	if cfg.FeatureEnabled("grpc") {
+		grpcServer = grpcserver.NewServer(grpcserver.Config{AuthSecret: cfg.Auth.Secret}, grpcserver.Services{
+			Events:          eventService,
+			TimeSlots:       timeslotService,
+			Availability:    availabilityService,
+			Users:           userService,
+			Recommendations: recommendationService,
+			Stream:          streamService,
+		})
+		listener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+		go func() {
+			log.Printf("gRPC server listening on port %s", cfg.GRPC.Port)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	if grpcServer != nil {
+		stopGRPC(ctx, grpcServer)
+	}
+	if err := jobRunner.Shutdown(ctx); err != nil {
+		log.Printf("Background jobs did not drain in time: %v", err)
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("Failed to flush traces: %v", err)
+	}
 
 	log.Println("Server exited properly")
 }
 
-func connectDB(dsn string) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// stopGRPC lets in-flight gRPC calls finish, cancelling them once ctx is done.
+// Recommendation streams end when the stream broker closes.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Printf("gRPC server forced to stop: %v", ctx.Err())
+		server.Stop()
+	}
+}
+
+func newJobStore(cfg config.JobsConfig, db *gorm.DB) repository.JobRepository {
+	if cfg.Store == "memory" {
+		return jobs.NewMemoryStore()
+	}
+	return repository.NewGormJobRepository(db)
+}
+
+func newSearchIndex(cfg config.SearchConfig, db *gorm.DB) repository.SearchRepository {
+	if cfg.Backend == "memory" {
+		return search.NewMemoryIndex()
+	}
+	return repository.NewGormSearchRepository(db)
+}
+
+func newBroker(cfg *config.Config, db *gorm.DB) (pubsub.Broker, error) {
+	if cfg.Stream.Broker == "memory" {
+		return pubsub.NewMemoryBroker(), nil
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return pubsub.NewPostgresBroker(sqlDB, cfg.Database.DSN, cfg.Stream.Channel), nil
+}
+
+func newMailer(cfg config.NotificationsConfig) notification.Mailer {
+	if cfg.Transport == "smtp" {
+		return notification.NewSMTPMailer(notification.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			StartTLS: cfg.SMTP.StartTLS,
+			Timeout:  cfg.SMTP.Timeout,
+		})
+	}
+	return notification.LogMailer{}
+}
+
+func newRateLimitConfig(cfg config.RateLimitConfig) middleware.RateLimitConfig {
+	var store middleware.RateLimitStore
+	switch cfg.Store {
+	case "redis":
+		client := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
+		store = middleware.NewRedisRateLimitStore(middleware.NewGoRedisScripter(client))
+	default:
+		store = middleware.NewMemoryRateLimitStore()
+	}
+
+	routes := make(map[string]middleware.RateLimitBudget, len(cfg.Routes))
+	for route, budget := range cfg.Routes {
+		routes[route] = middleware.RateLimitBudget{Rate: budget.Rate, Burst: budget.Burst}
+	}
+
+	return middleware.RateLimitConfig{
+		Store:   store,
+		Default: middleware.RateLimitBudget{Rate: cfg.Default.Rate, Burst: cfg.Default.Burst},
+		Routes:  routes,
+	}
+}
+
+func connectDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(cfg.DSN), &gorm.Config{})
 	if err != nil {
 		return nil, err
 	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := db.Use(telemetry.NewGormPlugin()); err != nil {
+		return nil, err
+	}
 	return db, nil
 }
@@ -179,7 +179,11 @@ resource "aws_lb_target_group" "main" {
   target_type = "ip"
 
   health_check {
-    path = "/health"
+    path                = "/readyz"
+    matcher             = "200"
+    interval            = 15
+    healthy_threshold   = 2
+    unhealthy_threshold = 3
   }
 }
 
@@ -252,8 +256,23 @@ resource "aws_ecs_task_definition" "main" {
         {
           name  = "DATABASE_DSN"
           value = "postgres://${var.db_username}:${var.db_password}@${aws_db_instance.postgres.endpoint}/${var.db_name}?sslmode=require"
+        },
+        {
+          name  = "ADMIN_TOKEN"
+          value = var.admin_token
+        },
+        {
+          name  = "AUTH_SECRET"
+          value = var.auth_secret
         }
       ]
+      healthCheck = {
+        command     = ["CMD-SHELL", "wget -qO- http://localhost:8080/livez || exit 1"]
+        interval    = 30
+        timeout     = 5
+        retries     = 3
+        startPeriod = 10
+      }
       logConfiguration = {
         logDriver = "awslogs"
         options = {
@@ -31,6 +31,19 @@ variable "db_name" {
   default     = "scheduler"
 }
 
+variable "admin_token" {
+  description = "Bearer token required for admin endpoints such as /health/details"
+  type        = string
+  sensitive   = true
+  default     = ""
+}
+
+variable "auth_secret" {
+  description = "Key of at least 32 bytes that signs the bearer tokens identifying callers and their organization"
+  type        = string
+  sensitive   = true
+}
+
 variable "container_image" {
   description = "The container image to deploy"
   type        = string
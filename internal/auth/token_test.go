@@ -0,0 +1,62 @@
+package auth_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "test-secret-0123456789abcdef-0123456789"
+
+func TestTokenRoundTrip(t *testing.T) {
+	now := time.Now()
+	principal := auth.Principal{UserID: uuid.New(), OrganizationID: uuid.New()}
+
+	token := auth.Sign(secret, principal, now, now.Add(time.Hour))
+	got, err := auth.Verify(secret, token, now.Add(time.Minute))
+
+	require.NoError(t, err)
+	assert.Equal(t, principal, got)
+}
+
+func TestTokenRejected(t *testing.T) {
+	now := time.Now()
+	principal := auth.Principal{UserID: uuid.New(), OrganizationID: uuid.New()}
+	token := auth.Sign(secret, principal, now, now.Add(time.Hour))
+	parts := strings.Split(token, ".")
+
+	// Claims of another organization under the original signature
+	other := auth.Sign(secret, auth.Principal{UserID: principal.UserID, OrganizationID: uuid.New()}, now, now.Add(time.Hour))
+	swapped := parts[0] + "." + strings.Split(other, ".")[1] + "." + parts[2]
+
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."
+
+	tests := []struct {
+		name    string
+		secret  string
+		token   string
+		at      time.Time
+		wantErr error
+	}{
+		{"wrong secret", "another-secret-0123456789abcdef-0123", token, now, errors.ErrInvalidToken},
+		{"tampered claims", secret, swapped, now, errors.ErrInvalidToken},
+		{"unsigned", secret, unsigned, now, errors.ErrInvalidToken},
+		{"malformed", secret, "not-a-token", now, errors.ErrInvalidToken},
+		{"expired", secret, token, now.Add(time.Hour), errors.ErrTokenExpired},
+		{"no organization", secret, auth.Sign(secret, auth.Principal{UserID: principal.UserID}, now, now.Add(time.Hour)), now, errors.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.Verify(tt.secret, tt.token, tt.at)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// internal/buildinfo/buildinfo.go
+package buildinfo
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// Version and GitCommit are set at build time with
+// -ldflags "-X github.com/npkanaka/meeting-scheduler/internal/buildinfo.Version=... -X ...GitCommit=..."
+var (
+	Version   = "dev"
+	GitCommit = ""
+)
+
+// startTime records when the process started
+var startTime = time.Now()
+
+// Commit returns the git commit the binary was built from, falling back to
+// the VCS information embedded by the Go toolchain
+func Commit() string {
+	if GitCommit != "" {
+		return GitCommit
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return "unknown"
+}
+
+// StartTime returns when the process started
+func StartTime() time.Time {
+	return startTime
+}
+
+// Uptime returns how long the process has been running
+func Uptime() time.Duration {
+	return time.Since(startTime)
+}
@@ -0,0 +1,127 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/npkanaka/meeting-scheduler/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := config.Load(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "8080", cfg.Server.Port)
+	assert.Equal(t, 5*time.Second, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, 25, cfg.Database.MaxOpenConns)
+	assert.Equal(t, "stdout", cfg.Tracing.Exporter)
+	assert.True(t, cfg.FeatureEnabled("health_details"))
+}
+
+func TestLoadLayering(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  port: "9000"
+  read_timeout: 20s
+  write_timeout: 40s
+database:
+  dsn: postgres://file@db/scheduler
+  max_open_conns: 50
+features:
+  search: true
+`)
+
+	// Environment overrides the file
+	t.Setenv("SERVER_WRITE_TIMEOUT", "45s")
+	t.Setenv("DATABASE_MAX_OPEN_CONNS", "60")
+	t.Setenv("FEATURE_SEARCH", "false")
+
+	// Flags override the environment
+	cfg, err := config.Load([]string{"-config", path, "-db-max-open-conns", "70", "-feature", "webhooks"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "9000", cfg.Server.Port)
+	assert.Equal(t, 20*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, 45*time.Second, cfg.Server.WriteTimeout)
+	assert.Equal(t, "postgres://file@db/scheduler", cfg.Database.DSN)
+	assert.Equal(t, 70, cfg.Database.MaxOpenConns)
+	assert.False(t, cfg.FeatureEnabled("search"))
+	assert.True(t, cfg.FeatureEnabled("webhooks"))
+	// Untouched settings keep their defaults
+	assert.Equal(t, 60*time.Second, cfg.Server.IdleTimeout)
+}
+
+func TestLoadSwitchFlags(t *testing.T) {
+	t.Setenv("RATE_LIMIT_ENABLED", "true")
+
+	// A bare switch turns its setting on, and an explicit value still works
+	cfg, err := config.Load([]string{"-tracing", "-rate-limit=false"})
+
+	require.NoError(t, err)
+	assert.True(t, cfg.Tracing.Enabled)
+	assert.False(t, cfg.RateLimit.Enabled)
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[server]
+port = "9100"
+shutdown_timeout = "10s"
+
+[rate_limit.routes."POST /events"]
+rate = 2.0
+burst = 4
+`)
+	t.Setenv("CONFIG_FILE", path)
+
+	cfg, err := config.Load(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "9100", cfg.Server.Port)
+	assert.Equal(t, 10*time.Second, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, config.RateLimitBudget{Rate: 2, Burst: 4}, cfg.RateLimit.Routes["POST /events"])
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	t.Setenv("SERVER_PORT", "http")
+	t.Setenv("SERVER_READ_TIMEOUT", "soon")
+	t.Setenv("DATABASE_MAX_IDLE_CONNS", "100")
+	t.Setenv("TRACING_ENABLED", "true")
+	t.Setenv("TRACING_EXPORTER", "otlp")
+	t.Setenv("CORS_CREDENTIAL_ORIGINS", "*")
+	t.Setenv("GRPC_PORT", "70000")
+	t.Setenv("GRAPHQL_MAX_DEPTH", "0")
+
+	cfg, err := config.Load([]string{"-shutdown-timeout", "0s"})
+
+	assert.Nil(t, cfg)
+	assert.ErrorContains(t, err, "SERVER_READ_TIMEOUT")
+	assert.ErrorContains(t, err, "server.port")
+	assert.ErrorContains(t, err, "server.shutdown_timeout")
+	assert.ErrorContains(t, err, "database.max_idle_conns")
+	assert.ErrorContains(t, err, "tracing.otlp_endpoint")
+	assert.ErrorContains(t, err, "cors.credential_origins")
+	assert.ErrorContains(t, err, "grpc.port")
+	assert.ErrorContains(t, err, "graphql.max_depth")
+}
+
+func TestSummaryRedactsSecrets(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.DSN = "postgres://postgres:hunter2@db:5432/scheduler"
+	cfg.Admin.Token = "secret-token"
+
+	summary := cfg.Summary()
+
+	assert.NotContains(t, summary["database"].(map[string]interface{})["dsn"], "hunter2")
+	assert.Equal(t, "xxxxx", summary["admin"].(map[string]interface{})["token"])
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+)
+
+// Validate checks the configuration and reports every problem found
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	// Server
+	port, err := strconv.Atoi(c.Server.Port)
+	check(err == nil && port > 0 && port <= 65535, "server.port: %q is not a valid port", c.Server.Port)
+	check(c.Server.ReadTimeout > 0, "server.read_timeout: must be positive")
+	check(c.Server.WriteTimeout >= 0, "server.write_timeout: must not be negative")
+	check(c.Server.IdleTimeout >= 0, "server.idle_timeout: must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout: must be positive")
+
+	// Database
+	check(c.Database.DSN != "", "database.dsn: is required")
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns: must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns: must not be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns: %d exceeds max_open_conns %d", c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime: must not be negative")
+	check(c.Database.ConnMaxIdleTime >= 0, "database.conn_max_idle_time: must not be negative")
+
+	// Tracing
+	if c.Tracing.Enabled {
+		switch c.Tracing.Exporter {
+		case "otlp":
+			check(c.Tracing.OTLPEndpoint != "", "tracing.otlp_endpoint: is required for the otlp exporter")
+		case "file":
+			check(c.Tracing.FilePath != "", "tracing.file_path: is required for the file exporter")
+		case "stdout":
+		default:
+			check(false, "tracing.exporter: %q must be one of otlp, stdout or file", c.Tracing.Exporter)
+		}
+		check(c.Tracing.ServiceName != "", "tracing.service_name: is required")
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio: must be between 0 and 1")
+
+	// Health
+	check(c.Health.MaxDBLatency > 0, "health.max_db_latency: must be positive")
+	check(c.Health.MaxPoolSaturation > 0 && c.Health.MaxPoolSaturation <= 1, "health.max_pool_saturation: must be between 0 and 1")
+
+	// Rate limit
+	if c.RateLimit.Enabled {
+		switch c.RateLimit.Store {
+		case "memory":
+		case "redis":
+			check(c.RateLimit.RedisAddr != "", "rate_limit.redis_addr: is required for the redis store")
+		default:
+			check(false, "rate_limit.store: %q must be memory or redis", c.RateLimit.Store)
+		}
+		check(c.RateLimit.Default.Rate > 0 && c.RateLimit.Default.Burst > 0, "rate_limit.default: rate and burst must be positive")
+		check(c.RateLimit.PerIP.Rate > 0 && c.RateLimit.PerIP.Burst > 0, "rate_limit.per_ip: rate and burst must be positive")
+		for route, budget := range c.RateLimit.Routes {
+			check(budget.Rate > 0 && budget.Burst > 0, "rate_limit.routes[%s]: rate and burst must be positive", route)
+		}
+	}
+
+	// CORS
+	for _, origin := range c.CORS.CredentialOrigins {
+		check(origin != "*", "cors.credential_origins: must list explicit origins, not %q", origin)
+	}
+	check(c.CORS.MaxAge >= 0, "cors.max_age: must not be negative")
+
+	// Webhooks
+	check(c.Webhooks.OutboxPollInterval > 0, "webhooks.outbox_poll_interval: must be positive")
+	check(c.Webhooks.OutboxBatchSize > 0, "webhooks.outbox_batch_size: must be positive")
+	check(c.Webhooks.OutboxMaxAttempts > 0, "webhooks.outbox_max_attempts: must be positive")
+	check(c.Webhooks.OutboxInitialBackoff > 0, "webhooks.outbox_initial_backoff: must be positive")
+	check(c.Webhooks.OutboxMaxBackoff >= c.Webhooks.OutboxInitialBackoff, "webhooks.outbox_max_backoff: must not be less than outbox_initial_backoff")
+	check(c.Webhooks.PollInterval > 0, "webhooks.poll_interval: must be positive")
+	check(c.Webhooks.BatchSize > 0, "webhooks.batch_size: must be positive")
+	check(c.Webhooks.RequestTimeout > 0, "webhooks.request_timeout: must be positive")
+	check(c.Webhooks.MaxAttempts > 0, "webhooks.max_attempts: must be positive")
+	check(c.Webhooks.InitialBackoff > 0, "webhooks.initial_backoff: must be positive")
+	check(c.Webhooks.MaxBackoff >= c.Webhooks.InitialBackoff, "webhooks.max_backoff: must not be less than initial_backoff")
+
+	// Notifications
+	switch c.Notifications.Transport {
+	case "smtp":
+		check(c.Notifications.SMTP.Host != "", "notifications.smtp.host: is required for the smtp transport")
+		check(c.Notifications.SMTP.Port > 0 && c.Notifications.SMTP.Port <= 65535, "notifications.smtp.port: %d is not a valid port", c.Notifications.SMTP.Port)
+	case "log":
+	default:
+		check(false, "notifications.transport: %q must be smtp or log", c.Notifications.Transport)
+	}
+	_, err = mail.ParseAddress(c.Notifications.From)
+	check(err == nil, "notifications.from: %q is not a valid address", c.Notifications.From)
+	check(c.Notifications.SMTP.Timeout > 0, "notifications.smtp.timeout: must be positive")
+	check(c.Notifications.PollInterval > 0, "notifications.poll_interval: must be positive")
+	check(c.Notifications.BatchSize > 0, "notifications.batch_size: must be positive")
+	check(c.Notifications.MaxAttempts > 0, "notifications.max_attempts: must be positive")
+	check(c.Notifications.InitialBackoff > 0, "notifications.initial_backoff: must be positive")
+	check(c.Notifications.MaxBackoff >= c.Notifications.InitialBackoff, "notifications.max_backoff: must not be less than initial_backoff")
+	check(c.Notifications.ReminderLead > 0, "notifications.reminder_lead: must be positive")
+	check(c.Notifications.ReminderInterval > 0, "notifications.reminder_interval: must be positive")
+
+	// Scheduler
+	check(c.Scheduler.Interval > 0, "scheduler.interval: must be positive")
+	check(c.Scheduler.BatchSize > 0, "scheduler.batch_size: must be positive")
+
+	// Jobs
+	check(c.Jobs.Store == "postgres" || c.Jobs.Store == "memory", "jobs.store: %q must be postgres or memory", c.Jobs.Store)
+	check(c.Jobs.PollInterval > 0, "jobs.poll_interval: must be positive")
+	check(c.Jobs.Concurrency > 0, "jobs.concurrency: must be positive")
+	check(c.Jobs.Timeout > 0, "jobs.timeout: must be positive")
+	check(c.Jobs.Lease > c.Jobs.Timeout, "jobs.lease: must be longer than jobs.timeout")
+	check(c.Jobs.MaxAttempts > 0, "jobs.max_attempts: must be positive")
+	check(c.Jobs.InitialBackoff > 0, "jobs.initial_backoff: must be positive")
+	check(c.Jobs.MaxBackoff >= c.Jobs.InitialBackoff, "jobs.max_backoff: must not be less than initial_backoff")
+
+	// Trash
+	check(c.Trash.Retention > 0, "trash.retention: must be positive")
+	check(c.Trash.PurgeInterval > 0, "trash.purge_interval: must be positive")
+	check(c.Idempotency.TTL > 0, "idempotency.ttl: must be positive")
+	check(c.Idempotency.Lease >= c.Server.WriteTimeout, "idempotency.lease: must not be less than server.write_timeout")
+	check(c.Idempotency.PurgeInterval > 0, "idempotency.purge_interval: must be positive")
+
+	// Stream
+	check(c.Stream.Broker == "postgres" || c.Stream.Broker == "memory", "stream.broker: %q must be postgres or memory", c.Stream.Broker)
+	check(c.Stream.Broker != "postgres" || c.Stream.Channel != "", "stream.channel: is required for the postgres broker")
+	check(c.Stream.Heartbeat > 0, "stream.heartbeat: must be positive")
+
+	// Search
+	check(c.Search.Backend == "postgres" || c.Search.Backend == "memory", "search.backend: %q must be postgres or memory", c.Search.Backend)
+
+	// gRPC
+	if c.FeatureEnabled("grpc") {
+		grpcPort, err := strconv.Atoi(c.GRPC.Port)
+		check(err == nil && grpcPort > 0 && grpcPort <= 65535, "grpc.port: %q is not a valid port", c.GRPC.Port)
+		check(c.GRPC.Port != c.Server.Port, "grpc.port: must differ from server.port")
+	}
+
+	// GraphQL
+	check(c.GraphQL.MaxDepth > 0, "graphql.max_depth: must be positive")
+	check(c.GraphQL.MaxComplexity > 0, "graphql.max_complexity: must be positive")
+
+	// Auth
+	check(c.Auth.Secret == "" || len(c.Auth.Secret) >= 32, "auth.secret: must be at least 32 bytes")
+	check(c.Auth.TokenTTL > 0, "auth.token_ttl: must be positive")
+
+	// Share links
+	check(c.ShareLinks.Secret == "" || len(c.ShareLinks.Secret) >= 32, "share_links.secret: must be at least 32 bytes")
+	check(c.ShareLinks.DefaultTTL > 0, "share_links.default_ttl: must be positive")
+	check(c.ShareLinks.MaxTTL >= c.ShareLinks.DefaultTTL, "share_links.max_ttl: must not be less than default_ttl")
+
+	return errors.Join(errs...)
+}
@@ -16,4 +16,76 @@ var (
 	ErrUserNotFound = errors.New("user not found")
 	// ErrAvailabilityNotFound is returned when an availability record is not found
 	ErrAvailabilityNotFound = errors.New("availability not found")
+	// ErrInvalidStatusTransition is returned when an event cannot move to the requested status
+	ErrInvalidStatusTransition = errors.New("invalid event status transition")
+	// ErrWebhookSubscriptionNotFound is returned when a webhook subscription is not found
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	// ErrWebhookDeliveryNotFound is returned when a webhook delivery is not found
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrParticipantNotFound is returned when a user is not a participant of an event
+	ErrParticipantNotFound = errors.New("participant not found")
+	// ErrParticipantExists is returned when a user is already a participant of an event
+	ErrParticipantExists = errors.New("user is already a participant of this event")
+	// ErrJobNotFound is returned when a background job is not found
+	ErrJobNotFound = errors.New("job not found")
+	// ErrShareLinkNotFound is returned when a share link is not found
+	ErrShareLinkNotFound = errors.New("share link not found")
+	// ErrInvalidShareToken is returned when a share token is malformed or its signature does not match
+	ErrInvalidShareToken = errors.New("invalid share token")
+	// ErrShareLinkExpired is returned when a share link has expired or was revoked
+	ErrShareLinkExpired = errors.New("share link has expired or was revoked")
+	// ErrInvalidShareLinkExpiry is returned when a share link would expire in the past or beyond the allowed lifetime
+	ErrInvalidShareLinkExpiry = errors.New("share link expiry must be in the future and within the allowed lifetime")
+	// ErrGuestNotFound is returned when a guest is not found
+	ErrGuestNotFound = errors.New("guest not found")
+	// ErrVoteNotFound is returned when a user has not voted on a time slot
+	ErrVoteNotFound = errors.New("vote not found")
+	// ErrResourceNotFound is returned when a resource is not found
+	ErrResourceNotFound = errors.New("resource not found")
+	// ErrResourceBookingNotFound is returned when a resource booking is not found
+	ErrResourceBookingNotFound = errors.New("resource booking not found")
+	// ErrResourceUnavailable is returned when no suitable resource is free for the requested time
+	ErrResourceUnavailable = errors.New("no suitable resource is free at that time")
+	// ErrGroupNotFound is returned when a group is not found
+	ErrGroupNotFound = errors.New("group not found")
+	// ErrGroupMemberNotFound is returned when a user or group is not a member of a group
+	ErrGroupMemberNotFound = errors.New("group member not found")
+	// ErrGroupMemberExists is returned when a user or group is already a member of a group
+	ErrGroupMemberExists = errors.New("already a member of this group")
+	// ErrGroupCycle is returned when nesting a group would make it contain itself
+	ErrGroupCycle = errors.New("a group cannot contain itself")
+	// ErrInvalidGroupMember is returned when a member request names neither or both of a user and a group
+	ErrInvalidGroupMember = errors.New("group member must be exactly one of user_id or group_id")
+	// ErrEventGroupNotFound is returned when a group is not invited to an event
+	ErrEventGroupNotFound = errors.New("group is not invited to this event")
+	// ErrEventGroupExists is returned when a group is already invited to an event
+	ErrEventGroupExists = errors.New("group is already invited to this event")
+	// ErrInvalidQuorum is returned when an event sets both kinds of quorum or a required group needs more attendees than it has members
+	ErrInvalidQuorum = errors.New("quorum and quorum_percent are exclusive, and required groups cannot need more attendees than members")
+	// ErrInvalidWebhookSubscription is returned when a subscription targets neither or both of an event and a creator
+	ErrInvalidWebhookSubscription = errors.New("webhook subscription must target exactly one of event_id or creator_id")
+	// ErrInvalidWebhookURL is returned when a webhook URL is not https or its host is not a public address
+	ErrInvalidWebhookURL = errors.New("webhook URL must use https and resolve to a public address")
+	// ErrOrganizationNotFound is returned when an organization is not found
+	ErrOrganizationNotFound = errors.New("organization not found")
+	// ErrUserEmailTaken is returned when another user already has the email address
+	ErrUserEmailTaken = errors.New("a user with this email already exists")
+	// ErrInvalidToken is returned when a bearer token is malformed or its signature does not match
+	ErrInvalidToken = errors.New("invalid bearer token")
+	// ErrTokenExpired is returned when a bearer token has expired
+	ErrTokenExpired = errors.New("bearer token has expired")
+	// ErrInvalidTokenExpiry is returned when a token would expire in the past
+	ErrInvalidTokenExpiry = errors.New("token expiry must be in the future")
+	// ErrStaleVersion is returned when a write was based on a version that has since changed
+	ErrStaleVersion = errors.New("the resource has changed since the version given")
+	// ErrInvalidCursor is returned when a page cursor is malformed or was issued for another sort order
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidSort is returned when a listing cannot be sorted by the requested field
+	ErrInvalidSort = errors.New("invalid sort field")
+	// ErrIdempotencyKeyInUse is returned when a request with the same idempotency key has not finished yet
+	ErrIdempotencyKeyInUse = errors.New("a request with this idempotency key is still in progress")
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent again with a different request
+	ErrIdempotencyKeyReused = errors.New("this idempotency key was already used for a different request")
+	// ErrNoOrganization is returned when organization data is accessed without an organization to act for
+	ErrNoOrganization = errors.New("no organization to act for")
 )
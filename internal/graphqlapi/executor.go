@@ -0,0 +1,125 @@
+// Package graphqlapi serves read queries over events, time slots,
+// availability, users and recommendations, so a dashboard can fetch a whole
+// event page in one request. Resolvers call the same services as the REST
+// handlers and run with the caller's organization.
+package graphqlapi
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// Services are the services the resolvers call
+type Services struct {
+	Events          *service.EventService
+	TimeSlots       *service.TimeSlotService
+	Availability    *service.AvailabilityService
+	Users           *service.UserService
+	Recommendations *service.RecommendationService
+}
+
+// Limits bound the work a single query can ask for
+type Limits struct {
+	MaxDepth      int // Levels of nested fields
+	MaxComplexity int // Fields, counting those of list items once per expected item
+}
+
+// Request is a GraphQL request as clients post it
+type Request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Executor runs queries against the schema
+type Executor struct {
+	schema graphql.Schema
+	limits Limits
+	users  *service.UserService
+}
+
+// NewExecutor creates an Executor whose resolvers call services
+func NewExecutor(services Services, limits Limits) (*Executor, error) {
+	schema, err := newSchema(&resolver{services: services})
+	if err != nil {
+		return nil, fmt.Errorf("building the GraphQL schema: %w", err)
+	}
+	return &Executor{schema: schema, limits: limits, users: services.Users}, nil
+}
+
+// Execute parses, validates and runs a request. Requests that are malformed
+// or over the limits are rejected before any resolver runs; their result
+// has errors and no data. Every root field is nullable, so executed requests
+// always have data.
+func (e *Executor) Execute(ctx context.Context, req Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if validation := graphql.ValidateDocument(&e.schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+	if err := checkLimits(&e.schema, doc, req.OperationName, req.Variables, e.limits); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        e.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withUserLoader(ctx, newUserLoader(e.users)),
+	})
+}
+
+// publicErrors are the service errors whose message callers see
+var publicErrors = []error{
+	errors.ErrEventNotFound,
+	errors.ErrTimeSlotNotFound,
+	errors.ErrAvailabilityNotFound,
+	errors.ErrUserNotFound,
+	errors.ErrInvalidQuorum,
+	errors.ErrInvalidCursor,
+	errors.ErrInvalidSort,
+	errors.ErrInvalidToken,
+	errors.ErrTokenExpired,
+	context.Canceled,
+	context.DeadlineExceeded,
+}
+
+// inputError is an error in the arguments of a field
+type inputError string
+
+func (e inputError) Error() string {
+	return string(e)
+}
+
+// publicError hides the details of unexpected errors from callers
+func publicError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var input inputError
+	if stderrors.As(err, &input) {
+		return err
+	}
+	for _, public := range publicErrors {
+		if stderrors.Is(err, public) {
+			return err
+		}
+	}
+	// Do not leak database or other internal details to callers
+	log.Printf("GraphQL resolver failed: %v", err)
+	return stderrors.New("internal error")
+}
@@ -0,0 +1,164 @@
+package graphqlapi_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/graphqlapi"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLimits = graphqlapi.Limits{MaxDepth: 8, MaxComplexity: 5000}
+
+// fakeUserRepository serves users from a map and records every batch
+type fakeUserRepository struct {
+	users   map[uuid.UUID]*models.User
+	batches [][]uuid.UUID
+}
+
+func (r *fakeUserRepository) Create(ctx context.Context, user *models.User) error { return nil }
+func (r *fakeUserRepository) Update(ctx context.Context, user *models.User) error { return nil }
+func (r *fakeUserRepository) Delete(ctx context.Context, id uuid.UUID) error      { return nil }
+
+func (r *fakeUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, errors.ErrUserNotFound
+}
+
+func (r *fakeUserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.User, error) {
+	r.batches = append(r.batches, ids)
+	var users []*models.User
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func newExecutor(t *testing.T, services graphqlapi.Services) *graphqlapi.Executor {
+	executor, err := graphqlapi.NewExecutor(services, testLimits)
+	require.NoError(t, err)
+	return executor
+}
+
+func TestUsersAreLoadedInOneBatch(t *testing.T) {
+	alice := &models.User{ID: uuid.New(), Name: "Alice"}
+	bob := &models.User{ID: uuid.New(), Name: "Bob"}
+	repo := &fakeUserRepository{users: map[uuid.UUID]*models.User{alice.ID: alice, bob.ID: bob}}
+	executor := newExecutor(t, graphqlapi.Services{Users: service.NewUserService(repo, nil, nil)})
+	missing := uuid.New()
+
+	result := executor.Execute(context.Background(), graphqlapi.Request{
+		Query: fmt.Sprintf(`{
+			a: user(id: "%s") { name }
+			b: user(id: "%s") { name }
+			again: user(id: "%s") { name }
+			missing: user(id: "%s") { name }
+		}`, alice.ID, bob.ID, alice.ID, missing),
+	})
+
+	require.False(t, result.HasErrors(), "%v", result.Errors)
+	data := result.Data.(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "Alice"}, data["a"])
+	assert.Equal(t, map[string]interface{}{"name": "Bob"}, data["b"])
+	assert.Equal(t, map[string]interface{}{"name": "Alice"}, data["again"])
+	assert.Nil(t, data["missing"])
+	require.Len(t, repo.batches, 1)
+	assert.ElementsMatch(t, []uuid.UUID{alice.ID, bob.ID, missing}, repo.batches[0])
+}
+
+func TestQueriesOverTheLimitsAreRejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		wantError string
+	}{
+		{
+			name:      "invalid query",
+			query:     `{ event(id: "x") { unknownField } }`,
+			wantError: `Cannot query field "unknownField"`,
+		},
+		{
+			name:      "too complex",
+			query:     `{ events(limit: 100) { events { timeSlots(limit: 100) { id } } } }`,
+			wantError: "query complexity 10102 exceeds the limit of 5000",
+		},
+		{
+			name:      "too complex through a variable and a fragment",
+			query:     `query($n: Int) { events { events { ...slots } } } fragment slots on Event { timeSlots(limit: $n) { id startTime } }`,
+			variables: map[string]interface{}{"n": float64(60)},
+			wantError: "query complexity 6052 exceeds the limit of 5000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := newExecutor(t, graphqlapi.Services{})
+
+			result := executor.Execute(context.Background(), graphqlapi.Request{Query: tt.query, Variables: tt.variables})
+
+			assert.Nil(t, result.Data)
+			require.NotEmpty(t, result.Errors)
+			assert.True(t, strings.Contains(result.Errors[0].Message, tt.wantError), result.Errors[0].Message)
+		})
+	}
+}
+
+func TestDashboardQueryIsWithinTheLimits(t *testing.T) {
+	executor := newExecutor(t, graphqlapi.Services{})
+
+	result := executor.Execute(context.Background(), graphqlapi.Request{
+		Query: `{
+			event(id: "not-a-uuid") {
+				title status creator { name }
+				timeSlots { id startTime endTime }
+				availability { startTime endTime user { name email } }
+				recommendations {
+					timeSlot { id } score meetsQuorum
+					attendees { name user { email } }
+					nonAttendees { name }
+				}
+			}
+		}`,
+	})
+
+	// Executed; only the resolver rejects the ID
+	assert.Equal(t, map[string]interface{}{"event": nil}, result.Data)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "invalid id", result.Errors[0].Message)
+}
+
+func TestDepthLimit(t *testing.T) {
+	executor, err := graphqlapi.NewExecutor(graphqlapi.Services{}, graphqlapi.Limits{MaxDepth: 3, MaxComplexity: 5000})
+	require.NoError(t, err)
+
+	result := executor.Execute(context.Background(), graphqlapi.Request{
+		Query: `{ event(id: "x") { availability { user { name } } } }`,
+	})
+
+	assert.Nil(t, result.Data)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "query depth 4 exceeds the limit of 3", result.Errors[0].Message)
+}
+
+func TestIntrospectionIsNotCounted(t *testing.T) {
+	executor, err := graphqlapi.NewExecutor(graphqlapi.Services{}, graphqlapi.Limits{MaxDepth: 1, MaxComplexity: 1})
+	require.NoError(t, err)
+
+	result := executor.Execute(context.Background(), graphqlapi.Request{
+		Query: `{ __schema { types { name fields { name type { name ofType { name } } } } } }`,
+	})
+
+	assert.False(t, result.HasErrors(), "%v", result.Errors)
+}
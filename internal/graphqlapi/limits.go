@@ -0,0 +1,168 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// unpagedListSize is the number of items assumed for list fields without a
+// limit, such as the attendees of a recommendation
+const unpagedListSize = 10
+
+// checkLimits rejects an operation whose fields nest deeper than
+// limits.MaxDepth or whose complexity exceeds limits.MaxComplexity. Every
+// field counts one towards the complexity, and the fields selected on the
+// items of a list count once per item: as many as the limit argument of the
+// list, or of the page holding it, allows, or else unpagedListSize.
+// Introspection fields are not counted.
+func checkLimits(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}, limits Limits) error {
+	c := &costCounter{
+		schema:    schema,
+		variables: variables,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		visiting:  make(map[string]bool),
+	}
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			c.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		}
+	}
+	// Execution reports a missing operation or an unsupported root type
+	if operation == nil || operation.Operation != ast.OperationTypeQuery {
+		return nil
+	}
+
+	depth, complexity := c.selectionSet(schema.QueryType(), operation.SelectionSet, 0)
+	if depth > limits.MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the limit of %d", depth, limits.MaxDepth)
+	}
+	if complexity > limits.MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, limits.MaxComplexity)
+	}
+	return nil
+}
+
+// costCounter measures the selections of an operation
+type costCounter struct {
+	schema    *graphql.Schema
+	variables map[string]interface{}
+	fragments map[string]*ast.FragmentDefinition
+	visiting  map[string]bool // Fragments being counted, which validation keeps from forming cycles
+}
+
+// selectionSet returns the depth and complexity of the fields selected on
+// parent. pageSize is the limit of the page parent is, or 0.
+func (c *costCounter) selectionSet(parent *graphql.Object, set *ast.SelectionSet, pageSize int) (depth, complexity int) {
+	if parent == nil || set == nil {
+		return 0, 0
+	}
+	for _, selection := range set.Selections {
+		var d, cx int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			d, cx = c.field(parent, selection, pageSize)
+		case *ast.InlineFragment:
+			d, cx = c.selectionSet(c.condition(parent, selection.TypeCondition), selection.SelectionSet, pageSize)
+		case *ast.FragmentSpread:
+			fragment := c.fragments[selection.Name.Value]
+			if fragment == nil || c.visiting[fragment.Name.Value] {
+				continue
+			}
+			c.visiting[fragment.Name.Value] = true
+			d, cx = c.selectionSet(c.condition(parent, fragment.TypeCondition), fragment.SelectionSet, pageSize)
+			delete(c.visiting, fragment.Name.Value)
+		}
+		depth = max(depth, d)
+		complexity += cx
+	}
+	return depth, complexity
+}
+
+// field returns the depth and complexity of a field and its selections
+func (c *costCounter) field(parent *graphql.Object, field *ast.Field, pageSize int) (depth, complexity int) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+	definition := parent.Fields()[field.Name.Value]
+	if definition == nil {
+		return 1, 1
+	}
+
+	limit, paged := c.limit(definition, field)
+	items, childPageSize := 1, 0
+	fieldType := definition.Type
+	if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+		fieldType = nonNull.OfType
+	}
+	if list, ok := fieldType.(*graphql.List); ok {
+		switch {
+		case paged:
+			items = max(limit, 0)
+		case pageSize > 0:
+			items = pageSize
+		default:
+			items = unpagedListSize
+		}
+		fieldType = list.OfType
+		if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nonNull.OfType
+		}
+	} else if paged {
+		// A page object; its limit sizes the list it holds
+		childPageSize = max(limit, 0)
+	}
+
+	object, _ := fieldType.(*graphql.Object)
+	d, cx := c.selectionSet(object, field.SelectionSet, childPageSize)
+	return 1 + d, 1 + items*cx
+}
+
+// limit returns the limit argument of a field, or that argument's default,
+// and whether the field takes a limit
+func (c *costCounter) limit(definition *graphql.FieldDefinition, field *ast.Field) (int, bool) {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil {
+				return n, true
+			}
+		case *ast.Variable:
+			switch n := c.variables[value.Name.Value].(type) {
+			case float64: // Variables decoded from JSON
+				return int(n), true
+			case int:
+				return n, true
+			}
+		}
+	}
+	for _, argument := range definition.Args {
+		if argument.Name() == "limit" {
+			n, _ := argument.DefaultValue.(int)
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// condition returns the type a fragment applies to, or parent when it has
+// no type condition
+func (c *costCounter) condition(parent *graphql.Object, named *ast.Named) *graphql.Object {
+	if named == nil {
+		return parent
+	}
+	object, _ := c.schema.Type(named.Name.Value).(*graphql.Object)
+	return object
+}
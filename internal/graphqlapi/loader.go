@@ -0,0 +1,86 @@
+package graphqlapi
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// userLoader batches the user lookups of one request. Resolvers queue the
+// IDs they need and return a thunk; the executor calls thunks only after it
+// has resolved every field on the same level, so the first thunk loads all
+// queued users with a single UserRepository.GetByIDs call. Loaded users are
+// cached for the rest of the request.
+type userLoader struct {
+	users *service.UserService
+
+	mu      sync.Mutex
+	queued  []uuid.UUID
+	results map[uuid.UUID]userResult
+}
+
+// userResult is a loaded user, nil when it does not exist, or the error of
+// the batch that was to load it
+type userResult struct {
+	user *models.User
+	err  error
+}
+
+func newUserLoader(users *service.UserService) *userLoader {
+	return &userLoader{users: users, results: make(map[uuid.UUID]userResult)}
+}
+
+// load queues id and returns a thunk resolving to its user
+func (l *userLoader) load(ctx context.Context, id uuid.UUID) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.results[id]; !ok && !slices.Contains(l.queued, id) {
+		l.queued = append(l.queued, id)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, ok := l.results[id]; !ok {
+			l.flush(ctx)
+		}
+		result := l.results[id]
+		if result.err != nil {
+			return nil, publicError(result.err)
+		}
+		if result.user == nil {
+			return nil, nil
+		}
+		return result.user, nil
+	}
+}
+
+// flush loads every queued user. It must be called with l.mu held.
+func (l *userLoader) flush(ctx context.Context) {
+	ids := l.queued
+	l.queued = nil
+
+	users, err := l.users.GetUsers(ctx, ids)
+	for _, id := range ids {
+		l.results[id] = userResult{err: err}
+	}
+	for _, user := range users {
+		l.results[user.ID] = userResult{user: user}
+	}
+}
+
+type loaderKey struct{}
+
+// withUserLoader returns a context whose resolvers share loader
+func withUserLoader(ctx context.Context, loader *userLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, loader)
+}
+
+// userLoaderFrom returns the user loader of the request ctx belongs to
+func userLoaderFrom(ctx context.Context) *userLoader {
+	return ctx.Value(loaderKey{}).(*userLoader)
+}
@@ -0,0 +1,172 @@
+package graphqlapi
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// resolver resolves the fields that need a service call
+type resolver struct {
+	services Services
+}
+
+// parseID parses a required ID argument
+func parseID(p graphql.ResolveParams, name string) (uuid.UUID, error) {
+	value, _ := p.Args[name].(string)
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, inputError(fmt.Sprintf("invalid %s", name))
+	}
+	return id, nil
+}
+
+// pageLimit reads the limit argument of a paged field
+func pageLimit(p graphql.ResolveParams) (int, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit < 1 || limit > maxPageLimit {
+		return 0, inputError(fmt.Sprintf("limit must be between 1 and %d", maxPageLimit))
+	}
+	return limit, nil
+}
+
+func (r *resolver) event(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	event, err := r.services.Events.GetEvent(p.Context, id)
+	return event, publicError(err)
+}
+
+func (r *resolver) events(p graphql.ResolveParams) (interface{}, error) {
+	limit, err := pageLimit(p)
+	if err != nil {
+		return nil, err
+	}
+	filter := models.EventFilter{Page: models.PageRequest{Limit: limit}}
+	filter.Page.Cursor, _ = p.Args["cursor"].(string)
+	filter.Page.Sort, _ = p.Args["sort"].(string)
+	filter.Title, _ = p.Args["title"].(string)
+
+	statuses, _ := p.Args["status"].([]interface{})
+	for _, value := range statuses {
+		switch status := models.EventStatus(fmt.Sprint(value)); status {
+		case models.EventStatusDraft, models.EventStatusActive, models.EventStatusCanceled, models.EventStatusFinalized:
+			filter.Status = append(filter.Status, status)
+		default:
+			return nil, inputError(fmt.Sprintf("invalid status %s", status))
+		}
+	}
+	if _, ok := p.Args["creatorId"]; ok {
+		creatorID, err := parseID(p, "creatorId")
+		if err != nil {
+			return nil, err
+		}
+		filter.CreatorID = &creatorID
+	}
+	if participating, _ := p.Args["participating"].(bool); participating {
+		principal, _ := auth.PrincipalFrom(p.Context)
+		filter.ParticipantID = &principal.UserID
+	}
+
+	events, info, err := r.services.Events.ListEvents(p.Context, filter)
+	if err != nil {
+		return nil, publicError(err)
+	}
+	return &eventPage{Events: events, PageInfo: info}, nil
+}
+
+func (r *resolver) timeSlot(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	slot, err := r.services.TimeSlots.GetTimeSlot(p.Context, id)
+	return slot, publicError(err)
+}
+
+func (r *resolver) user(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	return userLoaderFrom(p.Context).load(p.Context, id), nil
+}
+
+func (r *resolver) eventCreator(p graphql.ResolveParams) (interface{}, error) {
+	event := p.Source.(*models.Event)
+	return userLoaderFrom(p.Context).load(p.Context, event.CreatorID), nil
+}
+
+func (r *resolver) eventTimeSlots(p graphql.ResolveParams) (interface{}, error) {
+	limit, err := pageLimit(p)
+	if err != nil {
+		return nil, err
+	}
+	event := p.Source.(*models.Event)
+	slots, _, err := r.services.TimeSlots.GetEventTimeSlots(p.Context, event.ID, models.PageRequest{Limit: limit})
+	return slots, publicError(err)
+}
+
+func (r *resolver) eventAvailability(p graphql.ResolveParams) (interface{}, error) {
+	limit, err := pageLimit(p)
+	if err != nil {
+		return nil, err
+	}
+	event := p.Source.(*models.Event)
+	availabilities, _, err := r.services.Availability.GetEventAvailability(p.Context, event.ID, models.PageRequest{Limit: limit})
+	return availabilities, publicError(err)
+}
+
+func (r *resolver) eventRecommendations(p graphql.ResolveParams) (interface{}, error) {
+	quorum, _ := p.Args["quorum"].(string)
+	filter := service.QuorumFilter(quorum)
+	switch filter {
+	case service.QuorumAny, service.QuorumMet, service.QuorumUnmet:
+	default:
+		return nil, inputError("quorum must be met or unmet")
+	}
+
+	event := p.Source.(*models.Event)
+	recommendations, err := r.services.Recommendations.FindRecommendations(p.Context, event.ID, filter)
+	if err != nil {
+		return nil, publicError(err)
+	}
+	return recommendations.Recommendations, nil
+}
+
+func (r *resolver) availabilityUser(p graphql.ResolveParams) (interface{}, error) {
+	availability := p.Source.(*models.Availability)
+	return userLoaderFrom(p.Context).load(p.Context, availability.UserID), nil
+}
+
+// attendeeUser resolves the user behind an attendee; guests have none
+func (r *resolver) attendeeUser(p graphql.ResolveParams) (interface{}, error) {
+	attendee := p.Source.(models.UserResponse)
+	if attendee.Guest {
+		return nil, nil
+	}
+	return userLoaderFrom(p.Context).load(p.Context, attendee.ID), nil
+}
+
+func (r *resolver) recommendationResourceID(p graphql.ResolveParams) (interface{}, error) {
+	recommendation := p.Source.(models.Recommendation)
+	if recommendation.Resource == nil {
+		return nil, nil
+	}
+	return recommendation.Resource.ID, nil
+}
+
+// nextCursor leaves the cursor of the last page null rather than empty
+func (r *resolver) nextCursor(p graphql.ResolveParams) (interface{}, error) {
+	info := p.Source.(models.PageInfo)
+	if info.NextCursor == "" {
+		return nil, nil
+	}
+	return info.NextCursor, nil
+}
@@ -0,0 +1,214 @@
+package graphqlapi
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+)
+
+// Page sizes of listings, as in the REST API
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+// pageArgs are the arguments of a paged list field
+func pageArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageLimit},
+	}
+}
+
+func nonNullList(of graphql.Type) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(of)))
+}
+
+// newSchema builds the schema. Fields without a resolver read the struct
+// field of the same name from the models.
+func newSchema(r *resolver) (graphql.Schema, error) {
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":            &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"defaultBuffer": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Minutes kept free around meetings"},
+		},
+	})
+
+	timeSlot := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TimeSlot",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"eventId":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"startTime": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"endTime":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"version":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	availability := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Availability",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"eventId":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"userId":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"user":      &graphql.Field{Type: user, Resolve: r.availabilityUser},
+			"startTime": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"endTime":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"version":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	attendee := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Attendee",
+		Description: "A participant as recommendations list them; guests who responded through a share link have no user",
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"guest": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"user":  &graphql.Field{Type: user, Resolve: r.attendeeUser},
+		},
+	})
+
+	voteTally := graphql.NewObject(graphql.ObjectConfig{
+		Name: "VoteTally",
+		Fields: graphql.Fields{
+			"yes":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"no":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"maybe": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	timeSlotSummary := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TimeSlotSummary",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"startTime": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"endTime":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	recommendation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Recommendation",
+		Fields: graphql.Fields{
+			"timeSlot":                 &graphql.Field{Type: graphql.NewNonNull(timeSlotSummary)},
+			"attendees":                &graphql.Field{Type: nonNullList(attendee)},
+			"tight":                    &graphql.Field{Type: nonNullList(attendee), Description: "Free for the meeting, but not for the buffers around it"},
+			"maybe":                    &graphql.Field{Type: nonNullList(attendee), Description: "Voted maybe"},
+			"nonAttendees":             &graphql.Field{Type: nonNullList(attendee)},
+			"votes":                    &graphql.Field{Type: graphql.NewNonNull(voteTally)},
+			"resourceId":               &graphql.Field{Type: graphql.ID, Resolve: r.recommendationResourceID, Description: "Resource finalizing the slot would book"},
+			"score":                    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"meetsQuorum":              &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"missingGroups":            &graphql.Field{Type: nonNullList(graphql.String)},
+			"scoreWithoutBuffers":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"onlyViableWithoutBuffers": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	event := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Event",
+		Fields: graphql.Fields{
+			"id":               &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"title":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"creatorId":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"creator":          &graphql.Field{Type: user, Resolve: r.eventCreator},
+			"duration":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Minutes"},
+			"status":           &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "draft, active, canceled or finalized"},
+			"finalTimeSlotId":  &graphql.Field{Type: graphql.ID},
+			"responseDeadline": &graphql.Field{Type: graphql.DateTime},
+			"autoFinalize":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"quorum":           &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"quorumPercent":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"scoring":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"bufferBefore":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"bufferAfter":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"resourceId":       &graphql.Field{Type: graphql.ID},
+			"version":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt":        &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt":        &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"timeSlots": &graphql.Field{
+				Type:    nonNullList(timeSlot),
+				Args:    pageArgs(),
+				Resolve: r.eventTimeSlots,
+			},
+			"availability": &graphql.Field{
+				Type:    nonNullList(availability),
+				Args:    pageArgs(),
+				Resolve: r.eventAvailability,
+			},
+			"recommendations": &graphql.Field{
+				Type: nonNullList(recommendation),
+				Args: graphql.FieldConfigArgument{
+					"quorum": &graphql.ArgumentConfig{Type: graphql.String, Description: "met or unmet keeps only slots with or without quorum"},
+				},
+				Resolve: r.eventRecommendations,
+			},
+		},
+	})
+
+	pageInfo := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"nextCursor": &graphql.Field{Type: graphql.String, Resolve: r.nextCursor, Description: "Null on the last page"},
+			"total":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"limit":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	eventPage := graphql.NewObject(graphql.ObjectConfig{
+		Name: "EventPage",
+		Fields: graphql.Fields{
+			"events":   &graphql.Field{Type: nonNullList(event)},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfo)},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"event": &graphql.Field{
+				Type:    event,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: r.event,
+			},
+			"events": &graphql.Field{
+				Type: eventPage,
+				Args: graphql.FieldConfigArgument{
+					"limit":         &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageLimit},
+					"cursor":        &graphql.ArgumentConfig{Type: graphql.String},
+					"sort":          &graphql.ArgumentConfig{Type: graphql.String},
+					"status":        &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+					"creatorId":     &graphql.ArgumentConfig{Type: graphql.ID},
+					"participating": &graphql.ArgumentConfig{Type: graphql.Boolean, Description: "Only events the caller is invited to"},
+					"title":         &graphql.ArgumentConfig{Type: graphql.String, Description: "Text the title contains, ignoring case"},
+				},
+				Resolve: r.events,
+			},
+			"timeSlot": &graphql.Field{
+				Type:    timeSlot,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: r.timeSlot,
+			},
+			"user": &graphql.Field{
+				Type:    user,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: r.user,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// eventPage is a page of events with its description
+type eventPage struct {
+	Events   []*models.Event
+	PageInfo models.PageInfo
+}
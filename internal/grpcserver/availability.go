@@ -0,0 +1,139 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	schedulerv1 "github.com/npkanaka/meeting-scheduler/api/proto/scheduler/v1"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// availabilityServer implements schedulerv1.AvailabilityServiceServer
+type availabilityServer struct {
+	schedulerv1.UnimplementedAvailabilityServiceServer
+	availability *service.AvailabilityService
+}
+
+// CreateAvailability submits a user's availability for an event
+func (s *availabilityServer) CreateAvailability(ctx context.Context, req *schedulerv1.CreateAvailabilityRequest) (*schedulerv1.Availability, error) {
+	input, eventID, err := availabilityRequest(req.GetEventId(), req.GetUserId(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	availability, err := s.availability.CreateAvailability(ctx, eventID, input)
+	if err != nil {
+		return nil, err
+	}
+	return toAvailability(availability), nil
+}
+
+// GetAvailability retrieves an availability record by ID
+func (s *availabilityServer) GetAvailability(ctx context.Context, req *schedulerv1.GetAvailabilityRequest) (*schedulerv1.Availability, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	availability, err := s.availability.GetAvailability(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toAvailability(availability), nil
+}
+
+// UpdateAvailability changes a user's availability for an event, honoring if_match
+func (s *availabilityServer) UpdateAvailability(ctx context.Context, req *schedulerv1.UpdateAvailabilityRequest) (*schedulerv1.Availability, error) {
+	input, eventID, err := availabilityRequest(req.GetEventId(), req.GetUserId(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	availability, err := s.availability.UpdateAvailability(ctx, eventID, input, precondition(req.GetIfMatch()))
+	if err != nil {
+		return nil, err
+	}
+	return toAvailability(availability), nil
+}
+
+// DeleteAvailability deletes an availability record, honoring if_match
+func (s *availabilityServer) DeleteAvailability(ctx context.Context, req *schedulerv1.DeleteAvailabilityRequest) (*emptypb.Empty, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.availability.DeleteAvailability(ctx, id, precondition(req.GetIfMatch())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListEventAvailability returns a page of the availability submitted for an event
+func (s *availabilityServer) ListEventAvailability(ctx context.Context, req *schedulerv1.ListEventAvailabilityRequest) (*schedulerv1.ListEventAvailabilityResponse, error) {
+	eventID, err := parseID("event_id", req.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	page, err := pageRequest(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	availabilities, info, err := s.availability.GetEventAvailability(ctx, eventID, page)
+	if err != nil {
+		return nil, err
+	}
+	return &schedulerv1.ListEventAvailabilityResponse{
+		Availability: toAvailabilityList(availabilities),
+		Page:         toPageInfo(info),
+	}, nil
+}
+
+// ListUserAvailability returns a user's availability for an event with the
+// ETag UpdateAvailability compares if_match with
+func (s *availabilityServer) ListUserAvailability(ctx context.Context, req *schedulerv1.ListUserAvailabilityRequest) (*schedulerv1.ListUserAvailabilityResponse, error) {
+	eventID, err := parseID("event_id", req.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	availabilities, err := s.availability.GetUserEventAvailability(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &schedulerv1.ListUserAvailabilityResponse{
+		Availability: toAvailabilityList(availabilities),
+		Etag:         models.AvailabilityListETag(availabilities),
+	}, nil
+}
+
+// timeRangeRequest is a request carrying a required time range
+type timeRangeRequest interface {
+	GetStartTime() *timestamppb.Timestamp
+	GetEndTime() *timestamppb.Timestamp
+}
+
+// availabilityRequest converts the event, user and time range of a request
+func availabilityRequest(eventIDStr, userIDStr string, req timeRangeRequest) (*models.AvailabilityRequest, uuid.UUID, error) {
+	eventID, err := parseID("event_id", eventIDStr)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	userID, err := parseID("user_id", userIDStr)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	start, end, err := timeRange(req.GetStartTime(), req.GetEndTime())
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	return &models.AvailabilityRequest{UserID: userID, StartTime: start, EndTime: end}, eventID, nil
+}
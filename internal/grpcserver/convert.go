@@ -0,0 +1,237 @@
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	schedulerv1 "github.com/npkanaka/meeting-scheduler/api/proto/scheduler/v1"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Page sizes of listings, as in the REST API
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+// parseID parses a required ID field
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s", field)
+	}
+	return id, nil
+}
+
+// validate checks a request with the rules the REST API binds it with
+func validate(req interface{}) error {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// precondition turns an if_match field into a precondition; empty allows any
+func precondition(ifMatch string) models.Precondition {
+	if ifMatch == "" {
+		return models.Precondition{}
+	}
+	return models.Precondition{IfMatch: []string{ifMatch}}
+}
+
+// pageRequest applies the default page size and checks its range
+func pageRequest(page *schedulerv1.PageRequest) (models.PageRequest, error) {
+	req := models.PageRequest{
+		Limit:  int(page.GetLimit()),
+		Cursor: page.GetCursor(),
+		Sort:   page.GetSort(),
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultPageLimit
+	}
+	if req.Limit < 0 || req.Limit > maxPageLimit {
+		return req, status.Error(codes.InvalidArgument, "limit must be between 1 and 500")
+	}
+	return req, nil
+}
+
+// timeRange formats the required start and end of a request the way the
+// services parse them
+func timeRange(start, end *timestamppb.Timestamp) (string, string, error) {
+	if start == nil || end == nil {
+		return "", "", status.Error(codes.InvalidArgument, "start_time and end_time are required")
+	}
+	return start.AsTime().Format(time.RFC3339Nano), end.AsTime().Format(time.RFC3339Nano), nil
+}
+
+func toPageInfo(info models.PageInfo) *schedulerv1.PageInfo {
+	return &schedulerv1.PageInfo{
+		NextCursor: info.NextCursor,
+		Total:      info.Total,
+		Limit:      int32(info.Limit),
+	}
+}
+
+// optionalTimestamp converts a time that may be unset
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// optionalID converts an ID that may be unset, leaving it empty
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func toEvent(event *models.Event) *schedulerv1.Event {
+	return &schedulerv1.Event{
+		Id:               event.ID.String(),
+		OrganizationId:   event.OrganizationID.String(),
+		Title:            event.Title,
+		Description:      event.Description,
+		CreatorId:        event.CreatorID.String(),
+		Duration:         int32(event.Duration),
+		Status:           string(event.Status),
+		FinalTimeSlotId:  optionalID(event.FinalTimeSlotID),
+		ResponseDeadline: optionalTimestamp(event.ResponseDeadline),
+		AutoFinalize:     string(event.AutoFinalize),
+		Quorum:           int32(event.Quorum),
+		QuorumPercent:    int32(event.QuorumPercent),
+		Scoring:          string(event.Scoring),
+		BufferBefore:     int32(event.BufferBefore),
+		BufferAfter:      int32(event.BufferAfter),
+		ResourceId:       optionalID(event.ResourceID),
+		Version:          int32(event.Version),
+		Etag:             event.ETag(),
+		CreatedAt:        timestamppb.New(event.CreatedAt),
+		UpdatedAt:        timestamppb.New(event.UpdatedAt),
+	}
+}
+
+// eventRequest converts and validates the fields of an event a caller sets
+func eventRequest(input *schedulerv1.EventInput) (*models.CreateEventRequest, error) {
+	if input == nil {
+		return nil, status.Error(codes.InvalidArgument, "event is required")
+	}
+	req := &models.CreateEventRequest{
+		Title:         input.GetTitle(),
+		Description:   input.GetDescription(),
+		Duration:      int(input.GetDuration()),
+		AutoFinalize:  models.AutoFinalizePolicy(input.GetAutoFinalize()),
+		Quorum:        int(input.GetQuorum()),
+		QuorumPercent: int(input.GetQuorumPercent()),
+		Scoring:       models.ScoringMode(input.GetScoring()),
+		BufferBefore:  int(input.GetBufferBefore()),
+		BufferAfter:   int(input.GetBufferAfter()),
+	}
+	if input.GetResponseDeadline() != nil {
+		deadline := input.GetResponseDeadline().AsTime()
+		req.ResponseDeadline = &deadline
+	}
+	return req, validate(req)
+}
+
+func toTimeSlot(slot *models.TimeSlot) *schedulerv1.TimeSlot {
+	return &schedulerv1.TimeSlot{
+		Id:        slot.ID.String(),
+		EventId:   slot.EventID.String(),
+		StartTime: timestamppb.New(slot.StartTime),
+		EndTime:   timestamppb.New(slot.EndTime),
+		Version:   int32(slot.Version),
+		Etag:      slot.ETag(),
+		CreatedAt: timestamppb.New(slot.CreatedAt),
+		UpdatedAt: timestamppb.New(slot.UpdatedAt),
+	}
+}
+
+func toAvailability(availability *models.Availability) *schedulerv1.Availability {
+	return &schedulerv1.Availability{
+		Id:        availability.ID.String(),
+		EventId:   availability.EventID.String(),
+		UserId:    availability.UserID.String(),
+		StartTime: timestamppb.New(availability.StartTime),
+		EndTime:   timestamppb.New(availability.EndTime),
+		Version:   int32(availability.Version),
+		Etag:      availability.ETag(),
+		CreatedAt: timestamppb.New(availability.CreatedAt),
+		UpdatedAt: timestamppb.New(availability.UpdatedAt),
+	}
+}
+
+func toAvailabilityList(availabilities []*models.Availability) []*schedulerv1.Availability {
+	converted := make([]*schedulerv1.Availability, len(availabilities))
+	for i, availability := range availabilities {
+		converted[i] = toAvailability(availability)
+	}
+	return converted
+}
+
+func toUser(user *models.User) *schedulerv1.User {
+	return &schedulerv1.User{
+		Id:             user.ID.String(),
+		OrganizationId: user.OrganizationID.String(),
+		Name:           user.Name,
+		Email:          user.Email,
+		DefaultBuffer:  int32(user.DefaultBuffer),
+		CreatedAt:      timestamppb.New(user.CreatedAt),
+		UpdatedAt:      timestamppb.New(user.UpdatedAt),
+	}
+}
+
+func toRecommendations(resp *models.RecommendationResponse) *schedulerv1.Recommendations {
+	converted := &schedulerv1.Recommendations{
+		Scoring:         string(resp.Scoring),
+		Quorum:          int32(resp.Quorum),
+		Recommendations: make([]*schedulerv1.Recommendation, len(resp.Recommendations)),
+	}
+	for i, rec := range resp.Recommendations {
+		recommendation := &schedulerv1.Recommendation{
+			TimeSlot: &schedulerv1.TimeSlotSummary{
+				Id:        rec.TimeSlot.ID.String(),
+				StartTime: timestamppb.New(rec.TimeSlot.StartTime),
+				EndTime:   timestamppb.New(rec.TimeSlot.EndTime),
+			},
+			Attendees:    toAttendees(rec.Attendees),
+			Tight:        toAttendees(rec.Tight),
+			Maybe:        toAttendees(rec.Maybe),
+			NonAttendees: toAttendees(rec.NonAttendees),
+			Votes: &schedulerv1.VoteTally{
+				Yes:   int32(rec.Votes.Yes),
+				No:    int32(rec.Votes.No),
+				Maybe: int32(rec.Votes.Maybe),
+			},
+			Score:                    int32(rec.Score),
+			MeetsQuorum:              rec.MeetsQuorum,
+			MissingGroups:            rec.MissingGroups,
+			ScoreWithoutBuffers:      int32(rec.ScoreWithoutBuffers),
+			OnlyViableWithoutBuffers: rec.OnlyViableWithoutBuffers,
+		}
+		if rec.Resource != nil {
+			recommendation.ResourceId = rec.Resource.ID.String()
+		}
+		converted.Recommendations[i] = recommendation
+	}
+	return converted
+}
+
+func toAttendees(users []models.UserResponse) []*schedulerv1.Attendee {
+	attendees := make([]*schedulerv1.Attendee, len(users))
+	for i, user := range users {
+		attendees[i] = &schedulerv1.Attendee{
+			Id:    user.ID.String(),
+			Name:  user.Name,
+			Email: user.Email,
+			Guest: user.Guest,
+		}
+	}
+	return attendees
+}
@@ -0,0 +1,134 @@
+package grpcserver
+
+import (
+	"context"
+
+	schedulerv1 "github.com/npkanaka/meeting-scheduler/api/proto/scheduler/v1"
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// eventServer implements schedulerv1.EventServiceServer
+type eventServer struct {
+	schedulerv1.UnimplementedEventServiceServer
+	events *service.EventService
+}
+
+// CreateEvent creates an event organized by the caller
+func (s *eventServer) CreateEvent(ctx context.Context, req *schedulerv1.CreateEventRequest) (*schedulerv1.Event, error) {
+	input, err := eventRequest(req.GetEvent())
+	if err != nil {
+		return nil, err
+	}
+	principal, _ := auth.PrincipalFrom(ctx)
+
+	event, err := s.events.CreateEvent(ctx, input, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return toEvent(event), nil
+}
+
+// GetEvent retrieves an event by ID
+func (s *eventServer) GetEvent(ctx context.Context, req *schedulerv1.GetEventRequest) (*schedulerv1.Event, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.events.GetEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toEvent(event), nil
+}
+
+// UpdateEvent updates an event, honoring if_match
+func (s *eventServer) UpdateEvent(ctx context.Context, req *schedulerv1.UpdateEventRequest) (*schedulerv1.Event, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	input, err := eventRequest(req.GetEvent())
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.events.UpdateEvent(ctx, id, input, precondition(req.GetIfMatch()))
+	if err != nil {
+		return nil, err
+	}
+	return toEvent(event), nil
+}
+
+// DeleteEvent deletes an event, honoring if_match
+func (s *eventServer) DeleteEvent(ctx context.Context, req *schedulerv1.DeleteEventRequest) (*emptypb.Empty, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.events.DeleteEvent(ctx, id, precondition(req.GetIfMatch())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// PublishEvent moves a draft event to active
+func (s *eventServer) PublishEvent(ctx context.Context, req *schedulerv1.PublishEventRequest) (*schedulerv1.Event, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.events.PublishEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toEvent(event), nil
+}
+
+// ListEvents returns a page of events, narrowed by the request's filters
+func (s *eventServer) ListEvents(ctx context.Context, req *schedulerv1.ListEventsRequest) (*schedulerv1.ListEventsResponse, error) {
+	page, err := pageRequest(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+	filter := models.EventFilter{Page: page, Title: req.GetTitle()}
+	for _, value := range req.GetStatus() {
+		switch eventStatus := models.EventStatus(value); eventStatus {
+		case models.EventStatusDraft, models.EventStatusActive, models.EventStatusCanceled, models.EventStatusFinalized:
+			filter.Status = append(filter.Status, eventStatus)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid status %s", value)
+		}
+	}
+	if req.GetCreatorId() != "" {
+		creatorID, err := parseID("creator_id", req.GetCreatorId())
+		if err != nil {
+			return nil, err
+		}
+		filter.CreatorID = &creatorID
+	}
+	if req.GetParticipating() {
+		principal, _ := auth.PrincipalFrom(ctx)
+		filter.ParticipantID = &principal.UserID
+	}
+
+	events, info, err := s.events.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	resp := &schedulerv1.ListEventsResponse{
+		Events: make([]*schedulerv1.Event, len(events)),
+		Page:   toPageInfo(info),
+	}
+	for i, event := range events {
+		resp.Events[i] = toEvent(event)
+	}
+	return resp, nil
+}
@@ -0,0 +1,180 @@
+package grpcserver
+
+import (
+	"context"
+	stderrors "errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// publicServicePrefix marks the methods callers reach without a token
+const publicServicePrefix = "/grpc.health.v1.Health/"
+
+// UnaryAuth requires a bearer token signed with secret in the authorization
+// metadata, as the REST API does. The call context then carries the caller
+// as its principal and acts for the caller's organization. When no secret is
+// configured every call except health checks is refused.
+func UnaryAuth(secret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, secret, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth is UnaryAuth for streaming calls
+func StreamAuth(secret string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), secret, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate verifies the bearer token of a call
+func authenticate(ctx context.Context, secret, method string) (context.Context, error) {
+	if strings.HasPrefix(method, publicServicePrefix) {
+		return ctx, nil
+	}
+	if secret == "" {
+		return nil, status.Error(codes.PermissionDenied, "authentication is not configured")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	if values := md.Get("authorization"); len(values) > 0 {
+		token, _ = strings.CutPrefix(values[0], "Bearer ")
+	}
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "bearer token required")
+	}
+
+	principal, err := auth.Verify(secret, token, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = auth.WithPrincipal(ctx, principal)
+	return tenant.WithOrganization(ctx, principal.OrganizationID), nil
+}
+
+// UnaryErrors turns the service errors a call returns into status errors with
+// the matching code
+func UnaryErrors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, statusError(err)
+	}
+}
+
+// StreamErrors is UnaryErrors for streaming calls
+func StreamErrors() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return statusError(handler(srv, ss))
+	}
+}
+
+// statusError maps a service error to a status error. Errors that already
+// carry a status, and nil, are returned as they are.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := codeForError(err)
+	if code == codes.Internal {
+		// Do not leak database or other internal details to callers
+		log.Printf("gRPC call failed: %v", err)
+		return status.Error(code, "internal error")
+	}
+	return status.Error(code, err.Error())
+}
+
+// codeForError maps service errors to gRPC status codes, as statusForError
+// in the handlers maps them to HTTP status codes
+func codeForError(err error) codes.Code {
+	switch {
+	case stderrors.Is(err, errors.ErrEventNotFound),
+		stderrors.Is(err, errors.ErrTimeSlotNotFound),
+		stderrors.Is(err, errors.ErrAvailabilityNotFound),
+		stderrors.Is(err, errors.ErrUserNotFound),
+		stderrors.Is(err, errors.ErrParticipantNotFound),
+		stderrors.Is(err, errors.ErrVoteNotFound),
+		stderrors.Is(err, errors.ErrResourceNotFound),
+		stderrors.Is(err, errors.ErrGroupNotFound),
+		stderrors.Is(err, errors.ErrOrganizationNotFound):
+		return codes.NotFound
+	case stderrors.Is(err, errors.ErrParticipantExists),
+		stderrors.Is(err, errors.ErrUserEmailTaken):
+		return codes.AlreadyExists
+	case stderrors.Is(err, errors.ErrInvalidStatusTransition),
+		stderrors.Is(err, errors.ErrResourceUnavailable):
+		return codes.FailedPrecondition
+	case stderrors.Is(err, errors.ErrStaleVersion):
+		// Read again and retry, as with a failed read-modify-write
+		return codes.Aborted
+	case stderrors.Is(err, errors.ErrInvalidQuorum),
+		stderrors.Is(err, errors.ErrInvalidCursor),
+		stderrors.Is(err, errors.ErrInvalidSort),
+		stderrors.Is(err, errors.ErrInvalidTimeRange):
+		return codes.InvalidArgument
+	case stderrors.Is(err, errors.ErrInvalidToken),
+		stderrors.Is(err, errors.ErrTokenExpired):
+		return codes.Unauthenticated
+	case stderrors.Is(err, context.Canceled):
+		return codes.Canceled
+	case stderrors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// UnaryLogging logs every call with its status code and duration
+func UnaryLogging() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamLogging logs every streaming call when it ends
+func StreamLogging() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logCall(method string, start time.Time, err error) {
+	log.Printf("gRPC call - Method: %s, Code: %s, Duration: %s", method, status.Code(err), time.Since(start))
+}
+
+// contextStream is a server stream whose context was replaced
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the replaced context
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,101 @@
+package grpcserver_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/grpcserver"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authSecret = "test-secret-0123456789abcdef-0123456789"
+
+func TestUnaryAuth(t *testing.T) {
+	now := time.Now()
+	principal := auth.Principal{UserID: uuid.New(), OrganizationID: uuid.New()}
+	valid := auth.Sign(authSecret, principal, now, now.Add(time.Hour))
+	expired := auth.Sign(authSecret, principal, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	const method = "/scheduler.v1.EventService/GetEvent"
+
+	tests := []struct {
+		name       string
+		secret     string
+		method     string
+		header     string
+		wantCode   codes.Code
+		wantTenant uuid.UUID
+	}{
+		{"valid token", authSecret, method, "Bearer " + valid, codes.OK, principal.OrganizationID},
+		{"missing token", authSecret, method, "", codes.Unauthenticated, uuid.Nil},
+		{"expired token", authSecret, method, "Bearer " + expired, codes.Unauthenticated, uuid.Nil},
+		{"not configured", "", method, "Bearer " + valid, codes.PermissionDenied, uuid.Nil},
+		{"health check", "", "/grpc.health.v1.Health/Check", "", codes.OK, uuid.Nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.header))
+			}
+			var seen uuid.UUID
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				seen, _ = tenant.OrganizationID(ctx)
+				return "ok", nil
+			}
+
+			_, err := grpcserver.UnaryAuth(tt.secret)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantTenant, seen)
+		})
+	}
+}
+
+func TestUnaryErrors(t *testing.T) {
+	tests := []struct {
+		err      error
+		wantCode codes.Code
+	}{
+		{fmt.Errorf("loading: %w", errors.ErrEventNotFound), codes.NotFound},
+		{errors.ErrUserEmailTaken, codes.AlreadyExists},
+		{errors.ErrInvalidStatusTransition, codes.FailedPrecondition},
+		{errors.ErrStaleVersion, codes.Aborted},
+		{errors.ErrInvalidCursor, codes.InvalidArgument},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{status.Error(codes.InvalidArgument, "invalid id"), codes.InvalidArgument},
+		{fmt.Errorf("connection refused"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.err
+			}
+
+			_, err := grpcserver.UnaryErrors()(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestUnaryErrorsHidesInternalDetails(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("dial tcp 10.0.0.5:5432: connection refused")
+	}
+
+	_, err := grpcserver.UnaryErrors()(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	assert.Equal(t, "internal error", status.Convert(err).Message())
+}
@@ -0,0 +1,71 @@
+package grpcserver
+
+import (
+	"context"
+
+	schedulerv1 "github.com/npkanaka/meeting-scheduler/api/proto/scheduler/v1"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recommendationServer implements schedulerv1.RecommendationServiceServer
+type recommendationServer struct {
+	schedulerv1.UnimplementedRecommendationServiceServer
+	recommendations *service.RecommendationService
+	stream          *service.StreamService
+}
+
+// GetRecommendations returns the recommended time slots of an event
+func (s *recommendationServer) GetRecommendations(ctx context.Context, req *schedulerv1.GetRecommendationsRequest) (*schedulerv1.Recommendations, error) {
+	eventID, err := parseID("event_id", req.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	filter := service.QuorumFilter(req.GetQuorum())
+	switch filter {
+	case service.QuorumAny, service.QuorumMet, service.QuorumUnmet:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "quorum must be met or unmet")
+	}
+
+	recommendations, err := s.recommendations.FindRecommendations(ctx, eventID, filter)
+	if err != nil {
+		return nil, err
+	}
+	return toRecommendations(recommendations), nil
+}
+
+// WatchRecommendations streams the recommendations of an event whenever they
+// may have changed, starting with the current ones
+func (s *recommendationServer) WatchRecommendations(req *schedulerv1.WatchRecommendationsRequest, stream grpc.ServerStreamingServer[schedulerv1.RecommendationUpdate]) error {
+	if s.stream == nil {
+		return status.Error(codes.Unimplemented, "live updates are disabled")
+	}
+	eventID, err := parseID("event_id", req.GetEventId())
+	if err != nil {
+		return err
+	}
+
+	updates, err := s.stream.Subscribe(stream.Context(), eventID)
+	if err != nil {
+		return err
+	}
+	for update := range updates {
+		// Lifecycle updates are for dashboards; only the outcome is sent here
+		recommendations, ok := update.Data.(*models.RecommendationResponse)
+		if update.Type != service.StreamRecommendations || !ok {
+			continue
+		}
+		err := stream.Send(&schedulerv1.RecommendationUpdate{
+			EventId:         eventID.String(),
+			Recommendations: toRecommendations(recommendations),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
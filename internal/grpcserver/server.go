@@ -0,0 +1,56 @@
+// Package grpcserver serves the gRPC API defined in api/proto. It calls the
+// same services as the REST handlers, so both APIs share business logic,
+// authentication and tenant scoping.
+package grpcserver
+
+import (
+	schedulerv1 "github.com/npkanaka/meeting-scheduler/api/proto/scheduler/v1"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Config configures the gRPC server
+type Config struct {
+	AuthSecret string // Verifies bearer tokens; calls are refused while it is empty
+}
+
+// Services are the services the gRPC API calls
+type Services struct {
+	Events          *service.EventService
+	TimeSlots       *service.TimeSlotService
+	Availability    *service.AvailabilityService
+	Users           *service.UserService
+	Recommendations *service.RecommendationService
+	Stream          *service.StreamService // nil when live updates are disabled
+}
+
+// NewServer creates a gRPC server with every API service and the standard
+// health service registered. Calls are logged, authenticated and have
+// service errors mapped to status codes, in that order.
+func NewServer(cfg Config, services Services) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			UnaryLogging(),
+			UnaryErrors(),
+			UnaryAuth(cfg.AuthSecret),
+		),
+		grpc.ChainStreamInterceptor(
+			StreamLogging(),
+			StreamErrors(),
+			StreamAuth(cfg.AuthSecret),
+		),
+	)
+
+	schedulerv1.RegisterEventServiceServer(server, &eventServer{events: services.Events})
+	schedulerv1.RegisterTimeSlotServiceServer(server, &timeSlotServer{timeSlots: services.TimeSlots})
+	schedulerv1.RegisterAvailabilityServiceServer(server, &availabilityServer{availability: services.Availability})
+	schedulerv1.RegisterUserServiceServer(server, &userServer{users: services.Users})
+	schedulerv1.RegisterRecommendationServiceServer(server, &recommendationServer{
+		recommendations: services.Recommendations,
+		stream:          services.Stream,
+	})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	return server
+}
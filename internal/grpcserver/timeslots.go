@@ -0,0 +1,118 @@
+package grpcserver
+
+import (
+	"context"
+
+	schedulerv1 "github.com/npkanaka/meeting-scheduler/api/proto/scheduler/v1"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// timeSlotServer implements schedulerv1.TimeSlotServiceServer
+type timeSlotServer struct {
+	schedulerv1.UnimplementedTimeSlotServiceServer
+	timeSlots *service.TimeSlotService
+}
+
+// CreateTimeSlot proposes a time slot for an event
+func (s *timeSlotServer) CreateTimeSlot(ctx context.Context, req *schedulerv1.CreateTimeSlotRequest) (*schedulerv1.TimeSlot, error) {
+	eventID, err := parseID("event_id", req.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := timeRange(req.GetStartTime(), req.GetEndTime())
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := s.timeSlots.CreateTimeSlot(ctx, eventID, &models.TimeSlotRequest{StartTime: start, EndTime: end})
+	if err != nil {
+		return nil, err
+	}
+	return toTimeSlot(slot), nil
+}
+
+// GetTimeSlot retrieves a time slot by ID
+func (s *timeSlotServer) GetTimeSlot(ctx context.Context, req *schedulerv1.GetTimeSlotRequest) (*schedulerv1.TimeSlot, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := s.timeSlots.GetTimeSlot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTimeSlot(slot), nil
+}
+
+// UpdateTimeSlot moves a time slot, honoring if_match
+func (s *timeSlotServer) UpdateTimeSlot(ctx context.Context, req *schedulerv1.UpdateTimeSlotRequest) (*schedulerv1.TimeSlot, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := timeRange(req.GetStartTime(), req.GetEndTime())
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := s.timeSlots.UpdateTimeSlot(ctx, id, &models.TimeSlotRequest{StartTime: start, EndTime: end}, precondition(req.GetIfMatch()))
+	if err != nil {
+		return nil, err
+	}
+	return toTimeSlot(slot), nil
+}
+
+// DeleteTimeSlot deletes a time slot, honoring if_match
+func (s *timeSlotServer) DeleteTimeSlot(ctx context.Context, req *schedulerv1.DeleteTimeSlotRequest) (*emptypb.Empty, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.timeSlots.DeleteTimeSlot(ctx, id, precondition(req.GetIfMatch())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListTimeSlots returns a page of the time slots of an event
+func (s *timeSlotServer) ListTimeSlots(ctx context.Context, req *schedulerv1.ListTimeSlotsRequest) (*schedulerv1.ListTimeSlotsResponse, error) {
+	eventID, err := parseID("event_id", req.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	page, err := pageRequest(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	slots, info, err := s.timeSlots.GetEventTimeSlots(ctx, eventID, page)
+	if err != nil {
+		return nil, err
+	}
+	resp := &schedulerv1.ListTimeSlotsResponse{
+		TimeSlots: make([]*schedulerv1.TimeSlot, len(slots)),
+		Page:      toPageInfo(info),
+	}
+	for i, slot := range slots {
+		resp.TimeSlots[i] = toTimeSlot(slot)
+	}
+	return resp, nil
+}
+
+// FinalizeTimeSlot picks a time slot for its event
+func (s *timeSlotServer) FinalizeTimeSlot(ctx context.Context, req *schedulerv1.FinalizeTimeSlotRequest) (*schedulerv1.Event, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.timeSlots.FinalizeTimeSlot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toEvent(event), nil
+}
@@ -0,0 +1,48 @@
+package grpcserver
+
+import (
+	"context"
+
+	schedulerv1 "github.com/npkanaka/meeting-scheduler/api/proto/scheduler/v1"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// userServer implements schedulerv1.UserServiceServer
+type userServer struct {
+	schedulerv1.UnimplementedUserServiceServer
+	users *service.UserService
+}
+
+// GetUser retrieves a user by ID
+func (s *userServer) GetUser(ctx context.Context, req *schedulerv1.GetUserRequest) (*schedulerv1.User, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+// UpdatePreferences changes a user's scheduling preferences
+func (s *userServer) UpdatePreferences(ctx context.Context, req *schedulerv1.UpdatePreferencesRequest) (*schedulerv1.User, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	buffer := int(req.GetDefaultBuffer())
+	input := &models.UserPreferencesRequest{DefaultBuffer: &buffer}
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.UpdatePreferences(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
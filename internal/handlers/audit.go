@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// AuditHandler handles HTTP requests for the audit trail
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// History returns the changes made to an event, its time slots and its availability
+func (h *AuditHandler) History(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	limit, ok := auditLimit(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.auditService.GetEventHistory(c.Request.Context(), eventID, limit)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+// List returns audit entries across organizations, narrowed by the query parameters
+func (h *AuditHandler) List(c *gin.Context) {
+	var filter models.AuditFilter
+	var ok bool
+	if filter.Limit, ok = auditLimit(c); !ok {
+		return
+	}
+	for name, target := range map[string]**uuid.UUID{
+		"organization_id": &filter.OrganizationID,
+		"event_id":        &filter.EventID,
+		"actor_id":        &filter.ActorID,
+		"entity_id":       &filter.EntityID,
+	} {
+		if *target, ok = optionalUUIDQuery(c, name); !ok {
+			return
+		}
+	}
+	if filter.From, ok = optionalTimeQuery(c, "from"); !ok {
+		return
+	}
+	if filter.To, ok = optionalTimeQuery(c, "to"); !ok {
+		return
+	}
+	filter.EntityType = c.Query("entity_type")
+	filter.Action = c.Query("action")
+
+	entries, err := h.auditService.ListEntries(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+// auditLimit parses the limit query parameter, writing a 400 when it is out of range
+func auditLimit(c *gin.Context) (int, bool) {
+	limit := 100
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit <= 0 || limit > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return 0, false
+		}
+	}
+	return limit, true
+}
+
+// optionalTimeQuery parses an optional RFC 3339 query parameter, writing a 400 when it is malformed
+func optionalTimeQuery(c *gin.Context, name string) (*time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name + ", expected RFC 3339"})
+		return nil, false
+	}
+	return &t, true
+}
@@ -45,7 +45,26 @@ func (h *AvailabilityHandler) Create(c *gin.Context) {
 	c.JSON(http.StatusCreated, availability)
 }
 
-// Update updates an existing availability record
+// Get retrieves an availability record by ID
+func (h *AvailabilityHandler) Get(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid availability ID"})
+		return
+	}
+
+	availability, err := h.availabilityService.GetAvailability(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondWithETag(c, availability.ETag(), availability)
+}
+
+// Update updates an existing availability record. If-Match is compared with
+// the ETag of the user's availability list for the event.
 func (h *AvailabilityHandler) Update(c *gin.Context) {
 	idStr := c.Param("id") // Changed from eventId to id
 	eventID, err := uuid.Parse(idStr)
@@ -68,16 +87,16 @@ func (h *AvailabilityHandler) Update(c *gin.Context) {
 	}
 
 	req.UserID = userID
-	availability, err := h.availabilityService.UpdateAvailability(c.Request.Context(), eventID, &req)
+	availability, err := h.availabilityService.UpdateAvailability(c.Request.Context(), eventID, &req, precondition(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, availability)
 }
 
-// Delete removes an availability record
+// Delete removes an availability record, honoring If-Match
 func (h *AvailabilityHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -86,14 +105,32 @@ func (h *AvailabilityHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.availabilityService.DeleteAvailability(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.availabilityService.DeleteAvailability(c.Request.Context(), id, precondition(c)); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// Restore brings back a deleted availability record
+func (h *AvailabilityHandler) Restore(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid availability ID"})
+		return
+	}
+
+	availability, err := h.availabilityService.RestoreAvailability(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	respondWithETag(c, availability.ETag(), availability)
+}
+
 // GetUserAvailability retrieves all availability records for a user and event
 func (h *AvailabilityHandler) GetUserAvailability(c *gin.Context) {
 	idStr := c.Param("id") // Changed from eventId to id
@@ -116,10 +153,10 @@ func (h *AvailabilityHandler) GetUserAvailability(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"availabilities": availabilities})
+	respondWithETag(c, models.AvailabilityListETag(availabilities), gin.H{"availabilities": availabilities})
 }
 
-// GetEventAvailability retrieves all availability records for an event
+// GetEventAvailability retrieves a page of the availability records for an event
 func (h *AvailabilityHandler) GetEventAvailability(c *gin.Context) {
 	idStr := c.Param("id") // Changed from eventId to id
 	eventID, err := uuid.Parse(idStr)
@@ -128,11 +165,16 @@ func (h *AvailabilityHandler) GetEventAvailability(c *gin.Context) {
 		return
 	}
 
-	availabilities, err := h.availabilityService.GetEventAvailability(c.Request.Context(), eventID)
+	page, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	availabilities, info, err := h.availabilityService.GetEventAvailability(c.Request.Context(), eventID, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"availabilities": availabilities})
+	c.JSON(http.StatusOK, gin.H{"availabilities": availabilities, "page": info})
 }
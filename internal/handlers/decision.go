@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// DecisionHandler handles HTTP requests related to response deadline decisions
+type DecisionHandler struct {
+	scheduler *service.DeadlineScheduler
+}
+
+// NewDecisionHandler creates a new DecisionHandler
+func NewDecisionHandler(scheduler *service.DeadlineScheduler) *DecisionHandler {
+	return &DecisionHandler{
+		scheduler: scheduler,
+	}
+}
+
+// List returns the decisions made for an event when its response deadline passed
+func (h *DecisionHandler) List(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	decisions, err := h.scheduler.ListDecisions(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"decisions": decisions})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+)
+
+// statusForError maps service errors to HTTP status codes
+func statusForError(err error) int {
+	switch {
+	case stderrors.Is(err, errors.ErrEventNotFound),
+		stderrors.Is(err, errors.ErrTimeSlotNotFound),
+		stderrors.Is(err, errors.ErrAvailabilityNotFound),
+		stderrors.Is(err, errors.ErrUserNotFound),
+		stderrors.Is(err, errors.ErrParticipantNotFound),
+		stderrors.Is(err, errors.ErrWebhookSubscriptionNotFound),
+		stderrors.Is(err, errors.ErrWebhookDeliveryNotFound),
+		stderrors.Is(err, errors.ErrJobNotFound),
+		stderrors.Is(err, errors.ErrShareLinkNotFound),
+		stderrors.Is(err, errors.ErrGuestNotFound),
+		stderrors.Is(err, errors.ErrVoteNotFound),
+		stderrors.Is(err, errors.ErrResourceNotFound),
+		stderrors.Is(err, errors.ErrResourceBookingNotFound),
+		stderrors.Is(err, errors.ErrGroupNotFound),
+		stderrors.Is(err, errors.ErrGroupMemberNotFound),
+		stderrors.Is(err, errors.ErrEventGroupNotFound),
+		stderrors.Is(err, errors.ErrOrganizationNotFound):
+		return http.StatusNotFound
+	case stderrors.Is(err, errors.ErrInvalidStatusTransition),
+		stderrors.Is(err, errors.ErrParticipantExists),
+		stderrors.Is(err, errors.ErrResourceUnavailable),
+		stderrors.Is(err, errors.ErrGroupMemberExists),
+		stderrors.Is(err, errors.ErrGroupCycle),
+		stderrors.Is(err, errors.ErrEventGroupExists),
+		stderrors.Is(err, errors.ErrUserEmailTaken):
+		return http.StatusConflict
+	case stderrors.Is(err, errors.ErrInvalidWebhookSubscription),
+		stderrors.Is(err, errors.ErrInvalidWebhookURL),
+		stderrors.Is(err, errors.ErrInvalidQuorum),
+		stderrors.Is(err, errors.ErrInvalidGroupMember),
+		stderrors.Is(err, errors.ErrInvalidShareLinkExpiry),
+		stderrors.Is(err, errors.ErrInvalidTokenExpiry),
+		stderrors.Is(err, errors.ErrInvalidCursor),
+		stderrors.Is(err, errors.ErrInvalidSort),
+		stderrors.Is(err, errors.ErrInvalidTimeRange):
+		return http.StatusBadRequest
+	case stderrors.Is(err, errors.ErrInvalidShareToken),
+		stderrors.Is(err, errors.ErrInvalidToken),
+		stderrors.Is(err, errors.ErrTokenExpired):
+		return http.StatusUnauthorized
+	case stderrors.Is(err, errors.ErrShareLinkExpired):
+		return http.StatusGone
+	case stderrors.Is(err, errors.ErrStaleVersion):
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
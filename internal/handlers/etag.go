@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+)
+
+// precondition reads the If-Match header of a write. Weak tags never match,
+// since versions are compared strongly.
+func precondition(c *gin.Context) models.Precondition {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return models.Precondition{}
+	}
+	return models.Precondition{IfMatch: entityTags(header)}
+}
+
+// respondWithETag writes a representation with its entity tag, or 304 Not
+// Modified when the If-None-Match header of a GET already names that tag
+func respondWithETag(c *gin.Context, etag string, body interface{}) {
+	c.Header("ETag", etag)
+	if c.Request.Method == http.MethodGet {
+		if header := c.GetHeader("If-None-Match"); header != "" {
+			for _, tag := range entityTags(header) {
+				// If-None-Match compares weakly
+				if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+					c.Status(http.StatusNotModified)
+					return
+				}
+			}
+		}
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// entityTags splits the comma-separated entity tags of a conditional header
+func entityTags(header string) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
 	"github.com/npkanaka/meeting-scheduler/internal/models"
 	"github.com/npkanaka/meeting-scheduler/internal/service"
 )
@@ -29,11 +32,14 @@ func (h *EventHandler) Create(c *gin.Context) {
 		return
 	}
 
-	// In a real app, you'd get the user ID from authentication
-	// For this exercise, we'll use a placeholder user ID
-	creatorID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	// The authenticated caller organizes the event
+	principal, ok := auth.PrincipalFrom(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "bearer token required"})
+		return
+	}
 
-	event, err := h.eventService.CreateEvent(c.Request.Context(), &req, creatorID)
+	event, err := h.eventService.CreateEvent(c.Request.Context(), &req, principal.UserID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -57,10 +63,10 @@ func (h *EventHandler) Get(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, event)
+	respondWithETag(c, event.ETag(), event)
 }
 
-// Update updates an existing event
+// Update updates an existing event, honoring If-Match
 func (h *EventHandler) Update(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -75,16 +81,16 @@ func (h *EventHandler) Update(c *gin.Context) {
 		return
 	}
 
-	event, err := h.eventService.UpdateEvent(c.Request.Context(), id, &req)
+	event, err := h.eventService.UpdateEvent(c.Request.Context(), id, &req, precondition(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, event)
+	respondWithETag(c, event.ETag(), event)
 }
 
-// Delete removes an event
+// Delete removes an event, honoring If-Match
 func (h *EventHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -93,25 +99,118 @@ func (h *EventHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.eventService.DeleteEvent(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.eventService.DeleteEvent(c.Request.Context(), id, precondition(c)); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
-// List returns a paginated list of events
+// Restore brings back a deleted event with its time slots and availability
+func (h *EventHandler) Restore(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	event, err := h.eventService.RestoreEvent(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	respondWithETag(c, event.ETag(), event)
+}
+
+// List returns a page of events, narrowed by the query parameters
 func (h *EventHandler) List(c *gin.Context) {
-	// Default pagination values
-	limit := 10
-	offset := 0
+	var filter models.EventFilter
+	var ok bool
+	if filter.Page, ok = pageRequest(c); !ok {
+		return
+	}
+	if status := c.Query("status"); status != "" {
+		for _, s := range strings.Split(status, ",") {
+			switch s := models.EventStatus(strings.TrimSpace(s)); s {
+			case models.EventStatusDraft, models.EventStatusActive, models.EventStatusCanceled, models.EventStatusFinalized:
+				filter.Status = append(filter.Status, s)
+			default:
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status " + string(s)})
+				return
+			}
+		}
+	}
+	if filter.CreatorID, ok = optionalUUIDQuery(c, "creator_id"); !ok {
+		return
+	}
+	if filter.CreatedFrom, ok = optionalTimeQuery(c, "created_from"); !ok {
+		return
+	}
+	if filter.CreatedTo, ok = optionalTimeQuery(c, "created_to"); !ok {
+		return
+	}
+	filter.Title = c.Query("q")
+	if c.Query("participating") == "true" {
+		principal, _ := auth.PrincipalFrom(c.Request.Context())
+		filter.ParticipantID = &principal.UserID
+	}
 
-	events, err := h.eventService.ListEvents(c.Request.Context(), limit, offset)
+	events, page, err := h.eventService.ListEvents(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events, "page": page})
+}
+
+// Publish moves a draft event to active
+func (h *EventHandler) Publish(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	event, err := h.eventService.PublishEvent(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// Result counts of a search
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// Search returns the events matching the q query parameter, best match first
+func (h *EventHandler) Search(c *gin.Context) {
+	query := models.SearchQuery{Text: strings.TrimSpace(c.Query("q")), Limit: defaultSearchLimit}
+	if query.Text == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if query.Limit, err = strconv.Atoi(limitStr); err != nil || query.Limit <= 0 || query.Limit > maxSearchLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+			return
+		}
+	}
+
+	results, err := h.eventService.SearchEvents(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"events": events})
+	c.JSON(http.StatusOK, gin.H{"results": results})
 }
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/npkanaka/meeting-scheduler/internal/graphqlapi"
+)
+
+// GraphQLHandler handles GraphQL queries
+type GraphQLHandler struct {
+	executor *graphqlapi.Executor
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler
+func NewGraphQLHandler(executor *graphqlapi.Executor) *GraphQLHandler {
+	return &GraphQLHandler{
+		executor: executor,
+	}
+}
+
+// Query runs a GraphQL query. Requests rejected before execution, such as
+// invalid queries or queries over the depth and complexity limits, get 400;
+// results of executed queries get 200, with any field errors in "errors".
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req graphqlapi.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result := h.executor.Execute(c.Request.Context(), req)
+	if result.Data == nil && result.HasErrors() {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// GroupHandler handles HTTP requests related to groups and their invitations to events
+type GroupHandler struct {
+	groupService *service.GroupService
+}
+
+// NewGroupHandler creates a new GroupHandler
+func NewGroupHandler(groupService *service.GroupService) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+// Create creates a new group
+func (h *GroupHandler) Create(c *gin.Context) {
+	var req models.GroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := h.groupService.CreateGroup(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+// List returns all groups
+func (h *GroupHandler) List(c *gin.Context) {
+	groups, err := h.groupService.ListGroups(c.Request.Context())
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"groups": groups})
+}
+
+// Get returns a group with its direct members
+func (h *GroupHandler) Get(c *gin.Context) {
+	id, ok := groupID(c)
+	if !ok {
+		return
+	}
+
+	group, err := h.groupService.GetGroup(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// Update changes a group's name and description
+func (h *GroupHandler) Update(c *gin.Context) {
+	id, ok := groupID(c)
+	if !ok {
+		return
+	}
+
+	var req models.GroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := h.groupService.UpdateGroup(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// Delete removes a group
+func (h *GroupHandler) Delete(c *gin.Context) {
+	id, ok := groupID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.groupService.DeleteGroup(c.Request.Context(), id); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddMember adds a user or a nested group to a group
+func (h *GroupHandler) AddMember(c *gin.Context) {
+	id, ok := groupID(c)
+	if !ok {
+		return
+	}
+
+	var req models.GroupMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := h.groupService.AddMember(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// RemoveMember removes a member from a group
+func (h *GroupHandler) RemoveMember(c *gin.Context) {
+	id, ok := groupID(c)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(c.Param("memberId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group member ID"})
+		return
+	}
+
+	if err := h.groupService.RemoveMember(c.Request.Context(), id, memberID); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Invite invites a group to an event
+func (h *GroupHandler) Invite(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	var req models.InviteGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	eventGroup, err := h.groupService.InviteGroup(c.Request.Context(), eventID, &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, eventGroup)
+}
+
+// ListEventGroups returns the groups invited to an event
+func (h *GroupHandler) ListEventGroups(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	groups, err := h.groupService.ListEventGroups(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"groups": groups})
+}
+
+// Uninvite withdraws a group's invitation to an event
+func (h *GroupHandler) Uninvite(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+	invitedID, err := uuid.Parse(c.Param("groupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return
+	}
+
+	if err := h.groupService.UninviteGroup(c.Request.Context(), eventID, invitedID); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// groupID parses the group ID of a group route
+func groupID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -1,24 +1,58 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/npkanaka/meeting-scheduler/internal/buildinfo"
+	"github.com/npkanaka/meeting-scheduler/internal/config"
 	"gorm.io/gorm"
 )
 
+// requiredTables lists the tables created by the migrations; a missing table
+// means migrations have not been applied yet
+var requiredTables = []string{
+	"users", "events", "time_slots", "availabilities",
+	"outbox_messages", "webhook_subscriptions", "webhook_deliveries",
+	"event_participants", "notifications", "event_decisions",
+	"jobs", "job_schedules", "share_links", "guests", "votes",
+	"resources", "resource_bookings", "groups", "group_members", "event_groups",
+	"organizations", "audit_entries", "idempotency_records",
+}
+
+// requiredColumns lists columns later migrations added to existing tables; a
+// missing column means those migrations have not been applied yet
+var requiredColumns = []struct{ table, column string }{
+	{"events", "organization_id"}, {"events", "version"}, {"events", "deleted_at"}, {"events", "search_vector"},
+	{"time_slots", "organization_id"}, {"time_slots", "version"}, {"time_slots", "deleted_at"},
+	{"availabilities", "organization_id"}, {"availabilities", "version"}, {"availabilities", "deleted_at"},
+	{"users", "organization_id"}, {"groups", "organization_id"}, {"resources", "organization_id"},
+	{"webhook_subscriptions", "organization_id"}, {"audit_entries", "organization_id"},
+}
+
 // HealthHandler handles health check requests
 type HealthHandler struct {
-	db *gorm.DB
+	db  *gorm.DB
+	cfg *config.Config
 }
 
 // NewHealthHandler creates a new HealthHandler
-func NewHealthHandler(db *gorm.DB) *HealthHandler {
+func NewHealthHandler(db *gorm.DB, cfg *config.Config) *HealthHandler {
 	return &HealthHandler{
-		db: db,
+		db:  db,
+		cfg: cfg,
 	}
 }
 
+// dependencyStatus describes the state of a single dependency
+type dependencyStatus struct {
+	Status  string                 `json:"status"`
+	Message string                 `json:"message,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
 // Check handles the health check request
 func (h *HealthHandler) Check(c *gin.Context) {
 	// Check database connection
@@ -36,3 +70,139 @@ func (h *HealthHandler) Check(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "OK"})
 }
+
+// Live reports whether the process is running. It has no dependencies so that
+// a database outage does not get the container restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "OK"})
+}
+
+// Ready reports whether the instance can serve traffic: the database answers
+// quickly, migrations are applied and the connection pool is not saturated
+func (h *HealthHandler) Ready(c *gin.Context) {
+	checks := h.checkDatabase(c.Request.Context())
+
+	status := http.StatusOK
+	overall := "OK"
+	for _, check := range checks {
+		if check.Status != "OK" {
+			status = http.StatusServiceUnavailable
+			overall = "error"
+		}
+	}
+
+	c.JSON(status, gin.H{"status": overall, "checks": checks})
+}
+
+// Details reports build, uptime, configuration and dependency information for operators
+func (h *HealthHandler) Details(c *gin.Context) {
+	checks := h.checkDatabase(c.Request.Context())
+
+	overall := "OK"
+	for _, check := range checks {
+		if check.Status != "OK" {
+			overall = "error"
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": overall,
+		"build": gin.H{
+			"version":    buildinfo.Version,
+			"git_commit": buildinfo.Commit(),
+		},
+		"started_at":   buildinfo.StartTime().UTC(),
+		"uptime":       buildinfo.Uptime().Round(time.Second).String(),
+		"config":       h.cfg.Summary(),
+		"dependencies": checks,
+	})
+}
+
+// checkDatabase runs the database readiness checks
+func (h *HealthHandler) checkDatabase(ctx context.Context) map[string]dependencyStatus {
+	checks := make(map[string]dependencyStatus)
+
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		checks["database"] = dependencyStatus{Status: "error", Message: "Database connection error"}
+		return checks
+	}
+
+	// Ping latency
+	start := time.Now()
+	err = sqlDB.PingContext(ctx)
+	latency := time.Since(start)
+	switch {
+	case err != nil:
+		checks["database"] = dependencyStatus{Status: "error", Message: "Database ping failed"}
+		return checks
+	case latency > h.cfg.Health.MaxDBLatency:
+		checks["database"] = dependencyStatus{
+			Status:  "degraded",
+			Message: "Database ping is slow",
+			Details: map[string]interface{}{"latency": latency.String()},
+		}
+	default:
+		checks["database"] = dependencyStatus{
+			Status:  "OK",
+			Details: map[string]interface{}{"latency": latency.String()},
+		}
+	}
+
+	// Pending migrations
+	var missingTables, missingColumns []string
+	migrator := h.db.WithContext(ctx).Migrator()
+	absent := make(map[string]bool)
+	for _, table := range requiredTables {
+		if !migrator.HasTable(table) {
+			missingTables = append(missingTables, table)
+			absent[table] = true
+		}
+	}
+	for _, required := range requiredColumns {
+		if !absent[required.table] && !migrator.HasColumn(required.table, required.column) {
+			missingColumns = append(missingColumns, required.table+"."+required.column)
+		}
+	}
+	if len(missingTables) > 0 || len(missingColumns) > 0 {
+		details := make(map[string]interface{})
+		if len(missingTables) > 0 {
+			details["missing_tables"] = missingTables
+		}
+		if len(missingColumns) > 0 {
+			details["missing_columns"] = missingColumns
+		}
+		checks["migrations"] = dependencyStatus{
+			Status:  "error",
+			Message: "Migrations are pending",
+			Details: details,
+		}
+	} else {
+		checks["migrations"] = dependencyStatus{Status: "OK"}
+	}
+
+	// Pool saturation
+	stats := sqlDB.Stats()
+	saturation := 0.0
+	if stats.MaxOpenConnections > 0 {
+		saturation = float64(stats.InUse) / float64(stats.MaxOpenConnections)
+	}
+	pool := dependencyStatus{
+		Status: "OK",
+		Details: map[string]interface{}{
+			"open":       stats.OpenConnections,
+			"in_use":     stats.InUse,
+			"idle":       stats.Idle,
+			"max_open":   stats.MaxOpenConnections,
+			"wait_count": stats.WaitCount,
+			"saturation": saturation,
+		},
+	}
+	if saturation >= h.cfg.Health.MaxPoolSaturation {
+		pool.Status = "degraded"
+		pool.Message = "Connection pool is saturated"
+	}
+	checks["connection_pool"] = pool
+
+	return checks
+}
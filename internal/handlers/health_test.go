@@ -0,0 +1,247 @@
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/npkanaka/meeting-scheduler/internal/config"
+	"github.com/npkanaka/meeting-scheduler/internal/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeDatabase is a database/sql driver standing in for Postgres. Pings take
+// pingDelay and fail with pingErr, and the information_schema lookups of the
+// migrator find every table and column except the missing ones.
+type fakeDatabase struct {
+	pingDelay time.Duration
+	pingErr   error
+	missing   map[string]bool
+}
+
+func (f *fakeDatabase) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDatabase) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("use the connector") }
+
+type fakeConn struct {
+	db *fakeDatabase
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeConn) Ping(ctx context.Context) error {
+	time.Sleep(c.db.pingDelay)
+	return c.db.pingErr
+}
+
+// QueryContext answers the count queries of Migrator.HasTable, keyed by
+// table, and Migrator.HasColumn, keyed by "table.column"
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	count := int64(1)
+	if len(args) > 0 {
+		name := args[0].Value.(string)
+		if strings.Contains(query, "columns") {
+			name += "." + args[1].Value.(string)
+		}
+		if c.db.missing[name] {
+			count = 0
+		}
+	}
+	return &countRows{count: count}, nil
+}
+
+type countRows struct {
+	count int64
+	done  bool
+}
+
+func (r *countRows) Columns() []string { return []string{"count"} }
+func (r *countRows) Close() error      { return nil }
+
+func (r *countRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.count
+	return nil
+}
+
+// newHealthHandler returns a HealthHandler over fake and its pool
+func newHealthHandler(t *testing.T, fake *fakeDatabase, cfg *config.Config) (*handlers.HealthHandler, *sql.DB) {
+	t.Helper()
+	sqlDB := sql.OpenDB(fake)
+	t.Cleanup(func() { sqlDB.Close() })
+	sqlDB.SetMaxOpenConns(2)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+	return handlers.NewHealthHandler(db, cfg), sqlDB
+}
+
+// healthConfig returns a configuration with the default readiness thresholds
+func healthConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Health.MaxDBLatency = 500 * time.Millisecond
+	cfg.Health.MaxPoolSaturation = 0.9
+	return cfg
+}
+
+// healthResponse is the body of the readiness and details endpoints
+type healthResponse struct {
+	Status       string                     `json:"status"`
+	Checks       map[string]dependencyCheck `json:"checks"`
+	Dependencies map[string]dependencyCheck `json:"dependencies"`
+}
+
+type dependencyCheck struct {
+	Status  string                 `json:"status"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details"`
+}
+
+func getHealth(t *testing.T, handler gin.HandlerFunc) (int, healthResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var body healthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w.Code, body
+}
+
+func TestReadyWhenEveryCheckPasses(t *testing.T) {
+	handler, _ := newHealthHandler(t, &fakeDatabase{}, healthConfig())
+
+	code, body := getHealth(t, handler.Ready)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "OK", body.Status)
+	for _, name := range []string{"database", "migrations", "connection_pool"} {
+		assert.Equal(t, "OK", body.Checks[name].Status, name)
+	}
+}
+
+func TestReadyReportsDegradedDependencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		fake      *fakeDatabase
+		configure func(cfg *config.Config)
+		holdConn  bool // Keep one of the two pool connections busy during the check
+		check     string
+		status    string
+		message   string
+	}{
+		{
+			name:    "unreachable database",
+			fake:    &fakeDatabase{pingErr: errors.New("connection refused")},
+			check:   "database",
+			status:  "error",
+			message: "Database ping failed",
+		},
+		{
+			name:      "slow database",
+			fake:      &fakeDatabase{pingDelay: 20 * time.Millisecond},
+			configure: func(cfg *config.Config) { cfg.Health.MaxDBLatency = time.Millisecond },
+			check:     "database",
+			status:    "degraded",
+			message:   "Database ping is slow",
+		},
+		{
+			name:    "pending migrations",
+			fake:    &fakeDatabase{missing: map[string]bool{"availabilities": true}},
+			check:   "migrations",
+			status:  "error",
+			message: "Migrations are pending",
+		},
+		{
+			name:      "saturated pool",
+			fake:      &fakeDatabase{},
+			configure: func(cfg *config.Config) { cfg.Health.MaxPoolSaturation = 0.5 },
+			holdConn:  true,
+			check:     "connection_pool",
+			status:    "degraded",
+			message:   "Connection pool is saturated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := healthConfig()
+			if tt.configure != nil {
+				tt.configure(cfg)
+			}
+			handler, sqlDB := newHealthHandler(t, tt.fake, cfg)
+			if tt.holdConn {
+				conn, err := sqlDB.Conn(context.Background())
+				require.NoError(t, err)
+				defer conn.Close()
+			}
+
+			code, body := getHealth(t, handler.Ready)
+
+			assert.Equal(t, http.StatusServiceUnavailable, code)
+			assert.Equal(t, "error", body.Status)
+			assert.Equal(t, tt.status, body.Checks[tt.check].Status)
+			assert.Equal(t, tt.message, body.Checks[tt.check].Message)
+		})
+	}
+}
+
+func TestReadyListsMissingTables(t *testing.T) {
+	fake := &fakeDatabase{missing: map[string]bool{"time_slots": true, "availabilities": true}}
+	handler, _ := newHealthHandler(t, fake, healthConfig())
+
+	_, body := getHealth(t, handler.Ready)
+
+	assert.ElementsMatch(t, []interface{}{"availabilities", "time_slots"}, body.Checks["migrations"].Details["missing_tables"])
+}
+
+func TestReadyListsMissingColumns(t *testing.T) {
+	fake := &fakeDatabase{missing: map[string]bool{"events.search_vector": true, "time_slots.deleted_at": true}}
+	handler, _ := newHealthHandler(t, fake, healthConfig())
+
+	code, body := getHealth(t, handler.Ready)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "Migrations are pending", body.Checks["migrations"].Message)
+	assert.ElementsMatch(t, []interface{}{"events.search_vector", "time_slots.deleted_at"}, body.Checks["migrations"].Details["missing_columns"])
+	assert.NotContains(t, body.Checks["migrations"].Details, "missing_tables")
+}
+
+func TestDetailsReportDegradedDependenciesWithoutFailing(t *testing.T) {
+	fake := &fakeDatabase{missing: map[string]bool{"events": true}}
+	handler, _ := newHealthHandler(t, fake, healthConfig())
+
+	// Operators still get the details of an instance that is not ready
+	code, body := getHealth(t, handler.Details)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "error", body.Status)
+	assert.Equal(t, "error", body.Dependencies["migrations"].Status)
+}
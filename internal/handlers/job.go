@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/jobs"
+)
+
+// JobHandler handles administrative requests about background jobs
+type JobHandler struct {
+	runner *jobs.Runner
+}
+
+// NewJobHandler creates a new JobHandler
+func NewJobHandler(runner *jobs.Runner) *JobHandler {
+	return &JobHandler{
+		runner: runner,
+	}
+}
+
+// ListDead returns jobs that used up their attempts
+func (h *JobHandler) ListDead(c *gin.Context) {
+	limit := 50
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit <= 0 || limit > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+	}
+
+	deadJobs, err := h.runner.ListDead(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"jobs": deadJobs})
+}
+
+// Retry moves a dead job back to the queue
+func (h *JobHandler) Retry(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid job ID"})
+		return
+	}
+
+	job, err := h.runner.Retry(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// OrganizationHandler handles administrative requests about organizations,
+// their users and bearer tokens
+type OrganizationHandler struct {
+	organizationService *service.OrganizationService
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler
+func NewOrganizationHandler(organizationService *service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+	}
+}
+
+// Create creates a new organization
+func (h *OrganizationHandler) Create(c *gin.Context) {
+	var req models.OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	organization, err := h.organizationService.CreateOrganization(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, organization)
+}
+
+// List returns all organizations
+func (h *OrganizationHandler) List(c *gin.Context) {
+	organizations, err := h.organizationService.ListOrganizations(c.Request.Context())
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"organizations": organizations})
+}
+
+// CreateUser adds a user to an organization
+func (h *OrganizationHandler) CreateUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		return
+	}
+
+	var req models.CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.organizationService.CreateUser(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// IssueToken signs a bearer token acting for a user and their organization
+func (h *OrganizationHandler) IssueToken(c *gin.Context) {
+	var req models.TokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := h.organizationService.IssueToken(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+)
+
+// Page sizes of listings
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+// pageRequest parses the limit, cursor and sort query parameters, writing a
+// 400 when the limit is out of range
+func pageRequest(c *gin.Context) (models.PageRequest, bool) {
+	page := models.PageRequest{
+		Limit:  defaultPageLimit,
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if page.Limit, err = strconv.Atoi(limitStr); err != nil || page.Limit <= 0 || page.Limit > maxPageLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return page, false
+		}
+	}
+	return page, true
+}
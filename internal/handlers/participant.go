@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// ParticipantHandler handles HTTP requests related to event participants
+type ParticipantHandler struct {
+	participantService *service.ParticipantService
+}
+
+// NewParticipantHandler creates a new ParticipantHandler
+func NewParticipantHandler(participantService *service.ParticipantService) *ParticipantHandler {
+	return &ParticipantHandler{
+		participantService: participantService,
+	}
+}
+
+// Add invites a user to an event
+func (h *ParticipantHandler) Add(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	var req models.AddParticipantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	participant, err := h.participantService.AddParticipant(c.Request.Context(), eventID, &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, participant)
+}
+
+// List returns the participants of an event
+func (h *ParticipantHandler) List(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	participants, err := h.participantService.ListParticipants(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"participants": participants})
+}
+
+// Remove removes a user from an event
+func (h *ParticipantHandler) Remove(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	userIDStr := c.Param("userId")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	if err := h.participantService.RemoveParticipant(c.Request.Context(), eventID, userID); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
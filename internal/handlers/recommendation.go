@@ -29,9 +29,18 @@ func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
 		return
 	}
 
-	recommendations, err := h.recommendationService.GetRecommendations(c.Request.Context(), eventID)
+	// ?quorum=met or ?quorum=unmet keeps only slots with or without quorum
+	filter := service.QuorumFilter(c.Query("quorum"))
+	switch filter {
+	case service.QuorumAny, service.QuorumMet, service.QuorumUnmet:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "quorum must be met or unmet"})
+		return
+	}
+
+	recommendations, err := h.recommendationService.FindRecommendations(c.Request.Context(), eventID, filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// ResourceHandler handles HTTP requests related to the resource catalog and bookings
+type ResourceHandler struct {
+	resourceService *service.ResourceService
+}
+
+// NewResourceHandler creates a new ResourceHandler
+func NewResourceHandler(resourceService *service.ResourceService) *ResourceHandler {
+	return &ResourceHandler{
+		resourceService: resourceService,
+	}
+}
+
+// Create adds a resource to the catalog
+func (h *ResourceHandler) Create(c *gin.Context) {
+	var req models.ResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resource, err := h.resourceService.CreateResource(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resource)
+}
+
+// List returns the resource catalog, optionally filtered by kind
+func (h *ResourceHandler) List(c *gin.Context) {
+	resources, err := h.resourceService.ListResources(c.Request.Context(), models.ResourceKind(c.Query("kind")))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"resources": resources})
+}
+
+// Get retrieves a resource by ID
+func (h *ResourceHandler) Get(c *gin.Context) {
+	id, ok := resourceID(c)
+	if !ok {
+		return
+	}
+
+	resource, err := h.resourceService.GetResource(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resource)
+}
+
+// Update changes a resource
+func (h *ResourceHandler) Update(c *gin.Context) {
+	id, ok := resourceID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resource, err := h.resourceService.UpdateResource(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resource)
+}
+
+// Delete removes a resource
+func (h *ResourceHandler) Delete(c *gin.Context) {
+	id, ok := resourceID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.resourceService.DeleteResource(c.Request.Context(), id); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListBookings returns the bookings of a resource
+func (h *ResourceHandler) ListBookings(c *gin.Context) {
+	id, ok := resourceID(c)
+	if !ok {
+		return
+	}
+
+	bookings, err := h.resourceService.ListBookings(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bookings": bookings})
+}
+
+// Block books a resource for a period outside of any event
+func (h *ResourceHandler) Block(c *gin.Context) {
+	id, ok := resourceID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ResourceBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	booking, err := h.resourceService.BlockResource(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, booking)
+}
+
+// CancelBooking removes a booking
+func (h *ResourceHandler) CancelBooking(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid booking ID"})
+		return
+	}
+
+	if err := h.resourceService.CancelBooking(c.Request.Context(), id); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// resourceID parses the resource ID of a resource route
+func resourceID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid resource ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// ShareHandler handles HTTP requests related to share links and guest responses
+type ShareHandler struct {
+	shareService *service.ShareService
+}
+
+// NewShareHandler creates a new ShareHandler
+func NewShareHandler(shareService *service.ShareService) *ShareHandler {
+	return &ShareHandler{
+		shareService: shareService,
+	}
+}
+
+// Create mints a share link for an event
+func (h *ShareHandler) Create(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	var req models.CreateShareLinkRequest
+	// The body is optional
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	link, err := h.shareService.CreateLink(c.Request.Context(), eventID, &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+// List returns the share links of an event
+func (h *ShareHandler) List(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	links, err := h.shareService.ListLinks(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"share_links": links})
+}
+
+// Revoke stops a share link from being used
+func (h *ShareHandler) Revoke(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+	linkID, err := uuid.Parse(c.Param("linkId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid share link ID"})
+		return
+	}
+
+	if err := h.shareService.RevokeLink(c.Request.Context(), eventID, linkID); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetEvent shows a guest the event and time slots behind a share link
+func (h *ShareHandler) GetEvent(c *gin.Context) {
+	event, err := h.shareService.GetSharedEvent(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// SubmitAvailability records a guest's availability through a share link
+func (h *ShareHandler) SubmitAvailability(c *gin.Context) {
+	var req models.GuestAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.shareService.SubmitAvailability(c.Request.Context(), c.Param("token"), &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
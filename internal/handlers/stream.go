@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// StreamHandler serves live event updates as server-sent events
+type StreamHandler struct {
+	streamService *service.StreamService
+	heartbeat     time.Duration
+}
+
+// NewStreamHandler creates a new StreamHandler that sends a comment every
+// heartbeat to keep idle connections open through proxies
+func NewStreamHandler(streamService *service.StreamService, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{
+		streamService: streamService,
+		heartbeat:     heartbeat,
+	}
+}
+
+// Stream pushes availability, time slot and status changes of an event, and
+// its recommendations whenever they may have changed
+func (h *StreamHandler) Stream(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	updates, err := h.streamService.Subscribe(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	// The stream outlives the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		c.Error(err)
+	}
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return false
+			}
+			c.SSEvent(update.Type, update.Data)
+		case <-heartbeat.C:
+			_, _ = io.WriteString(w, ": heartbeat\n\n")
+		}
+		return true
+	})
+}
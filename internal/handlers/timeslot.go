@@ -60,10 +60,10 @@ func (h *TimeSlotHandler) Get(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, timeSlot)
+	respondWithETag(c, timeSlot.ETag(), timeSlot)
 }
 
-// Update updates an existing time slot
+// Update updates an existing time slot, honoring If-Match
 func (h *TimeSlotHandler) Update(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -78,16 +78,16 @@ func (h *TimeSlotHandler) Update(c *gin.Context) {
 		return
 	}
 
-	timeSlot, err := h.timeSlotService.UpdateTimeSlot(c.Request.Context(), id, &req)
+	timeSlot, err := h.timeSlotService.UpdateTimeSlot(c.Request.Context(), id, &req, precondition(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, timeSlot)
+	respondWithETag(c, timeSlot.ETag(), timeSlot)
 }
 
-// Delete removes a time slot
+// Delete removes a time slot, honoring If-Match
 func (h *TimeSlotHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -96,15 +96,15 @@ func (h *TimeSlotHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.timeSlotService.DeleteTimeSlot(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.timeSlotService.DeleteTimeSlot(c.Request.Context(), id, precondition(c)); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
-// List returns all time slots for an event
+// List returns a page of the time slots for an event
 func (h *TimeSlotHandler) List(c *gin.Context) {
 	idStr := c.Param("id") // Changed from eventId to id
 	eventID, err := uuid.Parse(idStr)
@@ -113,11 +113,52 @@ func (h *TimeSlotHandler) List(c *gin.Context) {
 		return
 	}
 
-	timeSlots, err := h.timeSlotService.GetEventTimeSlots(c.Request.Context(), eventID)
+	page, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	timeSlots, info, err := h.timeSlotService.GetEventTimeSlots(c.Request.Context(), eventID, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"time_slots": timeSlots, "page": info})
+}
+
+// Restore brings back a deleted time slot
+func (h *TimeSlotHandler) Restore(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time slot ID"})
+		return
+	}
+
+	timeSlot, err := h.timeSlotService.RestoreTimeSlot(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	respondWithETag(c, timeSlot.ETag(), timeSlot)
+}
+
+// Finalize picks a time slot as the final meeting time of its event
+func (h *TimeSlotHandler) Finalize(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time slot ID"})
+		return
+	}
+
+	event, err := h.timeSlotService.FinalizeTimeSlot(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"time_slots": timeSlots})
+	c.JSON(http.StatusOK, event)
 }
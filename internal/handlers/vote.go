@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// VoteHandler handles HTTP requests related to time slot votes
+type VoteHandler struct {
+	voteService *service.VoteService
+}
+
+// NewVoteHandler creates a new VoteHandler
+func NewVoteHandler(voteService *service.VoteService) *VoteHandler {
+	return &VoteHandler{
+		voteService: voteService,
+	}
+}
+
+// Cast casts or changes a user's vote on a time slot
+func (h *VoteHandler) Cast(c *gin.Context) {
+	timeSlotID, userID, ok := voteParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.VoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	vote, created, err := h.voteService.CastVote(c.Request.Context(), timeSlotID, userID, &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, vote)
+		return
+	}
+	c.JSON(http.StatusOK, vote)
+}
+
+// Retract removes a user's vote on a time slot
+func (h *VoteHandler) Retract(c *gin.Context) {
+	timeSlotID, userID, ok := voteParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.voteService.RetractVote(c.Request.Context(), timeSlotID, userID); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// List returns the votes cast on the time slots of an event
+func (h *VoteHandler) List(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return
+	}
+
+	votes, err := h.voteService.ListVotes(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"votes": votes})
+}
+
+// voteParams parses the time slot and user IDs of a vote route
+func voteParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	timeSlotID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time slot ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return timeSlotID, userID, true
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+)
+
+// WebhookHandler handles HTTP requests related to webhook subscriptions and deliveries
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// Create registers a webhook subscription
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req models.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// List returns webhook subscriptions, optionally filtered by event_id or creator_id
+func (h *WebhookHandler) List(c *gin.Context) {
+	eventID, ok := optionalUUIDQuery(c, "event_id")
+	if !ok {
+		return
+	}
+	creatorID, ok := optionalUUIDQuery(c, "creator_id")
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.webhookService.ListSubscriptions(c.Request.Context(), eventID, creatorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": subscriptions})
+}
+
+// Delete removes a webhook subscription
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), id); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries returns the delivery log of a webhook subscription
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+
+	limit := 50
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit <= 0 || limit > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), id, limit)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+// Replay queues a logged delivery to be sent again
+func (h *WebhookHandler) Replay(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid delivery ID"})
+		return
+	}
+
+	delivery, err := h.webhookService.ReplayDelivery(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// optionalUUIDQuery parses an optional UUID query parameter, writing a 400 when it is malformed
+func optionalUUIDQuery(c *gin.Context, name string) (*uuid.UUID, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return nil, false
+	}
+	return &id, true
+}
@@ -0,0 +1,144 @@
+package jobs
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+)
+
+// MemoryStore keeps jobs in process memory. Jobs are lost on restart and are
+// not shared between replicas, so it suits tests and single-instance setups.
+type MemoryStore struct {
+	mu        sync.Mutex
+	jobs      map[uuid.UUID]*models.Job
+	schedules map[string]time.Time
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		jobs:      make(map[uuid.UUID]*models.Job),
+		schedules: make(map[string]time.Time),
+	}
+}
+
+// Enqueue saves a new job
+func (s *MemoryStore) Enqueue(ctx context.Context, job *models.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.save(job)
+	return nil
+}
+
+// EnqueueScheduled enqueues the run of a schedule if it is due and the previous run finished
+func (s *MemoryStore) EnqueueScheduled(ctx context.Context, name string, now, next time.Time, job *models.Job) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if nextRun, ok := s.schedules[name]; ok && nextRun.After(now) {
+		return false, nil
+	}
+	s.schedules[name] = next
+	for _, queued := range s.jobs {
+		if queued.Kind == job.Kind && queued.Status != models.JobDead {
+			return false, nil
+		}
+	}
+	s.save(job)
+	return true, nil
+}
+
+// ClaimDue leases a batch of due jobs
+func (s *MemoryStore) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*models.Job
+	for _, job := range s.jobs {
+		if job.Status != models.JobDead && !job.RunAt.After(now) {
+			due = append(due, job)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].RunAt.Before(due[j].RunAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*models.Job, len(due))
+	for i, job := range due {
+		job.Status = models.JobRunning
+		job.Attempts++
+		job.RunAt = now.Add(lease)
+		job.UpdatedAt = now
+		copied := *job
+		claimed[i] = &copied
+	}
+	return claimed, nil
+}
+
+// Complete removes a finished job
+func (s *MemoryStore) Complete(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, id)
+	return nil
+}
+
+// Update saves every field of a job
+func (s *MemoryStore) Update(ctx context.Context, job *models.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.save(job)
+	return nil
+}
+
+// ListDead returns dead jobs, most recently failed first
+func (s *MemoryStore) ListDead(ctx context.Context, limit int) ([]*models.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var dead []*models.Job
+	for _, job := range s.jobs {
+		if job.Status == models.JobDead {
+			copied := *job
+			dead = append(dead, &copied)
+		}
+	}
+	sort.Slice(dead, func(i, j int) bool { return dead[i].UpdatedAt.After(dead[j].UpdatedAt) })
+
+	if len(dead) > limit {
+		dead = dead[:limit]
+	}
+	return dead, nil
+}
+
+// Retry requeues a dead job
+func (s *MemoryStore) Retry(ctx context.Context, id uuid.UUID, now time.Time) (*models.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok || job.Status != models.JobDead {
+		return nil, errors.ErrJobNotFound
+	}
+	job.Status = models.JobPending
+	job.Attempts = 0
+	job.RunAt = now
+	job.UpdatedAt = now
+	copied := *job
+	return &copied, nil
+}
+
+// save stores a copy of job so callers cannot change it behind the lock
+func (s *MemoryStore) save(job *models.Job) {
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	copied := *job
+	s.jobs[job.ID] = &copied
+}
@@ -0,0 +1,359 @@
+// Package jobs runs background work: one-off jobs with retries and
+// dead-lettering, and recurring jobs on cron or interval schedules. Jobs are
+// kept in a JobRepository, either the Postgres jobs table, which several
+// replicas can share, or the in-memory store.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/telemetry"
+	"github.com/npkanaka/meeting-scheduler/pkg/timeutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config configures a Runner
+type Config struct {
+	PollInterval   time.Duration // How often the store is checked for due jobs
+	Concurrency    int           // Jobs run at the same time
+	Timeout        time.Duration // Limit on a single attempt
+	Lease          time.Duration // How long a claimed job is reserved; must exceed Timeout
+	MaxAttempts    int           // Default attempts before a job is dead-lettered
+	InitialBackoff time.Duration // Doubled after every failed attempt
+	MaxBackoff     time.Duration
+}
+
+// HandlerFunc processes one attempt of a job
+type HandlerFunc func(ctx context.Context, job *models.Job) error
+
+// Option changes a job before it is enqueued
+type Option func(job *models.Job)
+
+// RunAt delays a job until t
+func RunAt(t time.Time) Option {
+	return func(job *models.Job) { job.RunAt = t }
+}
+
+// MaxAttempts overrides the number of attempts before a job is dead-lettered
+func MaxAttempts(n int) Option {
+	return func(job *models.Job) { job.MaxAttempts = n }
+}
+
+type scheduled struct {
+	kind     string
+	schedule Schedule
+	next     time.Time // Local estimate, so the store is only asked when a run may be due
+}
+
+// Runner claims due jobs from the store and hands them to their handlers
+type Runner struct {
+	store repository.JobRepository
+	cfg   Config
+
+	mu        sync.Mutex
+	handlers  map[string]HandlerFunc
+	schedules []*scheduled
+	started   bool
+
+	wake     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	inflight sync.WaitGroup
+	slots    chan struct{}
+	// cancelJobs aborts running jobs when draining takes too long
+	cancelJobs context.CancelFunc
+}
+
+// NewRunner creates a new Runner
+func NewRunner(store repository.JobRepository, cfg Config) *Runner {
+	return &Runner{
+		store:    store,
+		cfg:      cfg,
+		handlers: make(map[string]HandlerFunc),
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		slots:    make(chan struct{}, cfg.Concurrency),
+	}
+}
+
+// Handle registers the handler of a job kind
+func (r *Runner) Handle(kind string, handler HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[kind] = handler
+}
+
+// Register registers a typed handler whose payload is decoded from JSON
+func Register[T any](r *Runner, kind string, handler func(ctx context.Context, payload T) error) {
+	r.Handle(kind, func(ctx context.Context, job *models.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("decoding %s payload: %w", kind, err)
+		}
+		return handler(ctx, payload)
+	})
+}
+
+// Enqueue adds a job of a registered kind. Called with a transaction context,
+// the job commits together with the transaction.
+func (r *Runner) Enqueue(ctx context.Context, kind string, payload interface{}, opts ...Option) (*models.Job, error) {
+	job, err := r.newJob(kind, payload, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	r.notify()
+	return job, nil
+}
+
+// Schedule runs a registered job kind on a schedule. Each run is enqueued
+// once across all replicas sharing the store, and skipped while an earlier
+// job of the kind is still pending or running.
+func (r *Runner) Schedule(kind string, schedule Schedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.handlers[kind]; !ok {
+		return fmt.Errorf("no handler registered for job kind %q", kind)
+	}
+	r.schedules = append(r.schedules, &scheduled{kind: kind, schedule: schedule})
+	return nil
+}
+
+// Every runs task as a recurring job of the given kind every interval
+func (r *Runner) Every(kind string, interval time.Duration, task func(ctx context.Context) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[kind] = func(ctx context.Context, _ *models.Job) error { return task(ctx) }
+	r.schedules = append(r.schedules, &scheduled{kind: kind, schedule: Every(interval)})
+}
+
+// ListDead returns jobs that used up their attempts, most recently failed first
+func (r *Runner) ListDead(ctx context.Context, limit int) ([]*models.Job, error) {
+	return r.store.ListDead(ctx, limit)
+}
+
+// Retry moves a dead job back to the queue with fresh attempts
+func (r *Runner) Retry(ctx context.Context, id uuid.UUID) (*models.Job, error) {
+	job, err := r.store.Retry(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	r.notify()
+	return job, nil
+}
+
+// Run enqueues scheduled jobs and runs due jobs until ctx is canceled or
+// Shutdown is called. Jobs still running when it returns are drained by Shutdown.
+func (r *Runner) Run(ctx context.Context) {
+	r.mu.Lock()
+	r.started = true
+	// Running jobs outlive ctx so a canceled Run still drains
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	r.cancelJobs = cancel
+	r.mu.Unlock()
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		r.enqueueScheduled(ctx, now)
+		if err := r.claim(jobCtx, now); err != nil {
+			log.Printf("claiming jobs failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// Shutdown stops claiming jobs and waits for running ones to finish. If ctx
+// expires first, running jobs are canceled and its error is returned.
+func (r *Runner) Shutdown(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stop) })
+
+	r.mu.Lock()
+	started := r.started
+	r.mu.Unlock()
+	if !started {
+		return nil
+	}
+	<-r.done
+
+	drained := make(chan struct{})
+	go func() {
+		r.inflight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		r.cancelJobs()
+		return nil
+	case <-ctx.Done():
+		r.cancelJobs()
+		<-drained
+		return ctx.Err()
+	}
+}
+
+// notify wakes Run to look for work without waiting for the next poll
+func (r *Runner) notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Runner) newJob(kind string, payload interface{}, opts ...Option) (*models.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s payload: %w", kind, err)
+	}
+
+	now := time.Now()
+	job := &models.Job{
+		Kind:        kind,
+		Payload:     data,
+		Status:      models.JobPending,
+		MaxAttempts: r.cfg.MaxAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	return job, nil
+}
+
+// enqueueScheduled enqueues the runs of schedules that are due
+func (r *Runner) enqueueScheduled(ctx context.Context, now time.Time) {
+	r.mu.Lock()
+	schedules := r.schedules
+	r.mu.Unlock()
+
+	for _, s := range schedules {
+		if now.Before(s.next) {
+			continue
+		}
+		next := s.schedule.Next(now)
+		job, err := r.newJob(s.kind, struct{}{}, MaxAttempts(1))
+		if err != nil {
+			log.Printf("scheduling %s failed: %v", s.kind, err)
+			continue
+		}
+		// A failed scheduled run is retried by the next run rather than
+		// rescheduled, so it gets a single attempt
+		if _, err := r.store.EnqueueScheduled(ctx, s.kind, now, next, job); err != nil {
+			log.Printf("scheduling %s failed: %v", s.kind, err)
+			continue
+		}
+		s.next = next
+	}
+}
+
+// claim leases as many due jobs as there are free slots and starts them
+func (r *Runner) claim(ctx context.Context, now time.Time) error {
+	free := cap(r.slots) - len(r.slots)
+	if free == 0 {
+		return nil
+	}
+
+	jobs, err := r.store.ClaimDue(ctx, now, r.cfg.Lease, free)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		r.slots <- struct{}{}
+		r.inflight.Add(1)
+		go func(job *models.Job) {
+			defer func() {
+				<-r.slots
+				r.inflight.Done()
+				// A slot is free, so more work may be claimable
+				r.notify()
+			}()
+			r.process(ctx, job)
+		}(job)
+	}
+	return nil
+}
+
+// process runs one attempt of a job and records the outcome
+func (r *Runner) process(ctx context.Context, job *models.Job) {
+	ctx, span := telemetry.Tracer().Start(ctx, "jobs.process", trace.WithAttributes(
+		attribute.String("job.id", job.ID.String()),
+		attribute.String("job.kind", job.Kind),
+		attribute.Int("job.attempt", job.Attempts),
+	))
+	defer span.End()
+
+	err := r.run(ctx, job)
+
+	// Record the outcome even if the job was canceled during a drain
+	storeCtx := context.WithoutCancel(ctx)
+	if err == nil {
+		if err := r.store.Complete(storeCtx, job.ID); err != nil {
+			log.Printf("completing job %s failed: %v", job.ID, err)
+		}
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	now := time.Now()
+	job.LastError = err.Error()
+	job.UpdatedAt = now
+	if job.Attempts >= job.MaxAttempts {
+		job.Status = models.JobDead
+		log.Printf("job %s (%s) dead after %d attempts: %v", job.ID, job.Kind, job.Attempts, err)
+	} else {
+		job.Status = models.JobPending
+		job.RunAt = now.Add(timeutil.Backoff(job.Attempts, r.cfg.InitialBackoff, r.cfg.MaxBackoff))
+	}
+	if err := r.store.Update(storeCtx, job); err != nil {
+		log.Printf("recording failure of job %s failed: %v", job.ID, err)
+	}
+}
+
+// run calls the handler of a job, turning panics into errors
+func (r *Runner) run(ctx context.Context, job *models.Job) (err error) {
+	r.mu.Lock()
+	handler, ok := r.handlers[job.Kind]
+	r.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no handler registered for job kind %q", job.Kind)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+	return handler(ctx, job)
+}
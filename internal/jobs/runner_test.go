@@ -0,0 +1,172 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/npkanaka/meeting-scheduler/internal/jobs"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRunner(store *jobs.MemoryStore) *jobs.Runner {
+	return jobs.NewRunner(store, jobs.Config{
+		PollInterval:   5 * time.Millisecond,
+		Concurrency:    2,
+		Timeout:        time.Second,
+		Lease:          time.Minute,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	})
+}
+
+// startRunner runs r until the test ends
+func startRunner(t *testing.T, r *jobs.Runner) {
+	go r.Run(context.Background())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, r.Shutdown(ctx))
+	})
+}
+
+func TestRunnerDecodesTypedPayloads(t *testing.T) {
+	type greeting struct {
+		Name string `json:"name"`
+	}
+	store := jobs.NewMemoryStore()
+	runner := newTestRunner(store)
+	received := make(chan string, 1)
+	jobs.Register(runner, "greet", func(ctx context.Context, payload greeting) error {
+		received <- payload.Name
+		return nil
+	})
+	startRunner(t, runner)
+
+	_, err := runner.Enqueue(context.Background(), "greet", greeting{Name: "Jane"})
+	require.NoError(t, err)
+
+	select {
+	case name := <-received:
+		assert.Equal(t, "Jane", name)
+	case <-time.After(time.Second):
+		t.Fatal("job did not run")
+	}
+}
+
+func TestRunnerRetriesThenDeadLetters(t *testing.T) {
+	store := jobs.NewMemoryStore()
+	runner := newTestRunner(store)
+	var attempts atomic.Int32
+	runner.Handle("flaky", func(ctx context.Context, job *models.Job) error {
+		attempts.Add(1)
+		return errors.New("upstream unavailable")
+	})
+	startRunner(t, runner)
+
+	job, err := runner.Enqueue(context.Background(), "flaky", nil)
+	require.NoError(t, err)
+
+	var dead []*models.Job
+	require.Eventually(t, func() bool {
+		dead, err = runner.ListDead(context.Background(), 10)
+		return err == nil && len(dead) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, job.ID, dead[0].ID)
+	assert.Equal(t, 3, dead[0].Attempts)
+	assert.Equal(t, "upstream unavailable", dead[0].LastError)
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// A retried dead job gets a fresh set of attempts
+	retried, err := runner.Retry(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobPending, retried.Status)
+	require.Eventually(t, func() bool { return attempts.Load() == 6 }, time.Second, 5*time.Millisecond)
+}
+
+func TestRunnerRecoversFromPanics(t *testing.T) {
+	store := jobs.NewMemoryStore()
+	runner := newTestRunner(store)
+	runner.Handle("broken", func(ctx context.Context, job *models.Job) error {
+		panic("nil map")
+	})
+	startRunner(t, runner)
+
+	_, err := runner.Enqueue(context.Background(), "broken", nil, jobs.MaxAttempts(1))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		dead, err := runner.ListDead(context.Background(), 10)
+		return err == nil && len(dead) == 1 && dead[0].LastError == "job panicked: nil map"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestRunnerSharesScheduledRunsBetweenReplicas(t *testing.T) {
+	store := jobs.NewMemoryStore()
+	var runs atomic.Int32
+	task := func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}
+
+	// Two replicas share the store, and the interval is long enough that only
+	// the run due at startup falls within the test
+	for i := 0; i < 2; i++ {
+		runner := newTestRunner(store)
+		runner.Every("report", time.Hour, task)
+		startRunner(t, runner)
+	}
+
+	require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), runs.Load())
+}
+
+func TestRunnerShutdownDrainsRunningJobs(t *testing.T) {
+	store := jobs.NewMemoryStore()
+	runner := newTestRunner(store)
+	started := make(chan struct{})
+	var finished atomic.Bool
+	runner.Handle("slow", func(ctx context.Context, job *models.Job) error {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		finished.Store(true)
+		return nil
+	})
+	go runner.Run(context.Background())
+
+	_, err := runner.Enqueue(context.Background(), "slow", nil)
+	require.NoError(t, err)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, runner.Shutdown(ctx))
+	assert.True(t, finished.Load())
+}
+
+func TestRunnerShutdownCancelsJobsAfterDeadline(t *testing.T) {
+	store := jobs.NewMemoryStore()
+	runner := newTestRunner(store)
+	started := make(chan struct{})
+	runner.Handle("stuck", func(ctx context.Context, job *models.Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	go runner.Run(context.Background())
+
+	_, err := runner.Enqueue(context.Background(), "stuck", nil)
+	require.NoError(t, err)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, runner.Shutdown(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,45 @@
+package jobs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/npkanaka/meeting-scheduler/internal/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleNext(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2025, 1, 15, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 1, 15, 10, 15, 0, 0, time.UTC)},
+		{"0 9-17 * * 1-5", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2025, 1, 16, 2, 30, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 8 * * 0,6", time.Date(2025, 1, 18, 8, 0, 0, 0, time.UTC)},
+		{"0 8 * * 7", time.Date(2025, 1, 19, 8, 0, 0, 0, time.UTC)},
+		// Day of month and day of week restricted together match either
+		{"0 0 20 * 5", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"@every 5m", time.Date(2025, 1, 15, 10, 10, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := jobs.ParseSchedule(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseScheduleRejectsInvalidSpecs(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "5-1 * * * *", "*/0 * * * *", "@every soon", "@every -1s"} {
+		_, err := jobs.ParseSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
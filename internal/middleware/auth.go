@@ -0,0 +1,69 @@
+// internal/middleware/auth.go
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+)
+
+// UserIDKey is the gin context key under which authentication stores the caller's user ID
+const UserIDKey = "user_id"
+
+// Authenticate requires a bearer token signed with secret. The request
+// context then carries the caller as its principal and acts for the caller's
+// organization, which every tenant-owned repository filters by. When no
+// secret is configured the routes are disabled.
+func Authenticate(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if secret == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "authentication is not configured"})
+			return
+		}
+
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "bearer token required"})
+			return
+		}
+
+		principal, err := auth.Verify(secret, token, time.Now())
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx := auth.WithPrincipal(c.Request.Context(), principal)
+		c.Request = c.Request.WithContext(tenant.WithOrganization(ctx, principal.OrganizationID))
+		c.Set(UserIDKey, principal.UserID.String())
+		c.Next()
+	}
+}
+
+// AdminOnly restricts a route to callers presenting the configured admin token
+// as a bearer token. The request context then acts for the system, which sees
+// every organization. When no token is configured the route is disabled.
+func AdminOnly(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin access is not configured"})
+			return
+		}
+
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "admin token required"})
+			return
+		}
+
+		c.Request = c.Request.WithContext(tenant.System(c.Request.Context()))
+		c.Next()
+	}
+}
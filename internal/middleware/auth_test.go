@@ -0,0 +1,107 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
+	"github.com/npkanaka/meeting-scheduler/internal/middleware"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+const authSecret = "test-secret-0123456789abcdef-0123456789"
+
+func newAuthenticatedRouter(secret string, seen *uuid.UUID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Authenticate(secret))
+	router.GET("/events", func(c *gin.Context) {
+		*seen, _ = tenant.OrganizationID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestAuthenticate(t *testing.T) {
+	now := time.Now()
+	principal := auth.Principal{UserID: uuid.New(), OrganizationID: uuid.New()}
+	valid := auth.Sign(authSecret, principal, now, now.Add(time.Hour))
+	expired := auth.Sign(authSecret, principal, now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	tests := []struct {
+		name       string
+		secret     string
+		header     string
+		wantStatus int
+		wantTenant uuid.UUID
+	}{
+		{"valid token", authSecret, "Bearer " + valid, http.StatusOK, principal.OrganizationID},
+		{"missing token", authSecret, "", http.StatusUnauthorized, uuid.Nil},
+		{"expired token", authSecret, "Bearer " + expired, http.StatusUnauthorized, uuid.Nil},
+		{"foreign token", authSecret, "Bearer " + auth.Sign("another-secret-0123456789abcdef-0123", principal, now, now.Add(time.Hour)), http.StatusUnauthorized, uuid.Nil},
+		{"not configured", "", "Bearer " + valid, http.StatusForbidden, uuid.Nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen uuid.UUID
+			router := newAuthenticatedRouter(tt.secret, &seen)
+
+			req := httptest.NewRequest(http.MethodGet, "/events", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantTenant, seen)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAdminOnly(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		header     string
+		wantStatus int
+		wantSystem bool
+	}{
+		{"valid token", "admin-token", "Bearer admin-token", http.StatusOK, true},
+		{"wrong token", "admin-token", "Bearer guess", http.StatusUnauthorized, false},
+		{"missing token", "admin-token", "", http.StatusUnauthorized, false},
+		{"missing bearer prefix", "admin-token", "admin-token", http.StatusUnauthorized, false},
+		{"not configured", "", "Bearer admin-token", http.StatusForbidden, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			var system bool
+			router.GET("/admin/audit", middleware.AdminOnly(tt.token), func(c *gin.Context) {
+				system = tenant.IsSystem(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/audit", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantSystem, system)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// internal/middleware/cors.go
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig configures the CORS middleware
+type CORSConfig struct {
+	// AllowedOrigins lists exact origins ("https://app.example.com"), patterns with a
+	// single wildcard ("https://*.example.com") or "*" to allow any origin
+	AllowedOrigins []string
+	// CredentialOrigins lists the origins, in the same format, that may send credentials.
+	// Credentials are never allowed for an origin only matched by "*".
+	CredentialOrigins []string
+	AllowedMethods    []string
+	AllowedHeaders    []string
+	ExposedHeaders    []string
+	MaxAge            int // Seconds browsers may cache a preflight response
+}
+
+// CORS applies the configured cross-origin policy. Preflight requests are answered
+// directly; other requests get the allow headers and continue down the chain.
+func CORS(cfg CORSConfig) gin.HandlerFunc {
+	// Origins are matched ignoring case, so patterns are lower-cased once here
+	allowAny := false
+	var allowedOrigins []string
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+		}
+		allowedOrigins = append(allowedOrigins, strings.ToLower(origin))
+	}
+	// A wildcard must never grant credentials to arbitrary origins
+	var credentialOrigins []string
+	for _, origin := range cfg.CredentialOrigins {
+		if origin != "*" {
+			credentialOrigins = append(credentialOrigins, strings.ToLower(origin))
+		}
+	}
+	allowedMethods := strings.Join(cfg.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(cfg.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		// The response depends on the Origin header unless every origin gets the same answer
+		header := c.Writer.Header()
+		if !allowAny || len(credentialOrigins) > 0 {
+			header.Add("Vary", "Origin")
+		}
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		lowered := strings.ToLower(origin)
+		credentials := matchOrigin(credentialOrigins, lowered)
+		allowed := credentials || matchOrigin(allowedOrigins, lowered)
+		if !allowed {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if credentials || !allowAny {
+			header.Set("Access-Control-Allow-Origin", origin)
+		} else {
+			header.Set("Access-Control-Allow-Origin", "*")
+		}
+		if credentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			header.Set("Access-Control-Allow-Methods", allowedMethods)
+			header.Set("Access-Control-Allow-Headers", allowedHeaders)
+			if cfg.MaxAge > 0 {
+				header.Set("Access-Control-Max-Age", strconv.Itoa(cfg.MaxAge))
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if exposedHeaders != "" {
+			header.Set("Access-Control-Expose-Headers", exposedHeaders)
+		}
+		c.Next()
+	}
+}
+
+// matchOrigin reports whether origin matches one of the patterns; both are
+// expected in lower case
+func matchOrigin(patterns []string, origin string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == origin {
+			return true
+		}
+		prefix, suffix, ok := strings.Cut(pattern, "*")
+		if !ok {
+			continue
+		}
+		if len(origin) > len(prefix)+len(suffix) &&
+			strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			// The wildcard stands for subdomain labels only, not a path or port
+			if !strings.ContainsAny(origin[len(prefix):len(origin)-len(suffix)], "/:") {
+				return true
+			}
+		}
+	}
+	return false
+}
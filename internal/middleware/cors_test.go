@@ -0,0 +1,196 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/npkanaka/meeting-scheduler/internal/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCORSRouter(cfg middleware.CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.CORS(cfg))
+	router.GET("/events", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+var corsTestConfig = middleware.CORSConfig{
+	AllowedOrigins:    []string{"https://app.example.com", "https://*.partner.com"},
+	CredentialOrigins: []string{"https://app.example.com"},
+	AllowedMethods:    []string{"GET", "POST"},
+	AllowedHeaders:    []string{"Content-Type", "Authorization"},
+	ExposedHeaders:    []string{"RateLimit-Remaining"},
+	MaxAge:            600,
+}
+
+func TestCORSPreflight(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             middleware.CORSConfig
+		origin          string
+		wantStatus      int
+		wantOrigin      string
+		wantCredentials string
+		wantMaxAge      string
+	}{
+		{
+			name:            "exact origin with credentials",
+			cfg:             corsTestConfig,
+			origin:          "https://app.example.com",
+			wantStatus:      http.StatusNoContent,
+			wantOrigin:      "https://app.example.com",
+			wantCredentials: "true",
+			wantMaxAge:      "600",
+		},
+		{
+			name:       "wildcard subdomain without credentials",
+			cfg:        corsTestConfig,
+			origin:     "https://eu.partner.com",
+			wantStatus: http.StatusNoContent,
+			wantOrigin: "https://eu.partner.com",
+			wantMaxAge: "600",
+		},
+		{
+			name:       "wildcard does not match the bare domain",
+			cfg:        corsTestConfig,
+			origin:     "https://partner.com",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "wildcard does not match another host suffix",
+			cfg:        corsTestConfig,
+			origin:     "https://evil.com/.partner.com",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "wildcard subdomain ignoring case",
+			cfg:        corsTestConfig,
+			origin:     "HTTPS://EU.Partner.COM",
+			wantStatus: http.StatusNoContent,
+			wantOrigin: "HTTPS://EU.Partner.COM",
+			wantMaxAge: "600",
+		},
+		{
+			name: "wildcard pattern in mixed case",
+			cfg: middleware.CORSConfig{
+				AllowedOrigins: []string{"https://*.Partner.com"},
+				AllowedMethods: []string{"GET"},
+			},
+			origin:     "https://eu.partner.com",
+			wantStatus: http.StatusNoContent,
+			wantOrigin: "https://eu.partner.com",
+		},
+		{
+			name:       "unknown origin",
+			cfg:        corsTestConfig,
+			origin:     "https://evil.com",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "any origin never gets credentials",
+			cfg: middleware.CORSConfig{
+				AllowedOrigins:    []string{"*"},
+				CredentialOrigins: []string{"*"},
+				AllowedMethods:    []string{"GET"},
+			},
+			origin:     "https://evil.com",
+			wantStatus: http.StatusNoContent,
+			wantOrigin: "*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newCORSRouter(tt.cfg)
+
+			req := httptest.NewRequest(http.MethodOptions, "/events", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", "POST")
+			req.Header.Set("Access-Control-Request-Headers", "Content-Type")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.wantCredentials, w.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, tt.wantMaxAge, w.Header().Get("Access-Control-Max-Age"))
+			assert.Contains(t, w.Header().Values("Vary"), "Access-Control-Request-Method")
+			if tt.wantStatus == http.StatusNoContent {
+				assert.Equal(t, strings.Join(tt.cfg.AllowedMethods, ", "), w.Header().Get("Access-Control-Allow-Methods"))
+			}
+		})
+	}
+}
+
+func TestCORSSimpleRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             middleware.CORSConfig
+		origin          string
+		wantOrigin      string
+		wantCredentials string
+		wantExposed     string
+		wantVaryOrigin  bool
+	}{
+		{
+			name:            "allowed origin with credentials",
+			cfg:             corsTestConfig,
+			origin:          "https://app.example.com",
+			wantOrigin:      "https://app.example.com",
+			wantCredentials: "true",
+			wantExposed:     "RateLimit-Remaining",
+			wantVaryOrigin:  true,
+		},
+		{
+			name:           "disallowed origin gets no CORS headers",
+			cfg:            corsTestConfig,
+			origin:         "https://evil.com",
+			wantVaryOrigin: true,
+		},
+		{
+			name:           "same-origin request without Origin header",
+			cfg:            corsTestConfig,
+			wantVaryOrigin: true,
+		},
+		{
+			name:       "any origin answers with a literal wildcard",
+			cfg:        middleware.CORSConfig{AllowedOrigins: []string{"*"}},
+			origin:     "https://anywhere.com",
+			wantOrigin: "*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newCORSRouter(tt.cfg)
+
+			req := httptest.NewRequest(http.MethodGet, "/events", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Simple requests always reach the handler; the browser enforces the policy
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.wantCredentials, w.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, tt.wantExposed, w.Header().Get("Access-Control-Expose-Headers"))
+			assert.Equal(t, tt.wantVaryOrigin, contains(w.Header().Values("Vary"), "Origin"))
+		})
+	}
+}
+
+func contains(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+// internal/middleware/idempotency.go
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+)
+
+// IdempotencyKeyHeader names the header clients send to make a POST safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength bounds the keys clients may send
+const maxIdempotencyKeyLength = 255
+
+// replayedHeaders are the response headers stored with a response and
+// replayed with it. Others, such as rate limit headers, describe the retry.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// IdempotencyStore holds the responses to requests sent with an idempotency
+// key. Implementations must be safe for concurrent use.
+type IdempotencyStore interface {
+	// Reserve saves record as in progress and returns nil, unless the caller
+	// already has an unexpired record under the key, which is returned instead
+	Reserve(ctx context.Context, record *models.IdempotencyRecord, now time.Time) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *models.IdempotencyRecord) error
+	Release(ctx context.Context, caller, key string) error
+}
+
+// IdempotencyConfig configures the Idempotency middleware
+type IdempotencyConfig struct {
+	Store IdempotencyStore
+	TTL   time.Duration // How long a response is replayed
+	// Lease is how long a request in progress holds its key. A key left in
+	// progress by a crashed process can be reserved again once it lapses.
+	Lease time.Duration
+}
+
+// Idempotency makes POST requests sent with an Idempotency-Key header safe to
+// retry. The first response is stored per caller and key and replayed to
+// retries with the same method, URI and body; a different request under the
+// same key gets 422, and a retry while the first is still running gets 409.
+// Server errors, panics and authorization failures are not stored, so the
+// request can be retried, with valid credentials in the latter case.
+func Idempotency(cfg IdempotencyConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 255 characters"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now()
+		record := &models.IdempotencyRecord{
+			Caller:      callerKey(c),
+			Key:         key,
+			RequestHash: requestHash(c.Request.Method, c.Request.URL.RequestURI(), body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(cfg.Lease),
+		}
+		existing, err := cfg.Store.Reserve(c.Request.Context(), record, now)
+		switch {
+		case errors.Is(err, apperrors.ErrIdempotencyKeyInUse):
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			log.Printf("idempotency store unavailable: %v", err)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "idempotency keys are unavailable"})
+			return
+		case existing != nil:
+			replay(c, existing, record.RequestHash)
+			return
+		}
+
+		// The client may be gone by the time the handler returns, which is why
+		// it will retry
+		ctx := context.WithoutCancel(c.Request.Context())
+		completed := false
+		defer func() {
+			// Also runs when the handler panics
+			if completed {
+				return
+			}
+			if err := cfg.Store.Release(ctx, record.Caller, record.Key); err != nil {
+				log.Printf("failed to release idempotency key: %v", err)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Route-level auth such as AdminOnly runs after this middleware; its
+		// answer is about the credentials, not the request
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusUnauthorized || status == http.StatusForbidden {
+			return
+		}
+
+		header := make(http.Header)
+		for _, name := range replayedHeaders {
+			if values := recorder.Header().Values(name); len(values) > 0 {
+				header[name] = values
+			}
+		}
+		record.StatusCode = recorder.Status()
+		record.Header, _ = json.Marshal(header)
+		record.Body = recorder.body.Bytes()
+		record.ExpiresAt = time.Now().Add(cfg.TTL)
+		if err := cfg.Store.Complete(ctx, record); err != nil {
+			log.Printf("failed to store idempotent response: %v", err)
+			return
+		}
+		completed = true
+	}
+}
+
+// replay answers a retry with the stored response of the first request
+func replay(c *gin.Context, existing *models.IdempotencyRecord, hash string) {
+	switch {
+	case existing.RequestHash != hash:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": apperrors.ErrIdempotencyKeyReused.Error()})
+		return
+	case !existing.Completed:
+		c.Header("Retry-After", "1")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": apperrors.ErrIdempotencyKeyInUse.Error()})
+		return
+	}
+
+	var header http.Header
+	if len(existing.Header) > 0 {
+		if err := json.Unmarshal(existing.Header, &header); err != nil {
+			log.Printf("failed to read idempotent response headers: %v", err)
+		}
+	}
+	for name, values := range header {
+		for _, value := range values {
+			c.Writer.Header().Add(name, value)
+		}
+	}
+	c.Header("Idempotent-Replayed", "true")
+	c.Status(existing.StatusCode)
+	c.Writer.Write(existing.Body)
+	c.Abort()
+}
+
+// requestHash fingerprints a request so a reused key can be told apart from a retry
+func requestHash(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + uri + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body as it is written
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write implements io.Writer
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// WriteString implements io.StringWriter
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,236 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/npkanaka/meeting-scheduler/internal/middleware"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryIdempotencyStore keeps idempotency records in a map
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*models.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*models.IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, record *models.IdempotencyRecord, now time.Time) (*models.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := record.Caller + " " + record.Key
+	if existing, ok := s.records[id]; ok && existing.ExpiresAt.After(now) {
+		copied := *existing
+		return &copied, nil
+	}
+	copied := *record
+	s.records[id] = &copied
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, record *models.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *record
+	copied.Completed = true
+	s.records[record.Caller+" "+record.Key] = &copied
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, caller, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, caller+" "+key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) record(caller, key string) *models.IdempotencyRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.records[caller+" "+key]
+}
+
+func newIdempotentRouter(store middleware.IdempotencyStore, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, c.GetHeader("X-Test-User"))
+		c.Next()
+	})
+	router.Use(middleware.Idempotency(middleware.IdempotencyConfig{Store: store, TTL: time.Hour, Lease: time.Minute}))
+	router.POST("/events", handler)
+	return router
+}
+
+func postEvent(router *gin.Engine, user, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+	req.Header.Set("X-Test-User", user)
+	if key != "" {
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplaysTheFirstResponse(t *testing.T) {
+	created := 0
+	router := newIdempotentRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		created++
+		c.Header("Location", "/events/1")
+		c.Header("RateLimit-Remaining", "4")
+		c.JSON(http.StatusCreated, gin.H{"id": created})
+	})
+
+	first := postEvent(router, "alice", "key-1", `{"title":"Planning"}`)
+	retry := postEvent(router, "alice", "key-1", `{"title":"Planning"}`)
+
+	assert.Equal(t, 1, created)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "/events/1", retry.Header().Get("Location"))
+	assert.Equal(t, first.Header().Get("Content-Type"), retry.Header().Get("Content-Type"))
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Empty(t, retry.Header().Get("RateLimit-Remaining"), "headers about the first request are not replayed")
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotencyKeysBelongToTheCaller(t *testing.T) {
+	created := 0
+	router := newIdempotentRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		created++
+		c.Status(http.StatusCreated)
+	})
+
+	postEvent(router, "alice", "key-1", `{}`)
+	postEvent(router, "bob", "key-1", `{}`)
+	postEvent(router, "alice", "", `{}`)
+	postEvent(router, "alice", "", `{}`)
+
+	assert.Equal(t, 4, created)
+}
+
+func TestIdempotencyRejectsAReusedKey(t *testing.T) {
+	router := newIdempotentRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	require.Equal(t, http.StatusCreated, postEvent(router, "alice", "key-1", `{"title":"Planning"}`).Code)
+	w := postEvent(router, "alice", "key-1", `{"title":"Retro"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestIdempotencyTellsQueriesApart(t *testing.T) {
+	router := newIdempotentRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	post := func(target string) int {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{}`))
+		req.Header.Set("X-Test-User", "alice")
+		req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusCreated, post("/events?notify=true"))
+	assert.Equal(t, http.StatusUnprocessableEntity, post("/events?notify=false"))
+}
+
+func TestIdempotencyConflictsWhileTheFirstRequestRuns(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	router := newIdempotentRouter(store, func(c *gin.Context) {
+		close(started)
+		<-finish
+		c.Status(http.StatusCreated)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- postEvent(router, "alice", "key-1", `{}`) }()
+	<-started
+
+	w := postEvent(router, "alice", "key-1", `{}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	close(finish)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+}
+
+func TestIdempotencyForgetsServerErrors(t *testing.T) {
+	calls := 0
+	router := newIdempotentRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "database unavailable"})
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, postEvent(router, "alice", "key-1", `{}`).Code)
+	assert.Equal(t, http.StatusCreated, postEvent(router, "alice", "key-1", `{}`).Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyForgetsAuthorizationFailures(t *testing.T) {
+	calls := 0
+	router := newIdempotentRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "admin token required"})
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	assert.Equal(t, http.StatusUnauthorized, postEvent(router, "alice", "key-1", `{}`).Code)
+	assert.Equal(t, http.StatusCreated, postEvent(router, "alice", "key-1", `{}`).Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyReleasesTheKeyWhenTheHandlerPanics(t *testing.T) {
+	calls := 0
+	router := newIdempotentRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("handler bug")
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, postEvent(router, "alice", "key-1", `{}`).Code)
+	assert.Equal(t, http.StatusCreated, postEvent(router, "alice", "key-1", `{}`).Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyHoldsKeysInProgressForTheLease(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	var reserved time.Time
+	router := newIdempotentRouter(store, func(c *gin.Context) {
+		reserved = store.record("user:alice", "key-1").ExpiresAt
+		c.Status(http.StatusCreated)
+	})
+
+	start := time.Now()
+	require.Equal(t, http.StatusCreated, postEvent(router, "alice", "key-1", `{}`).Code)
+
+	// A crashed request frees its key after the lease; a response is replayed for the TTL
+	assert.WithinDuration(t, start.Add(time.Minute), reserved, time.Second)
+	assert.WithinDuration(t, start.Add(time.Hour), store.record("user:alice", "key-1").ExpiresAt, time.Second)
+}
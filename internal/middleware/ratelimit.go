@@ -0,0 +1,188 @@
+// internal/middleware/ratelimit.go
+package middleware
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitBudget is the token bucket configuration for a route: requests are
+// refilled at Rate per second up to Burst
+type RateLimitBudget struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitResult is the outcome of taking a token from a bucket
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // How long until a token is available, when not allowed
+	ResetAfter time.Duration // How long until the bucket is full again
+}
+
+// RateLimitStore holds token bucket state. Implementations must be safe for concurrent use.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, budget RateLimitBudget, now time.Time) (RateLimitResult, error)
+}
+
+// RateLimitConfig configures the RateLimit middleware
+type RateLimitConfig struct {
+	Store RateLimitStore
+	// Name keeps the buckets of limiters that share a store apart
+	Name    string
+	Default RateLimitBudget
+	// Routes holds per-route budgets keyed by "METHOD /route/:pattern"
+	Routes map[string]RateLimitBudget
+}
+
+// RateLimit throttles requests with a token bucket per caller and route. Callers
+// are identified by their authenticated user ID when present, otherwise by client IP.
+func RateLimit(cfg RateLimitConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+		budget, ok := cfg.Routes[route]
+		if !ok {
+			// Routes without their own budget share the default bucket
+			budget = cfg.Default
+			route = "default"
+		}
+
+		key := "ratelimit:" + route + ":" + callerKey(c)
+		if cfg.Name != "" {
+			key = "ratelimit:" + cfg.Name + ":" + route + ":" + callerKey(c)
+		}
+		result, err := cfg.Store.Take(c.Request.Context(), key, budget, time.Now())
+		if err != nil {
+			// Fail open so that a store outage does not take the API down
+			log.Printf("rate limiter unavailable: %v", err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// callerKey identifies the caller for rate limiting
+func callerKey(c *gin.Context) string {
+	if userID := c.GetString(UserIDKey); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds rounds a duration up to whole seconds for use in headers
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
+
+// bucket is the state of a single token bucket
+type bucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time // When the bucket is full again under its own budget
+}
+
+// MemoryRateLimitStore keeps token buckets in process memory
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimitStore creates a new MemoryRateLimitStore
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// sweepInterval is how often idle buckets are evicted from memory
+const sweepInterval = time.Minute
+
+// Take implements RateLimitStore
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, budget RateLimitBudget, now time.Time) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) > sweepInterval {
+		s.sweep(now)
+		s.lastSweep = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(budget.Burst), last: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = refill(b.tokens, now.Sub(b.last), budget)
+	b.last = now
+
+	result := takeToken(&b.tokens, budget)
+	b.full = now.Add(result.ResetAfter)
+	return result, nil
+}
+
+// sweep removes buckets that are full again; routes have different budgets,
+// so each bucket is judged by the budget it was last taken from
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.After(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// refill adds the tokens accumulated over elapsed, capped at the burst size
+func refill(tokens float64, elapsed time.Duration, budget RateLimitBudget) float64 {
+	if elapsed > 0 {
+		tokens += elapsed.Seconds() * budget.Rate
+	}
+	return math.Min(tokens, float64(budget.Burst))
+}
+
+// takeToken consumes a token if one is available and describes the bucket afterwards
+func takeToken(tokens *float64, budget RateLimitBudget) RateLimitResult {
+	result := RateLimitResult{Limit: budget.Burst}
+	if *tokens >= 1 {
+		*tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - *tokens) / budget.Rate)
+	}
+	result.Remaining = int(math.Floor(*tokens))
+	result.ResetAfter = secondsToDuration((float64(budget.Burst) - *tokens) / budget.Rate)
+	return result
+}
+
+// fullRefillTime is how long an empty bucket takes to fill up
+func fullRefillTime(budget RateLimitBudget) time.Duration {
+	return secondsToDuration(float64(budget.Burst) / budget.Rate)
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
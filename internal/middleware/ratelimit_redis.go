@@ -0,0 +1,105 @@
+// internal/middleware/ratelimit_redis.go
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisScripter is the subset of a Redis client needed by RedisRateLimitStore.
+// Any Redis-compatible server that supports EVAL can back it.
+type RedisScripter interface {
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+}
+
+// goRedisScripter adapts a go-redis client to RedisScripter
+type goRedisScripter struct {
+	client redis.Scripter
+}
+
+// NewGoRedisScripter wraps a go-redis client as a RedisScripter
+func NewGoRedisScripter(client redis.Scripter) RedisScripter {
+	return &goRedisScripter{client: client}
+}
+
+// Eval implements RedisScripter
+func (s *goRedisScripter) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	return s.client.Eval(ctx, script, keys, args...).Result()
+}
+
+// tokenBucketScript refills and takes a token atomically. It returns the
+// remaining tokens (scaled by 1000 to survive Redis integer conversion) and
+// whether the request is allowed.
+const tokenBucketScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(state[1])
+local last = tonumber(state[2])
+if tokens == nil then
+  tokens = burst
+  last = now
+end
+
+local elapsed = math.max(0, now - last) / 1000
+tokens = math.min(burst, tokens + elapsed * rate)
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "last", tostring(now))
+redis.call("PEXPIRE", KEYS[1], ttl)
+return {math.floor(tokens * 1000), allowed}
+`
+
+// RedisRateLimitStore keeps token buckets in Redis so limits are shared across replicas
+type RedisRateLimitStore struct {
+	client RedisScripter
+}
+
+// NewRedisRateLimitStore creates a new RedisRateLimitStore
+func NewRedisRateLimitStore(client RedisScripter) *RedisRateLimitStore {
+	return &RedisRateLimitStore{client: client}
+}
+
+// Take implements RateLimitStore
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, budget RateLimitBudget, now time.Time) (RateLimitResult, error) {
+	ttl := fullRefillTime(budget) + time.Second
+	reply, err := s.client.Eval(ctx, tokenBucketScript, []string{key},
+		budget.Rate, budget.Burst, now.UnixMilli(), ttl.Milliseconds())
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return RateLimitResult{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+	scaled, ok1 := values[0].(int64)
+	allowed, ok2 := values[1].(int64)
+	if !ok1 || !ok2 {
+		return RateLimitResult{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+
+	tokens := float64(scaled) / 1000
+	result := RateLimitResult{
+		Allowed:    allowed == 1,
+		Limit:      budget.Burst,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: secondsToDuration((float64(budget.Burst) - tokens) / budget.Rate),
+	}
+	if !result.Allowed {
+		result.RetryAfter = secondsToDuration((1 - tokens) / budget.Rate)
+	}
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/npkanaka/meeting-scheduler/internal/middleware"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRateLimitedRouter(store middleware.RateLimitStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RateLimit(middleware.RateLimitConfig{
+		Store:   store,
+		Default: middleware.RateLimitBudget{Rate: 100, Burst: 100},
+		Routes: map[string]middleware.RateLimitBudget{
+			"POST /events/:id/availability": {Rate: 1, Burst: 2},
+		},
+	}))
+	router.POST("/events/:id/availability", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.GET("/events", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func doRequest(router *gin.Engine, method, path, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitPerRouteBudget(t *testing.T) {
+	router := newRateLimitedRouter(middleware.NewMemoryRateLimitStore())
+
+	// The burst allows two requests
+	w := doRequest(router, http.MethodPost, "/events/1/availability", "10.0.0.1")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+
+	w = doRequest(router, http.MethodPost, "/events/2/availability", "10.0.0.1")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+	// The third is rejected with a retry hint
+	w = doRequest(router, http.MethodPost, "/events/3/availability", "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	// Other callers and other routes have their own buckets
+	w = doRequest(router, http.MethodPost, "/events/1/availability", "10.0.0.2")
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = doRequest(router, http.MethodGet, "/events", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "100", w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitKeyedByUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, c.GetHeader("X-Test-User"))
+		c.Next()
+	})
+	router.Use(middleware.RateLimit(middleware.RateLimitConfig{
+		Store:   middleware.NewMemoryRateLimitStore(),
+		Default: middleware.RateLimitBudget{Rate: 1, Burst: 1},
+	}))
+	router.GET("/events", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(user string) int {
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+		req.Header.Set("X-Test-User", user)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Same IP, different users
+	assert.Equal(t, http.StatusOK, request("alice"))
+	assert.Equal(t, http.StatusTooManyRequests, request("alice"))
+	assert.Equal(t, http.StatusOK, request("bob"))
+}
+
+func TestRateLimitStoresRefill(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	stores := map[string]middleware.RateLimitStore{
+		"memory": middleware.NewMemoryRateLimitStore(),
+		"redis":  middleware.NewRedisRateLimitStore(middleware.NewGoRedisScripter(client)),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			budget := middleware.RateLimitBudget{Rate: 2, Burst: 2}
+			now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+			for i := 0; i < 2; i++ {
+				result, err := store.Take(ctx, "bucket", budget, now)
+				assert.NoError(t, err)
+				assert.True(t, result.Allowed)
+			}
+
+			result, err := store.Take(ctx, "bucket", budget, now)
+			assert.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+			assert.Equal(t, time.Second, result.ResetAfter)
+
+			// Half a second refills one token at two per second
+			result, err = store.Take(ctx, "bucket", budget, now.Add(500*time.Millisecond))
+			assert.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 0, result.Remaining)
+		})
+	}
+}
+
+func TestMemoryRateLimitStoreSweepKeepsBucketsOfStricterBudgets(t *testing.T) {
+	ctx := context.Background()
+	store := middleware.NewMemoryRateLimitStore()
+	strict := middleware.RateLimitBudget{Rate: 0.001, Burst: 4}
+	lenient := middleware.RateLimitBudget{Rate: 100, Burst: 100}
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 4; i++ {
+		result, err := store.Take(ctx, "strict", strict, now)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+
+	// A request on a lenient route sweeps long after its own buckets would be full
+	later := now.Add(2 * time.Minute)
+	result, err := store.Take(ctx, "lenient", lenient, later)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// The strict bucket has refilled a fraction of a token, not its burst
+	result, err = store.Take(ctx, "strict", strict, later)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+}
+
+func TestRateLimitBeforeAuthenticationLimitsBadTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := middleware.NewMemoryRateLimitStore()
+	router := gin.New()
+	router.GET("/health", middleware.RateLimit(middleware.RateLimitConfig{
+		Store:   store,
+		Default: middleware.RateLimitBudget{Rate: 1, Burst: 1},
+	}), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/events",
+		middleware.RateLimit(middleware.RateLimitConfig{
+			Store:   store,
+			Name:    "ip",
+			Default: middleware.RateLimitBudget{Rate: 1, Burst: 2},
+		}),
+		middleware.Authenticate("secret"),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(router, http.MethodGet, "/events", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusUnauthorized, doRequest(router, http.MethodGet, "/events", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(router, http.MethodGet, "/events", "10.0.0.1").Code)
+
+	// The named limiter keeps its buckets apart from the default ones of the same IP
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodGet, "/health", "10.0.0.1").Code)
+}
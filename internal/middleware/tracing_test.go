@@ -0,0 +1,128 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/middleware"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/npkanaka/meeting-scheduler/internal/telemetry"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newSpanRecorder installs a tracer provider that keeps spans in memory
+func newSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	return recorder
+}
+
+// attributes collects the attributes of a span by key
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	values := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		values[kv.Key] = kv.Value
+	}
+	return values
+}
+
+// spanNamed returns the ended span with the given name, failing the test if there is none
+func spanNamed(t *testing.T, recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not recorded", "no span named %q", name)
+	return nil
+}
+
+func TestTracingRecordsServerSpans(t *testing.T) {
+	recorder := newSpanRecorder(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Tracing())
+	router.GET("/events/:id", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	router.POST("/events", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events/42", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/events", nil))
+
+	get := spanNamed(t, recorder, "GET /events/:id")
+	assert.Equal(t, trace.SpanKindServer, get.SpanKind())
+	attrs := attributes(get)
+	assert.Equal(t, "GET", attrs["http.request.method"].AsString())
+	assert.Equal(t, "/events/:id", attrs["http.route"].AsString())
+	assert.Equal(t, "/events/42", attrs["url.path"].AsString())
+	assert.Equal(t, "42", attrs["http.route.id"].AsString())
+	assert.Equal(t, int64(http.StatusNotFound), attrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, codes.Unset, get.Status().Code, "client errors do not mark the span failed")
+
+	post := spanNamed(t, recorder, "POST /events")
+	assert.Equal(t, int64(http.StatusInternalServerError), attributes(post)["http.response.status_code"].AsInt64())
+	assert.Equal(t, codes.Error, post.Status().Code)
+}
+
+func TestTracingParentsServiceAndRepositorySpans(t *testing.T) {
+	recorder := newSpanRecorder(t)
+
+	// A dry run builds the statement without a server and still runs the plugin's callbacks
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost user=test dbname=test sslmode=disable"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(telemetry.NewGormPlugin()))
+	events := service.NewEventService(repository.NewGormEventRepository(db), nil, nil, nil, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Tracing())
+	router.GET("/events/:id", func(c *gin.Context) {
+		_, err := events.GetEvent(tenant.WithOrganization(c.Request.Context(), uuid.New()), uuid.MustParse(c.Param("id")))
+		if errors.Is(err, apperrors.ErrEventNotFound) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events/"+uuid.NewString(), nil))
+
+	server := spanNamed(t, recorder, "GET /events/:id")
+	get := spanNamed(t, recorder, "EventService.GetEvent")
+	query := spanNamed(t, recorder, "gorm.query")
+	assert.Equal(t, server.SpanContext().TraceID(), get.SpanContext().TraceID())
+	assert.Equal(t, server.SpanContext().SpanID(), get.Parent().SpanID())
+	assert.Equal(t, server.SpanContext().TraceID(), query.SpanContext().TraceID())
+	assert.Equal(t, get.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Equal(t, trace.SpanKindClient, query.SpanKind())
+
+	attrs := attributes(query)
+	assert.Equal(t, "postgresql", attrs["db.system"].AsString())
+	assert.Equal(t, "query", attrs["db.operation"].AsString())
+	assert.Equal(t, "events", attrs["db.sql.table"].AsString())
+	assert.Contains(t, attrs["db.statement"].AsString(), `FROM "events"`)
+}
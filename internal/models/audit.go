@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entity types recorded in the audit trail
+const (
+	AuditEntityEvent        = "event"
+	AuditEntityTimeSlot     = "time_slot"
+	AuditEntityAvailability = "availability"
+	AuditEntityUser         = "user"
+)
+
+// Audit actions of changes that have no lifecycle topic. Other changes are
+// recorded under their topic, such as timeslot.updated.
+const (
+	AuditUserCreated = "user.created"
+	AuditUserUpdated = "user.updated"
+	// Rows removed for good once past the trash retention
+	AuditEventPurged        = "event.purged"
+	AuditTimeSlotPurged     = "timeslot.purged"
+	AuditAvailabilityPurged = "availability.purged"
+)
+
+// AuditActorType is the kind of caller that made an audited change
+type AuditActorType string
+
+const (
+	// AuditActorUser is a user calling with a bearer token
+	AuditActorUser AuditActorType = "user"
+	// AuditActorGuest is a guest responding through a share link
+	AuditActorGuest AuditActorType = "guest"
+	// AuditActorSystem is background work, such as automatic finalization, or an admin call
+	AuditActorSystem AuditActorType = "system"
+)
+
+// AuditEntry records one change to scheduling data. Entries are only ever
+// appended, and outlive the rows they describe.
+type AuditEntry struct {
+	ID             uuid.UUID       `json:"id" gorm:"type:uuid;primary_key"`
+	OrganizationID uuid.UUID       `json:"organization_id" gorm:"type:uuid;not null"`
+	EventID        *uuid.UUID      `json:"event_id,omitempty" gorm:"type:uuid"` // Event whose history the change belongs to
+	ActorType      AuditActorType  `json:"actor_type" gorm:"not null"`
+	ActorID        *uuid.UUID      `json:"actor_id,omitempty" gorm:"type:uuid"` // User or guest ID; empty for the system
+	Action         string          `json:"action" gorm:"not null"`
+	EntityType     string          `json:"entity_type" gorm:"not null"`
+	EntityID       uuid.UUID       `json:"entity_id" gorm:"type:uuid;not null"`
+	Before         json.RawMessage `json:"before,omitempty" gorm:"type:jsonb"` // Empty when the entity was created
+	After          json.RawMessage `json:"after,omitempty" gorm:"type:jsonb"`  // Empty when the entity was deleted
+	RequestID      string          `json:"request_id,omitempty"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"not null"`
+}
+
+// AuditFilter selects audit entries; unset fields match every entry
+type AuditFilter struct {
+	OrganizationID *uuid.UUID
+	EventID        *uuid.UUID
+	ActorID        *uuid.UUID
+	EntityType     string
+	EntityID       *uuid.UUID
+	Action         string
+	From           *time.Time // Inclusive
+	To             *time.Time // Exclusive
+	Limit          int
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DecisionOutcome is what was done when an event's response deadline passed
+type DecisionOutcome string
+
+const (
+	// DecisionFinalized means the event was finalized with the recommended slot
+	DecisionFinalized DecisionOutcome = "finalized"
+	// DecisionOrganizerNotified means the organizer was asked to decide
+	DecisionOrganizerNotified DecisionOutcome = "organizer_notified"
+)
+
+// EventDecision records the outcome of evaluating an event at its response deadline
+type EventDecision struct {
+	ID         uuid.UUID          `json:"id" gorm:"type:uuid;primary_key"`
+	EventID    uuid.UUID          `json:"event_id" gorm:"type:uuid;not null"`
+	Policy     AutoFinalizePolicy `json:"policy" gorm:"not null"`
+	Outcome    DecisionOutcome    `json:"outcome" gorm:"not null"`
+	TimeSlotID *uuid.UUID         `json:"time_slot_id,omitempty" gorm:"type:uuid"` // Top recommendation, if there was one
+	Attendees  int                `json:"attendees"`                               // Attendees of the top recommendation
+	Reason     string             `json:"reason" gorm:"not null"`
+	DecidedAt  time.Time          `json:"decided_at" gorm:"not null"`
+}
@@ -11,6 +11,34 @@ type CreateEventRequest struct {
 	Title       string `json:"title" binding:"required"`
 	Description string `json:"description"`
 	Duration    int    `json:"duration" binding:"required,min=1"`
+	// ResponseDeadline is when participants are expected to have submitted availability
+	ResponseDeadline *time.Time `json:"response_deadline"`
+	// AutoFinalize decides what happens at the response deadline; defaults to none
+	AutoFinalize AutoFinalizePolicy `json:"auto_finalize" binding:"omitempty,oneof=none top_recommendation"`
+	// Quorum is the number of attendees a slot needs to have quorum; defaults to 1
+	Quorum int `json:"quorum" binding:"min=0"`
+	// QuorumPercent is the percentage of invited participants a slot needs instead of a fixed quorum
+	QuorumPercent int `json:"quorum_percent" binding:"min=0,max=100"`
+	// RequiredGroups are groups a slot needs attendees from on top of the quorum
+	RequiredGroups []RequiredGroup `json:"required_groups" binding:"omitempty,dive"`
+	// Scoring decides what recommendations are scored from; defaults to availability
+	Scoring ScoringMode `json:"scoring" binding:"omitempty,oneof=availability votes combined"`
+	// ResourceRequirement limits recommendations to slots where a suitable resource is free
+	ResourceRequirement *ResourceRequirement `json:"resource_requirement"`
+	// BufferBefore and BufferAfter are the minutes participants need free around the meeting
+	BufferBefore int `json:"buffer_before" binding:"min=0,max=240"`
+	BufferAfter  int `json:"buffer_after" binding:"min=0,max=240"`
+}
+
+// UserPreferencesRequest represents a request to change a user's scheduling preferences
+type UserPreferencesRequest struct {
+	// DefaultBuffer is the minutes the user keeps free before and after meetings
+	DefaultBuffer *int `json:"default_buffer" binding:"required,min=0,max=240"`
+}
+
+// AddParticipantRequest represents a request to invite a user to an event
+type AddParticipantRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
 }
 
 // CreateEventResponse represents the response after creating an event
@@ -24,6 +52,49 @@ type CreateEventResponse struct {
 	CreatedAt   time.Time   `json:"created_at"`
 }
 
+// GroupRequest represents a request to create or update a group
+type GroupRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description"`
+}
+
+// GroupMemberRequest represents a request to add a user or a nested group to
+// a group; exactly one of them is set
+type GroupMemberRequest struct {
+	UserID  *uuid.UUID `json:"user_id"`
+	GroupID *uuid.UUID `json:"group_id"`
+}
+
+// InviteGroupRequest represents a request to invite a group to an event
+type InviteGroupRequest struct {
+	GroupID uuid.UUID `json:"group_id" binding:"required"`
+}
+
+// GroupResponse represents a group with its direct members in API responses
+type GroupResponse struct {
+	*Group
+	Members []*GroupMember `json:"members"`
+}
+
+// GroupAttendance summarizes how many members of an invited group can attend a slot
+type GroupAttendance struct {
+	GroupID   uuid.UUID `json:"group_id"`
+	Name      string    `json:"name"`
+	Members   int       `json:"members"`   // Users in the group, including nested groups
+	Attending int       `json:"attending"` // Members among the attendees
+}
+
+// ParticipantResponse represents an event participant in API responses
+type ParticipantResponse struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Responded bool       `json:"responded"` // Whether the user has submitted availability
+	InvitedAt time.Time  `json:"invited_at"`
+	GroupID   *uuid.UUID `json:"group_id,omitempty"` // Invited group the user was added through
+	Guest     bool       `json:"guest,omitempty"`    // Joined through a share link; UserID is the guest ID
+}
+
 // TimeSlotRequest represents a request to create or update a time slot
 type TimeSlotRequest struct {
 	StartTime string `json:"start_time" binding:"required"` // ISO 8601 format
@@ -37,17 +108,59 @@ type AvailabilityRequest struct {
 	EndTime   string    `json:"end_time" binding:"required"`   // ISO 8601 format
 }
 
+// VoteRequest represents a request to cast or change a vote on a time slot
+type VoteRequest struct {
+	Choice VoteChoice `json:"choice" binding:"required,oneof=yes no maybe"`
+}
+
+// ResourceRequest represents a request to add or change a resource
+type ResourceRequest struct {
+	Name       string       `json:"name" binding:"required,max=255"`
+	Kind       ResourceKind `json:"kind" binding:"required,oneof=room equipment video_bridge"`
+	Capacity   int          `json:"capacity" binding:"min=0"`
+	Attributes []string     `json:"attributes"`
+}
+
+// ResourceBookingRequest represents a request to block a resource for a period
+type ResourceBookingRequest struct {
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+	Note      string    `json:"note"`
+}
+
 // RecommendationResponse represents the recommendation API response
 type RecommendationResponse struct {
+	Scoring         ScoringMode      `json:"scoring,omitempty"`
+	Quorum          int              `json:"quorum"` // Attendees a slot needs to have quorum
 	Recommendations []Recommendation `json:"recommendations"`
 }
 
 // Recommendation represents a single time slot recommendation
 type Recommendation struct {
-	TimeSlot     TimeSlotResponse `json:"time_slot"`
-	Attendees    []UserResponse   `json:"attendees"`
-	NonAttendees []UserResponse   `json:"non_attendees"`
-	Score        int              `json:"score"` // Number of attendees
+	TimeSlot     TimeSlotResponse  `json:"time_slot"`
+	Attendees    []UserResponse    `json:"attendees"`
+	Tight        []UserResponse    `json:"tight,omitempty"` // Free for the meeting, but not for the buffers around it
+	Maybe        []UserResponse    `json:"maybe,omitempty"` // Voted maybe; breaks ties between equal scores
+	NonAttendees []UserResponse    `json:"non_attendees"`
+	Groups       []GroupAttendance `json:"groups,omitempty"` // Attendance of each group invited to the event
+	Votes        VoteTally         `json:"votes"`
+	Resource     *Resource         `json:"resource,omitempty"` // Resource finalizing the slot would book
+	Score        int               `json:"score"`              // Number of attendees
+	// MeetsQuorum is set when the attendees reach the event's quorum and every required group
+	MeetsQuorum bool `json:"meets_quorum"`
+	// MissingGroups names the required groups short of attendees
+	MissingGroups []string `json:"missing_groups,omitempty"`
+	// ScoreWithoutBuffers counts the attendees if buffers were skipped
+	ScoreWithoutBuffers int `json:"score_without_buffers"`
+	// OnlyViableWithoutBuffers is set when the slot reaches the event's quorum only if buffers are skipped
+	OnlyViableWithoutBuffers bool `json:"only_viable_without_buffers,omitempty"`
+}
+
+// VoteTally counts the votes cast on a time slot
+type VoteTally struct {
+	Yes   int `json:"yes"`
+	No    int `json:"no"`
+	Maybe int `json:"maybe"`
 }
 
 // TimeSlotResponse represents a time slot in API responses
@@ -62,4 +175,97 @@ type UserResponse struct {
 	ID    uuid.UUID `json:"id"`
 	Name  string    `json:"name"`
 	Email string    `json:"email"`
+	Guest bool      `json:"guest,omitempty"` // A guest who responded through a share link
+}
+
+// WebhookSubscriptionRequest represents a request to create a webhook subscription.
+// Exactly one of EventID and CreatorID must be set.
+type WebhookSubscriptionRequest struct {
+	URL        string     `json:"url" binding:"required,url"`
+	EventID    *uuid.UUID `json:"event_id"`
+	CreatorID  *uuid.UUID `json:"creator_id"`
+	EventTypes []string   `json:"event_types"`
+	Secret     string     `json:"secret"` // Generated when empty
+}
+
+// WebhookSubscriptionResponse represents a webhook subscription in API responses
+type WebhookSubscriptionResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	URL        string     `json:"url"`
+	EventID    *uuid.UUID `json:"event_id,omitempty"`
+	CreatorID  *uuid.UUID `json:"creator_id,omitempty"`
+	EventTypes []string   `json:"event_types"`
+	Active     bool       `json:"active"`
+	Secret     string     `json:"secret,omitempty"` // Only returned when the subscription is created
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateShareLinkRequest represents a request to create a share link for an event
+type CreateShareLinkRequest struct {
+	// ExpiresAt defaults to the configured link lifetime from now
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ShareLinkResponse represents a share link in API responses
+type ShareLinkResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	EventID   uuid.UUID  `json:"event_id"`
+	Token     string     `json:"token,omitempty"` // Only returned when the link is created
+	URL       string     `json:"url,omitempty"`   // Path guests open, only returned when the link is created
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// SharedEventResponse is the view of an event shown to guests opening a share link
+type SharedEventResponse struct {
+	ID               uuid.UUID          `json:"id"`
+	Title            string             `json:"title"`
+	Description      string             `json:"description"`
+	Duration         int                `json:"duration"`
+	Status           EventStatus        `json:"status"`
+	ResponseDeadline *time.Time         `json:"response_deadline,omitempty"`
+	TimeSlots        []TimeSlotResponse `json:"time_slots"`
+	LinkExpiresAt    time.Time          `json:"link_expires_at"`
+}
+
+// GuestAvailabilityRequest represents availability submitted by a guest through a share link
+type GuestAvailabilityRequest struct {
+	Name      string    `json:"name" binding:"required,max=255"`
+	Email     string    `json:"email" binding:"required,email"`
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+}
+
+// GuestAvailabilityResponse represents the result of a guest submitting availability
+type GuestAvailabilityResponse struct {
+	Guest        *Guest        `json:"guest"`
+	Availability *Availability `json:"availability"`
+}
+
+// OrganizationRequest represents a request to create an organization
+type OrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+// CreateUserRequest represents a request to add a user to an organization
+type CreateUserRequest struct {
+	Name          string `json:"name" binding:"required,max=255"`
+	Email         string `json:"email" binding:"required,email,max=255"`
+	DefaultBuffer int    `json:"default_buffer" binding:"min=0,max=240"`
+}
+
+// TokenRequest represents a request to issue a bearer token for a user
+type TokenRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+	// ExpiresAt defaults to the configured token lifetime from now
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// TokenResponse represents an issued bearer token
+type TokenResponse struct {
+	Token          string    `json:"token"`
+	UserID         uuid.UUID `json:"user_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	ExpiresAt      time.Time `json:"expires_at"`
 }
@@ -4,25 +4,93 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // EventStatus represents the status of an event
 type EventStatus string
 
 const (
-	EventStatusDraft    EventStatus = "draft"
-	EventStatusActive   EventStatus = "active"
-	EventStatusCanceled EventStatus = "canceled"
+	EventStatusDraft     EventStatus = "draft"
+	EventStatusActive    EventStatus = "active"
+	EventStatusCanceled  EventStatus = "canceled"
+	EventStatusFinalized EventStatus = "finalized"
+)
+
+// AutoFinalizePolicy decides what happens when an event's response deadline passes
+type AutoFinalizePolicy string
+
+const (
+	// AutoFinalizeNone leaves the decision to the organizer, who is notified at the deadline
+	AutoFinalizeNone AutoFinalizePolicy = "none"
+	// AutoFinalizeTopRecommendation finalizes the top recommendation if it has
+	// quorum and notifies the organizer otherwise
+	AutoFinalizeTopRecommendation AutoFinalizePolicy = "top_recommendation"
+)
+
+// ScoringMode decides what recommendations are scored from
+type ScoringMode string
+
+const (
+	// ScoringAvailability scores slots from the availability ranges participants submitted
+	ScoringAvailability ScoringMode = "availability"
+	// ScoringVotes scores slots from the yes/no/maybe votes cast on them
+	ScoringVotes ScoringMode = "votes"
+	// ScoringCombined scores slots from both, with a vote on a slot taking
+	// precedence over the voter's availability ranges
+	ScoringCombined ScoringMode = "combined"
 )
 
 // Event represents a meeting or event
 type Event struct {
-	ID          uuid.UUID   `json:"id" gorm:"type:uuid;primary_key"`
-	Title       string      `json:"title" gorm:"not null"`
-	Description string      `json:"description"`
-	CreatorID   uuid.UUID   `json:"creator_id" gorm:"type:uuid;not null"`
-	Duration    int         `json:"duration" gorm:"not null"` // Duration in minutes
-	Status      EventStatus `json:"status" gorm:"not null"`
-	CreatedAt   time.Time   `json:"created_at" gorm:"not null"`
-	UpdatedAt   time.Time   `json:"updated_at" gorm:"not null"`
+	ID                  uuid.UUID            `json:"id" gorm:"type:uuid;primary_key"`
+	OrganizationID      uuid.UUID            `json:"organization_id" gorm:"type:uuid;not null"`
+	Title               string               `json:"title" gorm:"not null"`
+	Description         string               `json:"description"`
+	CreatorID           uuid.UUID            `json:"creator_id" gorm:"type:uuid;not null"`
+	Duration            int                  `json:"duration" gorm:"not null"` // Duration in minutes
+	Status              EventStatus          `json:"status" gorm:"not null"`
+	FinalTimeSlotID     *uuid.UUID           `json:"final_time_slot_id,omitempty" gorm:"type:uuid"` // Slot chosen when the event is finalized
+	ResponseDeadline    *time.Time           `json:"response_deadline,omitempty"`                   // Participants are reminded before it passes
+	AutoFinalize        AutoFinalizePolicy   `json:"auto_finalize" gorm:"not null;default:none"`
+	Quorum              int                  `json:"quorum" gorm:"not null;default:1"`                            // Attendees a slot needs to have quorum
+	QuorumPercent       int                  `json:"quorum_percent" gorm:"not null;default:0"`                    // Percentage of invited participants a slot needs instead, when set
+	RequiredGroups      []RequiredGroup      `json:"required_groups,omitempty" gorm:"type:jsonb;serializer:json"` // Groups a slot needs attendees from to have quorum
+	Scoring             ScoringMode          `json:"scoring" gorm:"not null;default:availability"`
+	ResourceRequirement *ResourceRequirement `json:"resource_requirement,omitempty" gorm:"type:jsonb;serializer:json"` // Resource every recommended slot needs free
+	BufferBefore        int                  `json:"buffer_before" gorm:"not null;default:0"`                          // Minutes participants need free before the meeting
+	BufferAfter         int                  `json:"buffer_after" gorm:"not null;default:0"`                           // Minutes participants need free after the meeting
+	ResourceID          *uuid.UUID           `json:"resource_id,omitempty" gorm:"type:uuid"`                           // Resource booked for the final time slot
+	DeadlineProcessedAt *time.Time           `json:"deadline_processed_at,omitempty"`                                  // When the deadline decision was made
+	Version             int                  `json:"version" gorm:"not null;default:1"`                                // Incremented by every update
+	CreatedAt           time.Time            `json:"created_at" gorm:"not null"`
+	UpdatedAt           time.Time            `json:"updated_at" gorm:"not null"`
+	DeletedAt           gorm.DeletedAt       `json:"deleted_at"` // Set while the event can still be restored
+}
+
+// RequiredGroup is a set of users a time slot needs attendees from, such as
+// one person from each team
+type RequiredGroup struct {
+	Name    string      `json:"name" binding:"required"`
+	UserIDs []uuid.UUID `json:"user_ids" binding:"required,min=1"`
+	Min     int         `json:"min" binding:"min=0"` // Attendees needed from the group; defaults to 1
+}
+
+// EventFilter selects events to list; unset fields match every event
+type EventFilter struct {
+	Status        []EventStatus
+	CreatorID     *uuid.UUID
+	ParticipantID *uuid.UUID // Events the user is invited to
+	CreatedFrom   *time.Time // Inclusive
+	CreatedTo     *time.Time // Exclusive
+	Title         string     // Text the title contains, ignoring case
+	Page          PageRequest
+}
+
+// ConfirmedMeeting is a finalized meeting a user organizes or takes part in
+type ConfirmedMeeting struct {
+	UserID    uuid.UUID `json:"user_id"`
+	EventID   uuid.UUID `json:"event_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
 }
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Group is a team or other set of users that can be invited to events as a unit
+type Group struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	Name           string    `json:"name" gorm:"not null"`
+	Description    string    `json:"description"`
+	CreatedAt      time.Time `json:"created_at" gorm:"not null"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"not null"`
+}
+
+// GroupMember is a user or a nested group belonging to a group
+type GroupMember struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	GroupID       uuid.UUID  `json:"group_id" gorm:"type:uuid;not null"`
+	UserID        *uuid.UUID `json:"user_id,omitempty" gorm:"type:uuid"`         // Set for user members
+	MemberGroupID *uuid.UUID `json:"member_group_id,omitempty" gorm:"type:uuid"` // Set for nested groups
+	CreatedAt     time.Time  `json:"created_at" gorm:"not null"`
+}
+
+// EventGroup is a group invited to an event. Its members are participants of
+// the event until the event is finalized or canceled.
+type EventGroup struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	EventID   uuid.UUID `json:"event_id" gorm:"type:uuid;not null"`
+	GroupID   uuid.UUID `json:"group_id" gorm:"type:uuid;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+}
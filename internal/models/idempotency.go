@@ -0,0 +1,20 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// IdempotencyRecord is the first response to a request sent with an
+// Idempotency-Key, replayed when the caller retries with the same key
+type IdempotencyRecord struct {
+	Caller      string          `json:"caller" gorm:"primaryKey"` // User ID, or client IP for routes without a token
+	Key         string          `json:"key" gorm:"primaryKey"`
+	RequestHash string          `json:"request_hash" gorm:"not null"` // Method, path and body of the first request
+	Completed   bool            `json:"completed" gorm:"not null;default:false"`
+	StatusCode  int             `json:"status_code"`
+	Header      json.RawMessage `json:"header,omitempty" gorm:"type:jsonb"`
+	Body        []byte          `json:"body,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ExpiresAt   time.Time       `json:"expires_at" gorm:"not null"` // End of the lease while in progress, of the replay once completed
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobStatus represents the state of a background job
+type JobStatus string
+
+const (
+	// JobPending jobs wait for their run time
+	JobPending JobStatus = "pending"
+	// JobRunning jobs are leased by a runner; they become claimable again when the lease expires
+	JobRunning JobStatus = "running"
+	// JobDead jobs used up their attempts and wait for an operator to retry them
+	JobDead JobStatus = "dead"
+)
+
+// Job is a unit of background work. Jobs are deleted once they succeed.
+type Job struct {
+	ID          uuid.UUID       `json:"id" gorm:"type:uuid;primary_key"`
+	Kind        string          `json:"kind" gorm:"not null"`
+	Payload     json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	Status      JobStatus       `json:"status" gorm:"not null"`
+	Attempts    int             `json:"attempts" gorm:"not null"`
+	MaxAttempts int             `json:"max_attempts" gorm:"not null"`
+	RunAt       time.Time       `json:"run_at" gorm:"not null"` // Next run, or lease expiry while running
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time       `json:"updated_at" gorm:"not null"`
+}
+
+// JobSchedule tracks the next run of a recurring job, shared by all replicas
+type JobSchedule struct {
+	Name      string    `json:"name" gorm:"primary_key"`
+	NextRunAt time.Time `json:"next_run_at" gorm:"not null"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationKind identifies the template a notification is rendered from
+type NotificationKind string
+
+const (
+	NotificationInvitation   NotificationKind = "invitation"
+	NotificationReminder     NotificationKind = "reminder"
+	NotificationConfirmation NotificationKind = "confirmation"
+	NotificationDeadline     NotificationKind = "deadline"
+)
+
+// NotificationStatus represents the delivery state of a notification
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+	NotificationFailed  NotificationStatus = "failed"
+)
+
+// Notification is a rendered email queued for sending
+type Notification struct {
+	ID            uuid.UUID          `json:"id" gorm:"type:uuid;primary_key"`
+	Kind          NotificationKind   `json:"kind" gorm:"not null"`
+	EventID       uuid.UUID          `json:"event_id" gorm:"type:uuid;not null"`
+	UserID        uuid.UUID          `json:"user_id" gorm:"type:uuid;not null"`
+	Recipient     string             `json:"recipient" gorm:"not null"`
+	DedupeKey     string             `json:"-" gorm:"not null"` // Only one notification is queued per key
+	Subject       string             `json:"subject" gorm:"not null"`
+	TextBody      string             `json:"-" gorm:"not null"`
+	HTMLBody      string             `json:"-"`
+	Calendar      string             `json:"-"` // iCalendar attachment, if any
+	Status        NotificationStatus `json:"status" gorm:"not null"`
+	Attempts      int                `json:"attempts" gorm:"not null"`
+	NextAttemptAt time.Time          `json:"next_attempt_at" gorm:"not null"`
+	LastError     string             `json:"last_error,omitempty"`
+	SentAt        *time.Time         `json:"sent_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" gorm:"not null"`
+	UpdatedAt     time.Time          `json:"updated_at" gorm:"not null"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization is a tenant such as a business unit. Events, users, time slots,
+// availability, groups, resources and webhook subscriptions belong to exactly
+// one and are invisible to the others.
+type Organization struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Name      string    `json:"name" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+}
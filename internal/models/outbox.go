@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lifecycle topics recorded in the outbox when scheduling data changes
+const (
+	TopicEventCreated          = "event.created"
+	TopicEventUpdated          = "event.updated"
+	TopicEventDeleted          = "event.deleted"
+	TopicEventRestored         = "event.restored"
+	TopicEventPublished        = "event.published"
+	TopicEventDeadlinePassed   = "event.deadline_passed"
+	TopicTimeSlotCreated       = "timeslot.created"
+	TopicTimeSlotUpdated       = "timeslot.updated"
+	TopicTimeSlotDeleted       = "timeslot.deleted"
+	TopicTimeSlotRestored      = "timeslot.restored"
+	TopicTimeSlotFinalized     = "timeslot.finalized"
+	TopicAvailabilitySubmitted = "availability.submitted"
+	TopicAvailabilityUpdated   = "availability.updated"
+	TopicAvailabilityDeleted   = "availability.deleted"
+	TopicAvailabilityRestored  = "availability.restored"
+	TopicVoteCast              = "vote.cast"
+	TopicVoteRetracted         = "vote.retracted"
+	TopicParticipantAdded      = "participant.added"
+	TopicParticipantRemoved    = "participant.removed"
+)
+
+// OutboxMessage is a lifecycle notification written in the same transaction
+// as the change it describes and relayed to consumers after commit
+type OutboxMessage struct {
+	ID             uuid.UUID       `json:"id" gorm:"type:uuid;primary_key"`
+	Topic          string          `json:"topic" gorm:"not null"`
+	OrganizationID *uuid.UUID      `json:"organization_id,omitempty" gorm:"type:uuid"` // Organization whose change the message describes; handlers act for it
+	EventID        uuid.UUID       `json:"event_id" gorm:"type:uuid;not null"`
+	CreatorID      *uuid.UUID      `json:"creator_id,omitempty" gorm:"type:uuid"` // Resolved from the event when not known at write time
+	Payload        json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"not null"`
+	ProcessedAt    *time.Time      `json:"processed_at,omitempty"`
+	Attempts       int             `json:"attempts" gorm:"not null"` // Failed attempts to relay the message
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" gorm:"not null"`
+	DeadAt         *time.Time      `json:"dead_at,omitempty"` // Set once the message used up its attempts; it is no longer relayed
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// PageRequest selects one page of a listing
+type PageRequest struct {
+	Limit  int
+	Cursor string // next_cursor of the previous page; empty for the first page
+	Sort   string // Field to order by, prefixed with - for descending; empty for the listing's default
+}
+
+// PageInfo describes the page of a listing that was returned
+type PageInfo struct {
+	NextCursor string `json:"next_cursor,omitempty"` // Empty on the last page
+	Total      int64  `json:"total"`                 // Items matching the filters across all pages
+	Limit      int    `json:"limit"`
+}
+
+// Cursor is the position after the last item of a page: its value of the
+// sort field and its ID, which breaks ties. Clients treat it as opaque.
+type Cursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// Encode returns the cursor in the form clients pass back
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by Encode
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
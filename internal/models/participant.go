@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventParticipant represents a user invited to an event
+type EventParticipant struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	EventID   uuid.UUID  `json:"event_id" gorm:"type:uuid;not null"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	GroupID   *uuid.UUID `json:"group_id,omitempty" gorm:"type:uuid"` // Invited group the user was added through; nil when invited directly
+	CreatedAt time.Time  `json:"created_at" gorm:"not null"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResourceKind is the kind of a bookable resource
+type ResourceKind string
+
+const (
+	ResourceRoom        ResourceKind = "room"
+	ResourceEquipment   ResourceKind = "equipment"
+	ResourceVideoBridge ResourceKind = "video_bridge"
+)
+
+// Resource is a room, piece of equipment or video bridge meetings can be held with
+type Resource struct {
+	ID             uuid.UUID    `json:"id" gorm:"type:uuid;primary_key"`
+	OrganizationID uuid.UUID    `json:"organization_id" gorm:"type:uuid;not null"`
+	Name           string       `json:"name" gorm:"not null"`
+	Kind           ResourceKind `json:"kind" gorm:"not null"`
+	Capacity       int          `json:"capacity" gorm:"not null"`                              // People the resource holds; 0 when it does not apply
+	Attributes     []string     `json:"attributes" gorm:"type:jsonb;serializer:json;not null"` // Features such as "projector"
+	CreatedAt      time.Time    `json:"created_at" gorm:"not null"`
+	UpdatedAt      time.Time    `json:"updated_at" gorm:"not null"`
+}
+
+// ResourceBooking reserves a resource for a period, either for the final time
+// slot of an event or as a manual block such as maintenance
+type ResourceBooking struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	ResourceID uuid.UUID  `json:"resource_id" gorm:"type:uuid;not null"`
+	EventID    *uuid.UUID `json:"event_id,omitempty" gorm:"type:uuid"`
+	TimeSlotID *uuid.UUID `json:"time_slot_id,omitempty" gorm:"type:uuid"`
+	StartTime  time.Time  `json:"start_time" gorm:"not null"`
+	EndTime    time.Time  `json:"end_time" gorm:"not null"`
+	Note       string     `json:"note,omitempty"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"not null"`
+}
+
+// ResourceRequirement describes the resource an event has to be held with
+type ResourceRequirement struct {
+	Kind        ResourceKind `json:"kind" binding:"required,oneof=room equipment video_bridge"`
+	MinCapacity int          `json:"min_capacity" binding:"min=0"`
+	Attributes  []string     `json:"attributes"` // Every one of them must be present
+}
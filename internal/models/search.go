@@ -0,0 +1,24 @@
+package models
+
+import "github.com/google/uuid"
+
+// Highlight markers wrapped around the words that matched a search
+const (
+	HighlightStart = "<b>"
+	HighlightStop  = "</b>"
+)
+
+// SearchQuery asks for the events matching free text
+type SearchQuery struct {
+	Text  string // Words to match; quoted phrases, OR and -word are understood by the postgres backend
+	Limit int
+}
+
+// SearchResult is an event matching a search. Results come best match first.
+type SearchResult struct {
+	EventID              uuid.UUID `json:"event_id"`
+	Title                string    `json:"title"`
+	Rank                 float64   `json:"rank"`                  // Higher is a better match; only comparable within one search
+	TitleHighlight       string    `json:"title_highlight"`       // Title with the matching words highlighted
+	DescriptionHighlight string    `json:"description_highlight"` // Excerpts of the description around the matching words
+}
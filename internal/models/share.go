@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShareLink lets people without an account respond to one event. The token
+// handed out for it is signed and carries its expiry, so only revocation
+// needs the stored record.
+type ShareLink struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	EventID   uuid.UUID  `json:"event_id" gorm:"type:uuid;not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"not null"`
+}
+
+// Guest is a participant without an account who joined an event through a
+// share link. Guests are identified by email within their event, and their
+// availability is recorded under the guest ID.
+type Guest struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	EventID     uuid.UUID `json:"event_id" gorm:"type:uuid;not null"`
+	ShareLinkID uuid.UUID `json:"share_link_id" gorm:"type:uuid;not null"` // Link the guest first responded through
+	Name        string    `json:"name" gorm:"not null"`
+	Email       string    `json:"email" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"not null"`
+}
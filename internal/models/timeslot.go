@@ -4,14 +4,18 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // TimeSlot represents a potential time slot for an event
 type TimeSlot struct {
-	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
-	EventID   uuid.UUID `json:"event_id" gorm:"type:uuid;not null"`
-	StartTime time.Time `json:"start_time" gorm:"not null"`
-	EndTime   time.Time `json:"end_time" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"not null"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+	ID             uuid.UUID      `json:"id" gorm:"type:uuid;primary_key"`
+	OrganizationID uuid.UUID      `json:"organization_id" gorm:"type:uuid;not null"`
+	EventID        uuid.UUID      `json:"event_id" gorm:"type:uuid;not null"`
+	StartTime      time.Time      `json:"start_time" gorm:"not null"`
+	EndTime        time.Time      `json:"end_time" gorm:"not null"`
+	Version        int            `json:"version" gorm:"not null;default:1"` // Incremented by every update
+	CreatedAt      time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at"` // Set while the row can still be restored
 }
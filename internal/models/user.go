@@ -8,9 +8,11 @@ import (
 
 // User represents a system user
 type User struct {
-	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
-	Name      string    `json:"name" gorm:"not null"`
-	Email     string    `json:"email" gorm:"unique;not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"not null"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	Name           string    `json:"name" gorm:"not null"`
+	Email          string    `json:"email" gorm:"not null"`                    // Unique within the organization
+	DefaultBuffer  int       `json:"default_buffer" gorm:"not null;default:0"` // Minutes kept free around meetings when the event asks for less
+	CreatedAt      time.Time `json:"created_at" gorm:"not null"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"not null"`
 }
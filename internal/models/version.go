@@ -0,0 +1,58 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// Precondition holds the entity tags of an If-Match header. A write with a
+// precondition only goes ahead while what it changes still has one of those
+// tags; the zero value allows any.
+type Precondition struct {
+	IfMatch []string
+}
+
+// Allows reports whether a representation with the given entity tag may be changed
+func (p Precondition) Allows(etag string) bool {
+	if p.IfMatch == nil {
+		return true
+	}
+	for _, tag := range p.IfMatch {
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// VersionETag returns the strong entity tag of a row at a version
+func VersionETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ETag returns the entity tag of the event's current version
+func (e *Event) ETag() string {
+	return VersionETag(e.Version)
+}
+
+// ETag returns the entity tag of the time slot's current version
+func (s *TimeSlot) ETag() string {
+	return VersionETag(s.Version)
+}
+
+// ETag returns the entity tag of the availability record's current version
+func (a *Availability) ETag() string {
+	return VersionETag(a.Version)
+}
+
+// AvailabilityListETag returns the entity tag of a list of availability
+// records. It changes whenever a record is added, removed or updated.
+func AvailabilityListETag(availabilities []*Availability) string {
+	hash := sha256.New()
+	for _, availability := range availabilities {
+		fmt.Fprintf(hash, "%s:%d;", availability.ID, availability.Version)
+	}
+	return strconv.Quote(hex.EncodeToString(hash.Sum(nil))[:16])
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VoteChoice is a participant's answer to a proposed time slot
+type VoteChoice string
+
+const (
+	VoteYes   VoteChoice = "yes"
+	VoteNo    VoteChoice = "no"
+	VoteMaybe VoteChoice = "maybe"
+)
+
+// Vote is a user's answer to one time slot of an event. Each user has at most
+// one vote per slot.
+type Vote struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	EventID    uuid.UUID  `json:"event_id" gorm:"type:uuid;not null"`
+	TimeSlotID uuid.UUID  `json:"time_slot_id" gorm:"type:uuid;not null"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	Choice     VoteChoice `json:"choice" gorm:"not null"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"not null"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"not null"`
+}
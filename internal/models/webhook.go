@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDeliveryStatus represents the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookSubscription registers a URL to be called for lifecycle events of a
+// single event or of every event created by a user
+type WebhookSubscription struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	EventID        *uuid.UUID `json:"event_id,omitempty" gorm:"type:uuid"`
+	CreatorID      *uuid.UUID `json:"creator_id,omitempty" gorm:"type:uuid"`
+	URL            string     `json:"url" gorm:"not null"`
+	Secret         string     `json:"-" gorm:"not null"`
+	EventTypes     string     `json:"-"` // Comma-separated topics; empty means all
+	Active         bool       `json:"active" gorm:"not null"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"not null"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"not null"`
+}
+
+// WebhookDelivery is one entry in the delivery log
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id" gorm:"type:uuid;primary_key"`
+	SubscriptionID uuid.UUID             `json:"subscription_id" gorm:"type:uuid;not null"`
+	MessageID      uuid.UUID             `json:"message_id" gorm:"type:uuid;not null"`
+	Topic          string                `json:"topic" gorm:"not null"`
+	Payload        json.RawMessage       `json:"payload" gorm:"type:jsonb;not null"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"not null"`
+	Attempts       int                   `json:"attempts" gorm:"not null"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" gorm:"not null"`
+	ResponseStatus int                   `json:"response_status,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at" gorm:"not null"`
+	UpdatedAt      time.Time             `json:"updated_at" gorm:"not null"`
+}
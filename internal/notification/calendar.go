@@ -0,0 +1,99 @@
+package notification
+
+import (
+	"strings"
+	"time"
+)
+
+// CalendarAttendee is a person listed on a calendar invite
+type CalendarAttendee struct {
+	Name  string
+	Email string
+}
+
+// CalendarEvent describes a meeting to put on a calendar
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	Organizer   CalendarAttendee
+	Attendees   []CalendarAttendee
+}
+
+const calendarTimeFormat = "20060102T150405Z"
+
+// BuildCalendar renders an iCalendar (RFC 5545) REQUEST for the event
+func BuildCalendar(event CalendarEvent, now time.Time) string {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(foldCalendarLine(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//meeting-scheduler//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:REQUEST")
+	line("BEGIN:VEVENT")
+	line("UID:" + event.UID)
+	line("DTSTAMP:" + now.UTC().Format(calendarTimeFormat))
+	line("DTSTART:" + event.Start.UTC().Format(calendarTimeFormat))
+	line("DTEND:" + event.End.UTC().Format(calendarTimeFormat))
+	line("SUMMARY:" + escapeCalendarText(event.Summary))
+	if event.Description != "" {
+		line("DESCRIPTION:" + escapeCalendarText(event.Description))
+	}
+	if event.Organizer.Email != "" {
+		line("ORGANIZER;CN=" + quoteCalendarParam(event.Organizer.Name) + ":mailto:" + event.Organizer.Email)
+	}
+	for _, attendee := range event.Attendees {
+		line("ATTENDEE;CN=" + quoteCalendarParam(attendee.Name) + ";ROLE=REQ-PARTICIPANT;RSVP=FALSE:mailto:" + attendee.Email)
+	}
+	line("STATUS:CONFIRMED")
+	line("SEQUENCE:0")
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+
+	return b.String()
+}
+
+// escapeCalendarText escapes a TEXT value
+func escapeCalendarText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// quoteCalendarParam quotes a parameter value, dropping characters it cannot contain
+func quoteCalendarParam(s string) string {
+	return `"` + strings.NewReplacer(`"`, "", "\r", "", "\n", " ").Replace(s) + `"`
+}
+
+// foldCalendarLine splits content lines longer than 75 octets, without
+// breaking UTF-8 sequences
+func foldCalendarLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
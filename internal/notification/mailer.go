@@ -0,0 +1,218 @@
+// Package notification renders and sends email notifications.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is an email ready to be sent
+type Message struct {
+	From     string
+	To       string
+	Subject  string
+	Text     string
+	HTML     string
+	Calendar string // iCalendar invite attached as invite.ics when set
+}
+
+// Mailer delivers email messages
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// SMTPConfig configures the SMTP transport
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	StartTLS bool // Require upgrading the connection with STARTTLS
+	Timeout  time.Duration
+}
+
+// SMTPMailer sends messages through an SMTP server
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer creates a new SMTPMailer
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send delivers msg over a new SMTP connection
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	body, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := net.Dialer{Timeout: m.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else if m.cfg.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(m.cfg.Timeout))
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if m.cfg.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: m.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// LogMailer writes messages to the log instead of sending them, for local development
+type LogMailer struct{}
+
+// Send logs the message envelope and subject
+func (LogMailer) Send(ctx context.Context, msg *Message) error {
+	log.Printf("email to %s: %s (calendar attached: %t)", msg.To, msg.Subject, msg.Calendar != "")
+	return nil
+}
+
+// Bytes renders the message in MIME format. The text and HTML bodies are sent
+// as alternatives and the calendar, if any, as an attachment.
+func (msg *Message) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+
+	header("From", msg.From)
+	header("To", msg.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", "<"+uuid.NewString()+"@meeting-scheduler>")
+	header("MIME-Version", "1.0")
+
+	mixed := multipart.NewWriter(&buf)
+	header("Content-Type", `multipart/mixed; boundary="`+mixed.Boundary()+`"`)
+	buf.WriteString("\r\n")
+
+	// Text and HTML alternatives
+	var alternative bytes.Buffer
+	alt := multipart.NewWriter(&alternative)
+	if err := writeQuotedPrintable(alt, "text/plain; charset=utf-8", msg.Text); err != nil {
+		return nil, err
+	}
+	if msg.HTML != "" {
+		if err := writeQuotedPrintable(alt, "text/html; charset=utf-8", msg.HTML); err != nil {
+			return nil, err
+		}
+	}
+	if err := alt.Close(); err != nil {
+		return nil, err
+	}
+	part, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {`multipart/alternative; boundary="` + alt.Boundary() + `"`},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(alternative.Bytes()); err != nil {
+		return nil, err
+	}
+
+	// Calendar invite
+	if msg.Calendar != "" {
+		part, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {`text/calendar; charset=utf-8; method=REQUEST; name="invite.ics"`},
+			"Content-Disposition":       {`attachment; filename="invite.ics"`},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(part)
+		if _, err := qp.Write([]byte(msg.Calendar)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeQuotedPrintable adds a quoted-printable encoded part to w
+func writeQuotedPrintable(w *multipart.Writer, contentType, body string) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
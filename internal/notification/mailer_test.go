@@ -0,0 +1,171 @@
+package notification_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/npkanaka/meeting-scheduler/internal/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts a single SMTP session and records the envelope and data
+type fakeSMTPServer struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     chan string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTPServer{listener: listener, data: make(chan string, 1)}
+	t.Cleanup(func() { listener.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	reply("220 localhost fake SMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimRight(line, "\r\n")
+		switch upper := strings.ToUpper(cmd); {
+		case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			s.from = cmd[len("MAIL FROM:"):]
+			reply("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			s.to = append(s.to, cmd[len("RCPT TO:"):])
+			reply("250 OK")
+		case upper == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			s.data <- data.String()
+			reply("250 OK")
+		case upper == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPMailerSendsMultipartMessageWithCalendar(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	mailer := notification.NewSMTPMailer(notification.SMTPConfig{
+		Host:    "127.0.0.1",
+		Port:    server.port(),
+		Timeout: 5 * time.Second,
+	})
+
+	err := mailer.Send(context.Background(), &notification.Message{
+		From:     "Meeting Scheduler <no-reply@example.com>",
+		To:       `"Jane Smith" <jane@example.com>`,
+		Subject:  "Confirmed: Café planning",
+		Text:     "See you there",
+		HTML:     "<p>See you there</p>",
+		Calendar: "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+	})
+	require.NoError(t, err)
+
+	var raw string
+	select {
+	case raw = <-server.data:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+	assert.Equal(t, "<no-reply@example.com>", server.from)
+	assert.Equal(t, []string{"<jane@example.com>"}, server.to)
+
+	msg, err := mail.ReadMessage(strings.NewReader(raw))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Confirmed: Café planning", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	alternative, err := parts.NextPart()
+	require.NoError(t, err)
+	mediaType, params, err = mime.ParseMediaType(alternative.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	bodies := multipart.NewReader(alternative, params["boundary"])
+	var contentTypes []string
+	for {
+		part, err := bodies.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+	}
+	assert.Equal(t, []string{"text/plain; charset=utf-8", "text/html; charset=utf-8"}, contentTypes)
+
+	attachment, err := parts.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "invite.ics", attachment.FileName())
+	calendar, err := io.ReadAll(quotedprintable.NewReader(attachment))
+	require.NoError(t, err)
+	assert.Equal(t, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", string(calendar))
+}
+
+func TestSMTPMailerRequiresStartTLSWhenConfigured(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	mailer := notification.NewSMTPMailer(notification.SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     server.port(),
+		StartTLS: true,
+		Timeout:  5 * time.Second,
+	})
+
+	err := mailer.Send(context.Background(), &notification.Message{
+		From: "no-reply@example.com",
+		To:   "jane@example.com",
+		Text: "hello",
+	})
+	assert.ErrorContains(t, err, "STARTTLS")
+}
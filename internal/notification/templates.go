@@ -0,0 +1,136 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// Kinds lists the notification kinds that have templates
+var Kinds = []string{"invitation", "reminder", "confirmation", "deadline"}
+
+// Person is a recipient or organizer as seen by templates
+type Person struct {
+	Name  string
+	Email string
+}
+
+// TemplateData is passed to every template
+type TemplateData struct {
+	Recipient   Person
+	Organizer   Person
+	Title       string
+	Description string
+	Duration    int        // Minutes
+	Deadline    *time.Time // Response deadline, if any
+	Start       *time.Time // Final meeting time, for confirmations
+	End         *time.Time
+	EventURL    string
+	Reason      string // Why the organizer has to decide, for deadline notices
+}
+
+// Rendered is the output of rendering a notification
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type kindTemplates struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// Templates renders notification emails. Each kind has a subject, a plain text
+// and an HTML template.
+type Templates struct {
+	kinds map[string]*kindTemplates
+}
+
+var templateFuncs = map[string]interface{}{
+	"datetime": func(t time.Time) string { return t.UTC().Format("Mon, 02 Jan 2006 15:04 MST") },
+}
+
+// LoadTemplates loads the built-in templates, replacing any found in dir.
+// Overrides are named <kind>.subject.tmpl, <kind>.txt.tmpl and <kind>.html.tmpl.
+func LoadTemplates(dir string) (*Templates, error) {
+	t := &Templates{kinds: make(map[string]*kindTemplates, len(Kinds))}
+	for _, kind := range Kinds {
+		subject, err := readTemplate(dir, kind+".subject.tmpl")
+		if err != nil {
+			return nil, err
+		}
+		text, err := readTemplate(dir, kind+".txt.tmpl")
+		if err != nil {
+			return nil, err
+		}
+		html, err := readTemplate(dir, kind+".html.tmpl")
+		if err != nil {
+			return nil, err
+		}
+
+		kt := &kindTemplates{}
+		if kt.subject, err = texttemplate.New(kind + ".subject").Funcs(templateFuncs).Parse(subject); err != nil {
+			return nil, err
+		}
+		if kt.text, err = texttemplate.New(kind + ".txt").Funcs(templateFuncs).Parse(text); err != nil {
+			return nil, err
+		}
+		if kt.html, err = htmltemplate.New(kind + ".html").Funcs(templateFuncs).Parse(html); err != nil {
+			return nil, err
+		}
+		t.kinds[kind] = kt
+	}
+	return t, nil
+}
+
+// Render renders the templates of a kind with data
+func (t *Templates) Render(kind string, data *TemplateData) (*Rendered, error) {
+	kt, ok := t.kinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("no templates for notification kind %q", kind)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := kt.subject.Execute(&subject, data); err != nil {
+		return nil, err
+	}
+	if err := kt.text.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := kt.html.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	return &Rendered{
+		Subject: string(bytes.TrimSpace(subject.Bytes())),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// readTemplate returns the override in dir when present, otherwise the built-in template
+func readTemplate(dir, name string) (string, error) {
+	if dir != "" {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			return string(b), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	b, err := defaultTemplates.ReadFile("templates/" + name)
+	return string(b), err
+}
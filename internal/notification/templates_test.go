@@ -0,0 +1,81 @@
+package notification_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/npkanaka/meeting-scheduler/internal/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplatesRenderBuiltIns(t *testing.T) {
+	templates, err := notification.LoadTemplates("")
+	require.NoError(t, err)
+
+	deadline := time.Date(2025, 1, 10, 17, 0, 0, 0, time.UTC)
+	rendered, err := templates.Render("invitation", &notification.TemplateData{
+		Recipient: notification.Person{Name: "Jane <Smith>"},
+		Organizer: notification.Person{Name: "Admin User"},
+		Title:     "Team Brainstorming",
+		Duration:  60,
+		Deadline:  &deadline,
+		EventURL:  "https://scheduler.example.com/events/1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "You're invited: Team Brainstorming", rendered.Subject)
+	assert.Contains(t, rendered.Text, "Hi Jane <Smith>,")
+	assert.Contains(t, rendered.Text, "by Fri, 10 Jan 2025 17:00 UTC")
+	assert.Contains(t, rendered.HTML, "Hi Jane &lt;Smith&gt;,")
+}
+
+func TestTemplatesOverridesFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "reminder.subject.tmpl"), []byte("Nudge: {{.Title}}"), 0o644))
+
+	templates, err := notification.LoadTemplates(dir)
+	require.NoError(t, err)
+
+	rendered, err := templates.Render("reminder", &notification.TemplateData{Title: "Kickoff"})
+	require.NoError(t, err)
+	assert.Equal(t, "Nudge: Kickoff", rendered.Subject)
+	// Templates without an override fall back to the built-in ones
+	assert.Contains(t, rendered.Text, `still waiting for your availability for "Kickoff"`)
+}
+
+func TestTemplatesRejectInvalidOverride(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "confirmation.txt.tmpl"), []byte("{{.Title"), 0o644))
+
+	_, err := notification.LoadTemplates(dir)
+	assert.Error(t, err)
+}
+
+func TestBuildCalendar(t *testing.T) {
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	calendar := notification.BuildCalendar(notification.CalendarEvent{
+		UID:         "event-1@meeting-scheduler",
+		Summary:     "Planning; budget, roadmap",
+		Description: strings.Repeat("Agenda item ", 10),
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Organizer:   notification.CalendarAttendee{Name: "Admin User", Email: "admin@example.com"},
+		Attendees:   []notification.CalendarAttendee{{Name: "Jane Smith", Email: "jane@example.com"}},
+	}, start.Add(-24*time.Hour))
+
+	assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\n"))
+	assert.Contains(t, calendar, "METHOD:REQUEST\r\n")
+	assert.Contains(t, calendar, "DTSTART:20250115T100000Z\r\n")
+	assert.Contains(t, calendar, "DTEND:20250115T110000Z\r\n")
+	assert.Contains(t, calendar, `SUMMARY:Planning\; budget\, roadmap`)
+	// Long lines are folded and must be unfolded before matching
+	unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+	assert.Contains(t, unfolded, `ATTENDEE;CN="Jane Smith";ROLE=REQ-PARTICIPANT;RSVP=FALSE:mailto:jane@example.com`)
+	for _, line := range strings.Split(calendar, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "line not folded: %q", line)
+	}
+}
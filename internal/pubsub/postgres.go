@@ -0,0 +1,126 @@
+package pubsub
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/npkanaka/meeting-scheduler/pkg/timeutil"
+)
+
+// maxNotifyPayload stays below the 8000 byte limit Postgres puts on NOTIFY payloads
+const maxNotifyPayload = 7900
+
+// PostgresBroker publishes messages with NOTIFY and delivers the ones received
+// on a dedicated LISTEN connection to local subscribers, so every replica sees
+// every update
+type PostgresBroker struct {
+	hub     *hub
+	db      *sql.DB
+	dsn     string
+	channel string
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewPostgresBroker creates a PostgresBroker that publishes through db and
+// listens on its own connection to dsn until Close is called
+func NewPostgresBroker(db *sql.DB, dsn, channel string) *PostgresBroker {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &PostgresBroker{
+		hub:     newHub(),
+		db:      db,
+		dsn:     dsn,
+		channel: channel,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go b.listen(ctx)
+	return b
+}
+
+// Publish notifies every replica of msg. Data too large for a notification is
+// left out, and subscribers receive only the topic and event.
+func (b *PostgresBroker) Publish(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		msg.Data = nil
+		if payload, err = json.Marshal(msg); err != nil {
+			return err
+		}
+	}
+
+	_, err = b.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", b.channel, string(payload))
+	return err
+}
+
+// Subscribe receives the messages about an event
+func (b *PostgresBroker) Subscribe(eventID uuid.UUID) *Subscription {
+	return b.hub.subscribe(eventID)
+}
+
+// Close stops listening and drops every subscriber
+func (b *PostgresBroker) Close() error {
+	b.cancel()
+	<-b.done
+	b.hub.close()
+	return nil
+}
+
+// listen keeps a LISTEN connection open, reconnecting with backoff
+func (b *PostgresBroker) listen(ctx context.Context) {
+	defer close(b.done)
+
+	failures := 0
+	for {
+		err := b.listenOnce(ctx, func() { failures = 0 })
+		if ctx.Err() != nil {
+			return
+		}
+		failures++
+		log.Printf("listening for event updates failed: %v", err)
+		// Updates sent while disconnected are lost, so subscribers start over
+		b.hub.dropAll()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(timeutil.Backoff(failures, 100*time.Millisecond, 30*time.Second)):
+		}
+	}
+}
+
+// listenOnce delivers notifications until the connection fails. connected is
+// called once LISTEN is in effect.
+func (b *PostgresBroker) listenOnce(ctx context.Context, connected func()) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return err
+	}
+	connected()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var msg Message
+		if err := json.Unmarshal([]byte(notification.Payload), &msg); err != nil {
+			log.Printf("discarding malformed event update: %v", err)
+			continue
+		}
+		b.hub.deliver(msg)
+	}
+}
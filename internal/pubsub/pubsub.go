@@ -0,0 +1,149 @@
+// Package pubsub fans out updates about events to subscribers such as open
+// SSE streams. The memory broker only reaches subscribers in the same
+// process; the Postgres broker relays updates through LISTEN/NOTIFY so they
+// reach subscribers connected to any replica.
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// subscriberBuffer is how many updates a subscriber may fall behind before it is dropped
+const subscriberBuffer = 32
+
+// Message is an update about one event
+type Message struct {
+	Topic   string          `json:"topic"`
+	EventID uuid.UUID       `json:"event_id"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Broker publishes messages to the subscribers of their event
+type Broker interface {
+	Publish(ctx context.Context, msg Message) error
+	// Subscribe receives the messages about an event until the subscription
+	// is closed or dropped
+	Subscribe(eventID uuid.UUID) *Subscription
+	// Close drops every subscriber and stops accepting new ones
+	Close() error
+}
+
+// Subscription delivers the messages about one event. C is closed when the
+// subscription is closed, when the subscriber falls too far behind, and when
+// the broker shuts down or loses updates; subscribers should then start over
+// from the current state.
+type Subscription struct {
+	C     <-chan Message
+	close func()
+}
+
+// Close stops the subscription
+func (s *Subscription) Close() {
+	s.close()
+}
+
+// hub fans messages out to the local subscribers of each event
+type hub struct {
+	mu     sync.Mutex
+	subs   map[uuid.UUID]map[chan Message]struct{}
+	closed bool
+}
+
+func newHub() *hub {
+	return &hub{subs: make(map[uuid.UUID]map[chan Message]struct{})}
+}
+
+func (h *hub) subscribe(eventID uuid.UUID) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan Message, subscriberBuffer)
+	if h.closed {
+		close(ch)
+		return &Subscription{C: ch, close: func() {}}
+	}
+	if h.subs[eventID] == nil {
+		h.subs[eventID] = make(map[chan Message]struct{})
+	}
+	h.subs[eventID][ch] = struct{}{}
+
+	return &Subscription{C: ch, close: func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(eventID, ch)
+	}}
+}
+
+// deliver hands msg to the subscribers of its event, dropping those whose buffer is full
+func (h *hub) deliver(msg Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[msg.EventID] {
+		select {
+		case ch <- msg:
+		default:
+			h.remove(msg.EventID, ch)
+		}
+	}
+}
+
+// dropAll closes every subscription
+func (h *hub) dropAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for eventID, subs := range h.subs {
+		for ch := range subs {
+			h.remove(eventID, ch)
+		}
+	}
+}
+
+func (h *hub) close() {
+	h.dropAll()
+	h.mu.Lock()
+	h.closed = true
+	h.mu.Unlock()
+}
+
+// remove closes a subscriber channel once; h.mu must be held
+func (h *hub) remove(eventID uuid.UUID, ch chan Message) {
+	if _, ok := h.subs[eventID][ch]; !ok {
+		return
+	}
+	delete(h.subs[eventID], ch)
+	if len(h.subs[eventID]) == 0 {
+		delete(h.subs, eventID)
+	}
+	close(ch)
+}
+
+// MemoryBroker delivers messages to subscribers in the same process
+type MemoryBroker struct {
+	hub *hub
+}
+
+// NewMemoryBroker creates a new MemoryBroker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{hub: newHub()}
+}
+
+// Publish delivers msg to the subscribers of its event
+func (b *MemoryBroker) Publish(ctx context.Context, msg Message) error {
+	b.hub.deliver(msg)
+	return nil
+}
+
+// Subscribe receives the messages about an event
+func (b *MemoryBroker) Subscribe(eventID uuid.UUID) *Subscription {
+	return b.hub.subscribe(eventID)
+}
+
+// Close drops every subscriber
+func (b *MemoryBroker) Close() error {
+	b.hub.close()
+	return nil
+}
@@ -0,0 +1,60 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBrokerDeliversToSubscribersOfTheEvent(t *testing.T) {
+	broker := pubsub.NewMemoryBroker()
+	eventID := uuid.New()
+	first := broker.Subscribe(eventID)
+	second := broker.Subscribe(eventID)
+	other := broker.Subscribe(uuid.New())
+	defer first.Close()
+	defer second.Close()
+	defer other.Close()
+
+	msg := pubsub.Message{Topic: "availability.submitted", EventID: eventID}
+	require.NoError(t, broker.Publish(context.Background(), msg))
+
+	assert.Equal(t, msg, <-first.C)
+	assert.Equal(t, msg, <-second.C)
+	assert.Empty(t, other.C)
+}
+
+func TestMemoryBrokerDropsSubscribersThatFallBehind(t *testing.T) {
+	broker := pubsub.NewMemoryBroker()
+	eventID := uuid.New()
+	sub := broker.Subscribe(eventID)
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, broker.Publish(context.Background(), pubsub.Message{Topic: "timeslot.created", EventID: eventID}))
+	}
+
+	received := 0
+	for range sub.C {
+		received++
+	}
+	assert.Less(t, received, 100)
+	// Closing a dropped subscription is harmless
+	sub.Close()
+}
+
+func TestMemoryBrokerCloseEndsSubscriptions(t *testing.T) {
+	broker := pubsub.NewMemoryBroker()
+	sub := broker.Subscribe(uuid.New())
+
+	require.NoError(t, broker.Close())
+	_, ok := <-sub.C
+	assert.False(t, ok)
+
+	late := broker.Subscribe(uuid.New())
+	_, ok = <-late.C
+	assert.False(t, ok)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+)
+
+// AuditRepository defines the interface for the append-only audit trail
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditEntry) error
+	List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEntry, error)
+}
+
+// GormAuditRepository implements AuditRepository using GORM
+type GormAuditRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAuditRepository creates a new GormAuditRepository
+func NewGormAuditRepository(db *gorm.DB) *GormAuditRepository {
+	return &GormAuditRepository{db: db}
+}
+
+// Create appends an entry to the audit trail
+func (r *GormAuditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if err := stamp(ctx, &entry.OrganizationID); err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Create(entry).Error
+}
+
+// List retrieves the entries matching filter, most recent first
+func (r *GormAuditRepository) List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEntry, error) {
+	query := scoped(ctx, r.db, "audit_entries")
+	if filter.OrganizationID != nil {
+		query = query.Where("organization_id = ?", *filter.OrganizationID)
+	}
+	if filter.EventID != nil {
+		query = query.Where("event_id = ?", *filter.EventID)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var entries []*models.AuditEntry
+	err := query.Order("created_at DESC, id DESC").Limit(filter.Limit).Find(&entries).Error
+	return entries, err
+}
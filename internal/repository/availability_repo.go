@@ -2,19 +2,37 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
 	"github.com/npkanaka/meeting-scheduler/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // AvailabilityRepository defines the interface for availability data access
 type AvailabilityRepository interface {
 	Create(ctx context.Context, availability *models.Availability) error
+	// Update writes an availability record read at availability.Version and increments the
+	// version. It fails with ErrStaleVersion when the availability record has changed since.
 	Update(ctx context.Context, availability *models.Availability) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete soft-deletes an availability read at version. It fails with
+	// ErrStaleVersion when the availability has changed since.
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	// GetDeletedByID retrieves a soft-deleted availability by its ID
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Availability, error)
+	// Restore brings back a soft-deleted availability
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge permanently removes the availability entries deleted before the
+	// given time and returns them
+	Purge(ctx context.Context, before time.Time) ([]*models.Availability, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Availability, error)
 	GetByUserAndEvent(ctx context.Context, userID, eventID uuid.UUID) ([]*models.Availability, error)
 	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.Availability, error)
+	// ListByEventID returns a page of the availability of an event and describes the page
+	ListByEventID(ctx context.Context, eventID uuid.UUID, page models.PageRequest) ([]*models.Availability, models.PageInfo, error)
 }
 
 // GormAvailabilityRepository implements AvailabilityRepository using GORM
@@ -32,29 +50,110 @@ func (r *GormAvailabilityRepository) Create(ctx context.Context, availability *m
 	if availability.ID == uuid.Nil {
 		availability.ID = uuid.New()
 	}
-	return r.db.WithContext(ctx).Create(availability).Error
+	if err := stamp(ctx, &availability.OrganizationID); err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Create(availability).Error
 }
 
-// Update updates an existing availability
+// Update updates an existing availability record, provided it is still at the version it was read at
 func (r *GormAvailabilityRepository) Update(ctx context.Context, availability *models.Availability) error {
-	return r.db.WithContext(ctx).Model(&models.Availability{}).Where("id = ?", availability.ID).Updates(availability).Error
+	version := availability.Version
+	availability.Version++
+	result := scoped(ctx, r.db, "availabilities").Model(&models.Availability{}).
+		Where("id = ? AND version = ?", availability.ID, version).
+		Omit("organization_id").Updates(availability)
+	return checkVersion(result, &availability.Version, version)
+}
+
+// Delete marks an availability as deleted
+func (r *GormAvailabilityRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return checkDeleted(scoped(ctx, r.db, "availabilities").Where("id = ? AND version = ?", id, version).Delete(&models.Availability{}))
 }
 
-// Delete removes an availability by its ID
-func (r *GormAvailabilityRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.Availability{}, id).Error
+// GetDeletedByID retrieves an availability that is deleted but not yet purged
+func (r *GormAvailabilityRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Availability, error) {
+	var availability models.Availability
+	if err := scoped(ctx, r.db, "availabilities").Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&availability).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrAvailabilityNotFound
+		}
+		return nil, err
+	}
+	return &availability, nil
+}
+
+// Restore clears the deletion of an availability
+func (r *GormAvailabilityRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return scoped(ctx, r.db, "availabilities").Unscoped().Model(&models.Availability{}).Where("id = ?", id).UpdateColumn("deleted_at", nil).Error
+}
+
+// Purge permanently removes availability entries deleted before the given time and returns them
+func (r *GormAvailabilityRepository) Purge(ctx context.Context, before time.Time) ([]*models.Availability, error) {
+	var availabilities []*models.Availability
+	err := scoped(ctx, r.db, "availabilities").Unscoped().Clauses(clause.Returning{}).Where("deleted_at < ?", before).Delete(&availabilities).Error
+	return availabilities, err
+}
+
+// GetByID retrieves an availability by its ID
+func (r *GormAvailabilityRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Availability, error) {
+	var availability models.Availability
+	if err := scoped(ctx, r.db, "availabilities").Where("id = ?", id).First(&availability).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrAvailabilityNotFound
+		}
+		return nil, err
+	}
+	return &availability, nil
 }
 
 // GetByUserAndEvent retrieves all availability entries for a user and event
 func (r *GormAvailabilityRepository) GetByUserAndEvent(ctx context.Context, userID, eventID uuid.UUID) ([]*models.Availability, error) {
 	var availabilities []*models.Availability
-	err := r.db.WithContext(ctx).Where("user_id = ? AND event_id = ?", userID, eventID).Find(&availabilities).Error
+	err := scoped(ctx, r.db, "availabilities").Where("user_id = ? AND event_id = ?", userID, eventID).Find(&availabilities).Error
 	return availabilities, err
 }
 
 // GetByEventID retrieves all availability entries for an event
 func (r *GormAvailabilityRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.Availability, error) {
 	var availabilities []*models.Availability
-	err := r.db.WithContext(ctx).Where("event_id = ?", eventID).Find(&availabilities).Error
+	err := scoped(ctx, r.db, "availabilities").Where("event_id = ?", eventID).Find(&availabilities).Error
 	return availabilities, err
 }
+
+// availabilitySortKeys are the fields availability can be listed by
+var availabilitySortKeys = map[string]sortKey{
+	"start_time": {column: "start_time", time: true},
+	"created_at": {column: "created_at", time: true},
+}
+
+// ListByEventID retrieves a page of the availability of an event, earliest first
+// unless another sort is requested
+func (r *GormAvailabilityRepository) ListByEventID(ctx context.Context, eventID uuid.UUID, page models.PageRequest) ([]*models.Availability, models.PageInfo, error) {
+	q, err := parsePage(page, availabilitySortKeys, "start_time")
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	var total int64
+	if err := scoped(ctx, r.db, "availabilities").Model(&models.Availability{}).Where("event_id = ?", eventID).Count(&total).Error; err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	query, err := q.apply(scoped(ctx, r.db, "availabilities").Where("event_id = ?", eventID), "availabilities")
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	var availabilities []*models.Availability
+	if err := query.Find(&availabilities).Error; err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	availabilities, info := finishPage(availabilities, q, total, func(row *models.Availability) (interface{}, uuid.UUID) {
+		if q.key.column == "created_at" {
+			return row.CreatedAt, row.ID
+		}
+		return row.StartTime, row.ID
+	})
+	return availabilities, info, nil
+}
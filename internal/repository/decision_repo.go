@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+)
+
+// DecisionRepository defines the interface for deadline decision data access
+type DecisionRepository interface {
+	Create(ctx context.Context, decision *models.EventDecision) error
+	ListByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.EventDecision, error)
+}
+
+// GormDecisionRepository implements DecisionRepository using GORM
+type GormDecisionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDecisionRepository creates a new GormDecisionRepository
+func NewGormDecisionRepository(db *gorm.DB) *GormDecisionRepository {
+	return &GormDecisionRepository{db: db}
+}
+
+// Create records a new decision
+func (r *GormDecisionRepository) Create(ctx context.Context, decision *models.EventDecision) error {
+	if decision.ID == uuid.Nil {
+		decision.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(decision).Error
+}
+
+// ListByEventID retrieves the decisions made for an event, oldest first
+func (r *GormDecisionRepository) ListByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.EventDecision, error) {
+	var decisions []*models.EventDecision
+	err := conn(ctx, r.db).Where("event_id = ?", eventID).Order("decided_at").Find(&decisions).Error
+	return decisions, err
+}
@@ -3,19 +3,45 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
 	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // EventRepository defines the interface for event data access
 type EventRepository interface {
 	Create(ctx context.Context, event *models.Event) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Event, error)
+	// Update writes an event read at event.Version and increments the version.
+	// It fails with ErrStaleVersion when the event has changed since.
 	Update(ctx context.Context, event *models.Event) error
-	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, limit, offset int) ([]*models.Event, error)
+	// Delete soft-deletes an event read at version together with its time
+	// slots and availability. It fails with ErrStaleVersion when the event has
+	// changed since, and must run inside a transaction.
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	// GetDeletedByID retrieves a soft-deleted event by its ID
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Event, error)
+	// Restore brings back a soft-deleted event and the children deleted with it.
+	// It must run inside a transaction.
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge permanently removes the events deleted before the given time and
+	// returns them
+	Purge(ctx context.Context, before time.Time) ([]*models.Event, error)
+	// List returns a page of the events matching filter and describes the page
+	List(ctx context.Context, filter models.EventFilter) ([]*models.Event, models.PageInfo, error)
+	// ListByDeadline returns active events whose response deadline falls in [from, to)
+	ListByDeadline(ctx context.Context, from, to time.Time) ([]*models.Event, error)
+	// ClaimPastDeadline locks up to limit active events whose response deadline has
+	// passed without being processed. It must run inside a transaction.
+	ClaimPastDeadline(ctx context.Context, now time.Time, limit int) ([]*models.Event, error)
+	// ListConfirmedMeetings returns the finalized meetings other than those of
+	// excludeEventID that the given users organize or take part in and that overlap [from, to]
+	ListConfirmedMeetings(ctx context.Context, userIDs []uuid.UUID, excludeEventID uuid.UUID, from, to time.Time) ([]*models.ConfirmedMeeting, error)
 }
 
 // GormEventRepository implements EventRepository using GORM
@@ -33,34 +59,207 @@ func (r *GormEventRepository) Create(ctx context.Context, event *models.Event) e
 	if event.ID == uuid.Nil {
 		event.ID = uuid.New()
 	}
-	return r.db.WithContext(ctx).Create(event).Error
+	if err := stamp(ctx, &event.OrganizationID); err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Create(event).Error
 }
 
 // GetByID retrieves an event by its ID
 func (r *GormEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Event, error) {
 	var event models.Event
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&event).Error; err != nil {
+	if err := scoped(ctx, r.db, "events").Where("id = ?", id).First(&event).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("event not found")
+			return nil, apperrors.ErrEventNotFound
 		}
 		return nil, err
 	}
 	return &event, nil
 }
 
-// Update updates an existing event
+// Update updates an existing event, provided it is still at the version it was read at
 func (r *GormEventRepository) Update(ctx context.Context, event *models.Event) error {
-	return r.db.WithContext(ctx).Model(&models.Event{}).Where("id = ?", event.ID).Updates(event).Error
+	version := event.Version
+	event.Version++
+	// Select all columns so cleared optional fields are written too
+	result := scoped(ctx, r.db, "events").Model(&models.Event{}).
+		Where("id = ? AND version = ?", event.ID, version).
+		Select("*").Omit("organization_id").Updates(event)
+	return checkVersion(result, &event.Version, version)
+}
+
+// Delete marks an event and its live time slots and availability as deleted.
+// They share one deletion time, so Restore brings back exactly what was
+// deleted with the event and not what was deleted on its own before.
+func (r *GormEventRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	now := time.Now()
+	result := scoped(ctx, r.db, "events").Model(&models.Event{}).Where("id = ? AND version = ?", id, version).UpdateColumn("deleted_at", now)
+	if err := checkDeleted(result); err != nil {
+		return err
+	}
+	for _, child := range []interface{}{&models.TimeSlot{}, &models.Availability{}} {
+		if err := conn(ctx, r.db).Model(child).Where("event_id = ?", id).UpdateColumn("deleted_at", now).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDeletedByID retrieves an event that is deleted but not yet purged
+func (r *GormEventRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Event, error) {
+	var event models.Event
+	if err := scoped(ctx, r.db, "events").Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&event).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrEventNotFound
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+// Restore clears the deletion of an event and of the time slots and
+// availability deleted together with it
+func (r *GormEventRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.GetDeletedByID(ctx, id); err != nil {
+		return err
+	}
+	// Children first, while the event still records when it was deleted
+	deletedAt := conn(ctx, r.db).Unscoped().Model(&models.Event{}).Select("deleted_at").Where("id = ?", id)
+	for _, child := range []interface{}{&models.TimeSlot{}, &models.Availability{}} {
+		err := conn(ctx, r.db).Unscoped().Model(child).
+			Where("event_id = ? AND deleted_at = (?)", id, deletedAt).
+			UpdateColumn("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+	}
+	return scoped(ctx, r.db, "events").Unscoped().Model(&models.Event{}).Where("id = ?", id).UpdateColumn("deleted_at", nil).Error
+}
+
+// Purge permanently removes events deleted before the given time; their time
+// slots, availability and everything else about them go with them. The
+// removed events are returned.
+func (r *GormEventRepository) Purge(ctx context.Context, before time.Time) ([]*models.Event, error) {
+	var events []*models.Event
+	err := scoped(ctx, r.db, "events").Unscoped().Clauses(clause.Returning{}).Where("deleted_at < ?", before).Delete(&events).Error
+	return events, err
+}
+
+// eventSortKeys are the fields events can be listed by
+var eventSortKeys = map[string]sortKey{
+	"created_at": {column: "created_at", time: true},
+	"updated_at": {column: "updated_at", time: true},
+	"title":      {column: "title"},
 }
 
-// Delete removes an event by its ID
-func (r *GormEventRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.Event{}, id).Error
+// List retrieves a page of events, newest first unless another sort is requested
+func (r *GormEventRepository) List(ctx context.Context, filter models.EventFilter) ([]*models.Event, models.PageInfo, error) {
+	q, err := parsePage(filter.Page, eventSortKeys, "-created_at")
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	var total int64
+	if err := r.filtered(ctx, filter).Model(&models.Event{}).Count(&total).Error; err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	query, err := q.apply(r.filtered(ctx, filter), "events")
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	var events []*models.Event
+	if err := query.Find(&events).Error; err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	events, info := finishPage(events, q, total, func(e *models.Event) (interface{}, uuid.UUID) {
+		switch q.key.column {
+		case "updated_at":
+			return e.UpdatedAt, e.ID
+		case "title":
+			return e.Title, e.ID
+		default:
+			return e.CreatedAt, e.ID
+		}
+	})
+	return events, info, nil
 }
 
-// List retrieves a paginated list of events
-func (r *GormEventRepository) List(ctx context.Context, limit, offset int) ([]*models.Event, error) {
+// filtered selects the events matching filter
+func (r *GormEventRepository) filtered(ctx context.Context, filter models.EventFilter) *gorm.DB {
+	query := scoped(ctx, r.db, "events")
+	if len(filter.Status) > 0 {
+		query = query.Where("events.status IN ?", filter.Status)
+	}
+	if filter.CreatorID != nil {
+		query = query.Where("events.creator_id = ?", *filter.CreatorID)
+	}
+	if filter.ParticipantID != nil {
+		query = query.Where("events.id IN (SELECT event_id FROM event_participants WHERE user_id = ?)", *filter.ParticipantID)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("events.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("events.created_at < ?", *filter.CreatedTo)
+	}
+	if filter.Title != "" {
+		query = query.Where("events.title ILIKE ?", containsPattern(filter.Title))
+	}
+	return query
+}
+
+// ListByDeadline retrieves active events whose response deadline falls in a window
+func (r *GormEventRepository) ListByDeadline(ctx context.Context, from, to time.Time) ([]*models.Event, error) {
+	var events []*models.Event
+	err := scoped(ctx, r.db, "events").
+		Where("status = ? AND response_deadline >= ? AND response_deadline < ?", models.EventStatusActive, from, to).
+		Order("response_deadline").
+		Find(&events).Error
+	return events, err
+}
+
+// ClaimPastDeadline locks unprocessed active events whose response deadline has passed,
+// skipping rows another replica is already working on
+func (r *GormEventRepository) ClaimPastDeadline(ctx context.Context, now time.Time, limit int) ([]*models.Event, error) {
 	var events []*models.Event
-	err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&events).Error
+	err := scoped(ctx, r.db, "events").
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND response_deadline <= ? AND deadline_processed_at IS NULL", models.EventStatusActive, now).
+		Order("response_deadline").
+		Limit(limit).
+		Find(&events).Error
 	return events, err
 }
+
+// ListConfirmedMeetings retrieves the finalized meetings of the given users
+// that overlap or touch [from, to]
+func (r *GormEventRepository) ListConfirmedMeetings(ctx context.Context, userIDs []uuid.UUID, excludeEventID uuid.UUID, from, to time.Time) ([]*models.ConfirmedMeeting, error) {
+	var meetings []*models.ConfirmedMeeting
+	if len(userIDs) == 0 {
+		return meetings, nil
+	}
+	query := `
+		SELECT attendees.user_id, e.id AS event_id, ts.start_time,
+			ts.start_time + e.duration * INTERVAL '1 minute' AS end_time
+		FROM events e
+		JOIN time_slots ts ON ts.id = e.final_time_slot_id
+		JOIN (
+			SELECT event_id, user_id FROM event_participants
+			UNION
+			SELECT id, creator_id FROM events
+		) attendees ON attendees.event_id = e.id
+		WHERE e.status = ? AND e.id <> ? AND attendees.user_id IN ?
+			AND e.deleted_at IS NULL AND ts.deleted_at IS NULL
+			AND ts.start_time <= ? AND ts.start_time + e.duration * INTERVAL '1 minute' >= ?`
+	args := []interface{}{models.EventStatusFinalized, excludeEventID, userIDs, to, from}
+	if organizationID, ok := tenant.OrganizationID(ctx); ok {
+		query += " AND e.organization_id = ?"
+		args = append(args, organizationID)
+	} else if !tenant.IsSystem(ctx) {
+		return nil, apperrors.ErrNoOrganization
+	}
+	err := conn(ctx, r.db).Raw(query+" ORDER BY ts.start_time", args...).Scan(&meetings).Error
+	return meetings, err
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+)
+
+// GroupRepository defines the interface for group and group membership data access
+type GroupRepository interface {
+	Create(ctx context.Context, group *models.Group) error
+	Update(ctx context.Context, group *models.Group) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Group, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Group, error)
+	List(ctx context.Context) ([]*models.Group, error)
+	AddMember(ctx context.Context, member *models.GroupMember) error
+	RemoveMember(ctx context.Context, id uuid.UUID) error
+	// ListMembers returns the direct members of the given groups
+	ListMembers(ctx context.Context, groupIDs []uuid.UUID) ([]*models.GroupMember, error)
+	// ListParentIDs returns the groups the given groups are direct members of
+	ListParentIDs(ctx context.Context, groupIDs []uuid.UUID) ([]uuid.UUID, error)
+}
+
+// EventGroupRepository defines the interface for data access to groups invited to events
+type EventGroupRepository interface {
+	Create(ctx context.Context, eventGroup *models.EventGroup) error
+	Delete(ctx context.Context, eventID, groupID uuid.UUID) error
+	Get(ctx context.Context, eventID, groupID uuid.UUID) (*models.EventGroup, error)
+	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.EventGroup, error)
+	ListByGroupIDs(ctx context.Context, groupIDs []uuid.UUID) ([]*models.EventGroup, error)
+}
+
+// GormGroupRepository implements GroupRepository using GORM
+type GormGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewGormGroupRepository creates a new GormGroupRepository
+func NewGormGroupRepository(db *gorm.DB) *GormGroupRepository {
+	return &GormGroupRepository{db: db}
+}
+
+// Create saves a new group to the database
+func (r *GormGroupRepository) Create(ctx context.Context, group *models.Group) error {
+	if group.ID == uuid.Nil {
+		group.ID = uuid.New()
+	}
+	if err := stamp(ctx, &group.OrganizationID); err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Create(group).Error
+}
+
+// Update updates an existing group
+func (r *GormGroupRepository) Update(ctx context.Context, group *models.Group) error {
+	return scoped(ctx, r.db, "groups").Model(&models.Group{}).Where("id = ?", group.ID).Select("*").Omit("organization_id").Updates(group).Error
+}
+
+// Delete removes a group by its ID, along with its memberships and invitations
+func (r *GormGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return scoped(ctx, r.db, "groups").Delete(&models.Group{}, id).Error
+}
+
+// GetByID retrieves a group by its ID
+func (r *GormGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Group, error) {
+	var group models.Group
+	if err := scoped(ctx, r.db, "groups").Where("id = ?", id).First(&group).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrGroupNotFound
+		}
+		return nil, err
+	}
+	return &group, nil
+}
+
+// GetByIDs retrieves the groups with the given IDs
+func (r *GormGroupRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Group, error) {
+	var groups []*models.Group
+	if len(ids) == 0 {
+		return groups, nil
+	}
+	err := scoped(ctx, r.db, "groups").Where("id IN ?", ids).Order("name").Find(&groups).Error
+	return groups, err
+}
+
+// List retrieves all groups ordered by name
+func (r *GormGroupRepository) List(ctx context.Context) ([]*models.Group, error) {
+	var groups []*models.Group
+	err := scoped(ctx, r.db, "groups").Order("name").Find(&groups).Error
+	return groups, err
+}
+
+// AddMember saves a new group member
+func (r *GormGroupRepository) AddMember(ctx context.Context, member *models.GroupMember) error {
+	if member.ID == uuid.Nil {
+		member.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(member).Error
+}
+
+// RemoveMember removes a group member by its ID
+func (r *GormGroupRepository) RemoveMember(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Delete(&models.GroupMember{}, id).Error
+}
+
+// ListMembers retrieves the direct members of the given groups
+func (r *GormGroupRepository) ListMembers(ctx context.Context, groupIDs []uuid.UUID) ([]*models.GroupMember, error) {
+	var members []*models.GroupMember
+	if len(groupIDs) == 0 {
+		return members, nil
+	}
+	err := conn(ctx, r.db).Where("group_id IN ?", groupIDs).Order("created_at").Find(&members).Error
+	return members, err
+}
+
+// ListParentIDs retrieves the groups the given groups are direct members of
+func (r *GormGroupRepository) ListParentIDs(ctx context.Context, groupIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if len(groupIDs) == 0 {
+		return ids, nil
+	}
+	err := conn(ctx, r.db).Model(&models.GroupMember{}).Distinct().
+		Where("member_group_id IN ?", groupIDs).Pluck("group_id", &ids).Error
+	return ids, err
+}
+
+// GormEventGroupRepository implements EventGroupRepository using GORM
+type GormEventGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEventGroupRepository creates a new GormEventGroupRepository
+func NewGormEventGroupRepository(db *gorm.DB) *GormEventGroupRepository {
+	return &GormEventGroupRepository{db: db}
+}
+
+// Create saves a new group invitation
+func (r *GormEventGroupRepository) Create(ctx context.Context, eventGroup *models.EventGroup) error {
+	if eventGroup.ID == uuid.Nil {
+		eventGroup.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(eventGroup).Error
+}
+
+// Delete removes the invitation of a group to an event
+func (r *GormEventGroupRepository) Delete(ctx context.Context, eventID, groupID uuid.UUID) error {
+	return conn(ctx, r.db).Where("event_id = ? AND group_id = ?", eventID, groupID).Delete(&models.EventGroup{}).Error
+}
+
+// Get retrieves the invitation of a group to an event
+func (r *GormEventGroupRepository) Get(ctx context.Context, eventID, groupID uuid.UUID) (*models.EventGroup, error) {
+	var eventGroup models.EventGroup
+	if err := conn(ctx, r.db).Where("event_id = ? AND group_id = ?", eventID, groupID).First(&eventGroup).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrEventGroupNotFound
+		}
+		return nil, err
+	}
+	return &eventGroup, nil
+}
+
+// GetByEventID retrieves the groups invited to an event
+func (r *GormEventGroupRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.EventGroup, error) {
+	var eventGroups []*models.EventGroup
+	err := conn(ctx, r.db).Where("event_id = ?", eventID).Order("created_at").Find(&eventGroups).Error
+	return eventGroups, err
+}
+
+// ListByGroupIDs retrieves the invitations of the given groups to any event
+func (r *GormEventGroupRepository) ListByGroupIDs(ctx context.Context, groupIDs []uuid.UUID) ([]*models.EventGroup, error) {
+	var eventGroups []*models.EventGroup
+	if len(groupIDs) == 0 {
+		return eventGroups, nil
+	}
+	err := conn(ctx, r.db).Where("group_id IN ?", groupIDs).Order("created_at").Find(&eventGroups).Error
+	return eventGroups, err
+}
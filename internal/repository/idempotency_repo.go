@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository defines the interface for idempotency key storage.
+// Keys belong to a caller rather than an organization.
+type IdempotencyRepository interface {
+	// Reserve saves record as in progress and returns nil, unless the caller
+	// already has an unexpired record under the key, which is returned instead
+	Reserve(ctx context.Context, record *models.IdempotencyRecord, now time.Time) (*models.IdempotencyRecord, error)
+	// Complete stores the response of a reserved record
+	Complete(ctx context.Context, record *models.IdempotencyRecord) error
+	// Release removes a reservation so that the request can be retried
+	Release(ctx context.Context, caller, key string) error
+	// Purge removes the records that expired before the given time
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// GormIdempotencyRepository implements IdempotencyRepository using GORM
+type GormIdempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormIdempotencyRepository creates a new GormIdempotencyRepository
+func NewGormIdempotencyRepository(db *gorm.DB) *GormIdempotencyRepository {
+	return &GormIdempotencyRepository{db: db}
+}
+
+// Reserve inserts the record, taking over an expired one under the same key
+func (r *GormIdempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyRecord, now time.Time) (*models.IdempotencyRecord, error) {
+	result := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "caller"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"request_hash", "completed", "status_code", "header", "body", "created_at", "expires_at",
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "idempotency_records.expires_at <= ?", Vars: []interface{}{now}}}},
+	}).Create(record)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return nil, result.Error
+	}
+
+	var existing models.IdempotencyRecord
+	result = conn(ctx, r.db).
+		Where("caller = ? AND key = ? AND expires_at > ?", record.Caller, record.Key, now).
+		Limit(1).
+		Find(&existing)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Released or purged since the insert; the caller should retry
+		return nil, apperrors.ErrIdempotencyKeyInUse
+	}
+	return &existing, nil
+}
+
+// Complete saves the response of a reserved record and extends its expiry
+// from the lease of the request to the replay TTL
+func (r *GormIdempotencyRepository) Complete(ctx context.Context, record *models.IdempotencyRecord) error {
+	record.Completed = true
+	return conn(ctx, r.db).Model(&models.IdempotencyRecord{}).
+		Where("caller = ? AND key = ?", record.Caller, record.Key).
+		Updates(map[string]interface{}{
+			"completed":   true,
+			"status_code": record.StatusCode,
+			"header":      record.Header,
+			"body":        record.Body,
+			"expires_at":  record.ExpiresAt,
+		}).Error
+}
+
+// Release deletes a record that is still in progress
+func (r *GormIdempotencyRepository) Release(ctx context.Context, caller, key string) error {
+	return conn(ctx, r.db).
+		Where("caller = ? AND key = ? AND NOT completed", caller, key).
+		Delete(&models.IdempotencyRecord{}).Error
+}
+
+// Purge permanently removes records that expired before the given time
+func (r *GormIdempotencyRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, r.db).Where("expires_at < ?", before).Delete(&models.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
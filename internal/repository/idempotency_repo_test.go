@@ -0,0 +1,33 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReserveTakesOverOnlyExpiredKeys(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	now := time.Now()
+
+	_, err := repository.NewGormIdempotencyRepository(db).Reserve(context.Background(), &models.IdempotencyRecord{
+		Caller:    "user:1",
+		Key:       "key-1",
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}, now)
+	// A dry run inserts nothing and finds nothing, as if the key was released meanwhile
+	require.ErrorIs(t, err, apperrors.ErrIdempotencyKeyInUse)
+
+	require.Len(t, *statements, 2)
+	insert := (*statements)[0]
+	assert.Contains(t, insert.SQL, `ON CONFLICT ("caller","key") DO UPDATE SET`)
+	assert.Contains(t, insert.SQL, "WHERE idempotency_records.expires_at <= $")
+	assert.Contains(t, last(t, statements).SQL, "expires_at > $")
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobRepository defines the interface for background job storage
+type JobRepository interface {
+	Enqueue(ctx context.Context, job *models.Job) error
+	// EnqueueScheduled moves the named schedule to next if it is due at now
+	// and enqueues job unless a job of the same kind is still pending or
+	// running, reporting whether job was enqueued. Only one replica wins each
+	// run of a schedule.
+	EnqueueScheduled(ctx context.Context, name string, now, next time.Time, job *models.Job) (bool, error)
+	// ClaimDue leases up to limit due jobs until now+lease and counts the attempt
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Job, error)
+	// Complete removes a job that succeeded
+	Complete(ctx context.Context, id uuid.UUID) error
+	Update(ctx context.Context, job *models.Job) error
+	ListDead(ctx context.Context, limit int) ([]*models.Job, error)
+	// Retry moves a dead job back to pending with fresh attempts
+	Retry(ctx context.Context, id uuid.UUID, now time.Time) (*models.Job, error)
+}
+
+// GormJobRepository implements JobRepository using GORM
+type GormJobRepository struct {
+	db *gorm.DB
+}
+
+// NewGormJobRepository creates a new GormJobRepository
+func NewGormJobRepository(db *gorm.DB) *GormJobRepository {
+	return &GormJobRepository{db: db}
+}
+
+// Enqueue saves a new job
+func (r *GormJobRepository) Enqueue(ctx context.Context, job *models.Job) error {
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(job).Error
+}
+
+// EnqueueScheduled advances a schedule and enqueues its run in one transaction
+func (r *GormJobRepository) EnqueueScheduled(ctx context.Context, name string, now, next time.Time, job *models.Job) (bool, error) {
+	enqueued := false
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Inserting a new schedule or moving a due one both affect a row; a
+		// schedule another replica already moved past now is left alone
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"next_run_at"}),
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "job_schedules.next_run_at <= ?", Vars: []interface{}{now}}}},
+		}).Create(&models.JobSchedule{Name: name, NextRunAt: next})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		var queued int64
+		if err := tx.Model(&models.Job{}).
+			Where("kind = ? AND status IN ?", job.Kind, []models.JobStatus{models.JobPending, models.JobRunning}).
+			Count(&queued).Error; err != nil {
+			return err
+		}
+		if queued > 0 {
+			return nil
+		}
+
+		enqueued = true
+		if job.ID == uuid.Nil {
+			job.ID = uuid.New()
+		}
+		return tx.Create(job).Error
+	})
+	return enqueued, err
+}
+
+// ClaimDue leases a batch of due jobs, including running jobs whose lease expired
+func (r *GormJobRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Job, error) {
+	var jobs []*models.Job
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND run_at <= ?", []models.JobStatus{models.JobPending, models.JobRunning}, now).
+			Order("run_at").
+			Limit(limit).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(jobs))
+		for i, job := range jobs {
+			job.Status = models.JobRunning
+			job.Attempts++
+			job.RunAt = now.Add(lease)
+			job.UpdatedAt = now
+			ids[i] = job.ID
+		}
+		return tx.Model(&models.Job{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":     models.JobRunning,
+				"attempts":   gorm.Expr("attempts + 1"),
+				"run_at":     now.Add(lease),
+				"updated_at": now,
+			}).Error
+	})
+	return jobs, err
+}
+
+// Complete deletes a finished job
+func (r *GormJobRepository) Complete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Delete(&models.Job{}, id).Error
+}
+
+// Update saves every field of a job
+func (r *GormJobRepository) Update(ctx context.Context, job *models.Job) error {
+	return conn(ctx, r.db).Save(job).Error
+}
+
+// ListDead retrieves dead jobs, most recently failed first
+func (r *GormJobRepository) ListDead(ctx context.Context, limit int) ([]*models.Job, error) {
+	var jobs []*models.Job
+	err := conn(ctx, r.db).
+		Where("status = ?", models.JobDead).
+		Order("updated_at DESC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// Retry requeues a dead job
+func (r *GormJobRepository) Retry(ctx context.Context, id uuid.UUID, now time.Time) (*models.Job, error) {
+	var job models.Job
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ?", id, models.JobDead).
+			Limit(1).
+			Find(&job).Error; err != nil {
+			return err
+		}
+		if job.ID == uuid.Nil {
+			return apperrors.ErrJobNotFound
+		}
+
+		job.Status = models.JobPending
+		job.Attempts = 0
+		job.RunAt = now
+		job.UpdatedAt = now
+		return tx.Save(&job).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationRepository defines the interface for notification queue data access
+type NotificationRepository interface {
+	// Enqueue saves a notification unless one with the same dedupe key exists,
+	// reporting whether it was saved
+	Enqueue(ctx context.Context, notification *models.Notification) (bool, error)
+	// ClaimDue leases up to limit pending notifications that are due by pushing
+	// their next attempt past the lease, so other replicas skip them
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Notification, error)
+	Update(ctx context.Context, notification *models.Notification) error
+}
+
+// GormNotificationRepository implements NotificationRepository using GORM
+type GormNotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewGormNotificationRepository creates a new GormNotificationRepository
+func NewGormNotificationRepository(db *gorm.DB) *GormNotificationRepository {
+	return &GormNotificationRepository{db: db}
+}
+
+// Enqueue saves a new notification, skipping duplicates
+func (r *GormNotificationRepository) Enqueue(ctx context.Context, notification *models.Notification) (bool, error) {
+	if notification.ID == uuid.Nil {
+		notification.ID = uuid.New()
+	}
+	result := conn(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "dedupe_key"}}, DoNothing: true}).
+		Create(notification)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ClaimDue leases a batch of due notifications
+func (r *GormNotificationRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.NotificationPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&notifications).Error; err != nil {
+			return err
+		}
+		if len(notifications) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(notifications))
+		for i, notification := range notifications {
+			ids[i] = notification.ID
+		}
+		return tx.Model(&models.Notification{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return notifications, err
+}
+
+// Update saves every field of a notification
+func (r *GormNotificationRepository) Update(ctx context.Context, notification *models.Notification) error {
+	return conn(ctx, r.db).Save(notification).Error
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository defines the interface for organization data access
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *models.Organization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error)
+	List(ctx context.Context) ([]*models.Organization, error)
+}
+
+// GormOrganizationRepository implements OrganizationRepository using GORM
+type GormOrganizationRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOrganizationRepository creates a new GormOrganizationRepository
+func NewGormOrganizationRepository(db *gorm.DB) *GormOrganizationRepository {
+	return &GormOrganizationRepository{db: db}
+}
+
+// Create saves a new organization to the database
+func (r *GormOrganizationRepository) Create(ctx context.Context, organization *models.Organization) error {
+	if organization.ID == uuid.Nil {
+		organization.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(organization).Error
+}
+
+// GetByID retrieves an organization by its ID
+func (r *GormOrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	var organization models.Organization
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&organization).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// List retrieves all organizations ordered by name
+func (r *GormOrganizationRepository) List(ctx context.Context) ([]*models.Organization, error) {
+	var organizations []*models.Organization
+	err := conn(ctx, r.db).Order("name").Find(&organizations).Error
+	return organizations, err
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxRepository defines the interface for transactional outbox data access
+type OutboxRepository interface {
+	Create(ctx context.Context, message *models.OutboxMessage) error
+	// ClaimPending locks up to limit unprocessed messages due at now, oldest
+	// first, skipping dead messages and rows locked by other replicas. It must
+	// be called inside a transaction.
+	ClaimPending(ctx context.Context, now time.Time, limit int) ([]*models.OutboxMessage, error)
+	MarkProcessed(ctx context.Context, id uuid.UUID, processedAt time.Time) error
+	// RecordFailure saves the attempts, last error, next attempt and dead time of a message
+	RecordFailure(ctx context.Context, message *models.OutboxMessage) error
+}
+
+// GormOutboxRepository implements OutboxRepository using GORM
+type GormOutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOutboxRepository creates a new GormOutboxRepository
+func NewGormOutboxRepository(db *gorm.DB) *GormOutboxRepository {
+	return &GormOutboxRepository{db: db}
+}
+
+// Create saves a new outbox message
+func (r *GormOutboxRepository) Create(ctx context.Context, message *models.OutboxMessage) error {
+	if message.ID == uuid.Nil {
+		message.ID = uuid.New()
+	}
+	if message.NextAttemptAt.IsZero() {
+		message.NextAttemptAt = message.CreatedAt
+	}
+	return conn(ctx, r.db).Create(message).Error
+}
+
+// ClaimPending locks a batch of due messages
+func (r *GormOutboxRepository) ClaimPending(ctx context.Context, now time.Time, limit int) ([]*models.OutboxMessage, error) {
+	var messages []*models.OutboxMessage
+	err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("processed_at IS NULL AND dead_at IS NULL AND next_attempt_at <= ?", now).
+		Order("next_attempt_at, created_at").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+// MarkProcessed records that a message has been relayed
+func (r *GormOutboxRepository) MarkProcessed(ctx context.Context, id uuid.UUID, processedAt time.Time) error {
+	return conn(ctx, r.db).Model(&models.OutboxMessage{}).Where("id = ?", id).Update("processed_at", processedAt).Error
+}
+
+// RecordFailure saves the retry state of a message
+func (r *GormOutboxRepository) RecordFailure(ctx context.Context, message *models.OutboxMessage) error {
+	return conn(ctx, r.db).Model(&models.OutboxMessage{}).Where("id = ?", message.ID).Updates(map[string]interface{}{
+		"attempts":        message.Attempts,
+		"last_error":      message.LastError,
+		"next_attempt_at": message.NextAttemptAt,
+		"dead_at":         message.DeadAt,
+	}).Error
+}
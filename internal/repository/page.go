@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+)
+
+// sortKey is a column a listing can be ordered by
+type sortKey struct {
+	column string
+	time   bool // Cursor values are timestamps rather than text
+}
+
+// pageQuery is a validated page request
+type pageQuery struct {
+	sort  string // As requested, with - for descending
+	key   sortKey
+	desc  bool
+	after *models.Cursor
+	limit int
+}
+
+// parsePage checks a page request against the sort keys of a listing
+func parsePage(page models.PageRequest, keys map[string]sortKey, defaultSort string) (pageQuery, error) {
+	sort := page.Sort
+	if sort == "" {
+		sort = defaultSort
+	}
+	key, ok := keys[strings.TrimPrefix(sort, "-")]
+	if !ok {
+		return pageQuery{}, apperrors.ErrInvalidSort
+	}
+	q := pageQuery{sort: sort, key: key, desc: strings.HasPrefix(sort, "-"), limit: page.Limit}
+	if page.Cursor != "" {
+		cursor, err := models.DecodeCursor(page.Cursor)
+		if err != nil || cursor.Sort != sort {
+			return pageQuery{}, apperrors.ErrInvalidCursor
+		}
+		q.after = &cursor
+	}
+	return q, nil
+}
+
+// apply orders query by the sort key and then ID, starts after the cursor and
+// fetches one row more than the limit, which tells whether another page follows
+func (q pageQuery) apply(query *gorm.DB, table string) (*gorm.DB, error) {
+	column := table + "." + q.key.column
+	direction, op := "ASC", ">"
+	if q.desc {
+		direction, op = "DESC", "<"
+	}
+	if q.after != nil {
+		var value interface{} = q.after.Value
+		if q.key.time {
+			t, err := time.Parse(time.RFC3339Nano, q.after.Value)
+			if err != nil {
+				return nil, apperrors.ErrInvalidCursor
+			}
+			value = t
+		}
+		query = query.Where(fmt.Sprintf("(%s, %s.id) %s (?, ?)", column, table, op), value, q.after.ID)
+	}
+	return query.Order(column + " " + direction).Order(table + ".id " + direction).Limit(q.limit + 1), nil
+}
+
+// cursor returns the position after a row with the given sort value and ID
+func (q pageQuery) cursor(value interface{}, id uuid.UUID) string {
+	c := models.Cursor{Sort: q.sort, ID: id}
+	switch v := value.(type) {
+	case time.Time:
+		c.Value = v.UTC().Format(time.RFC3339Nano)
+	default:
+		c.Value = fmt.Sprint(v)
+	}
+	return c.Encode()
+}
+
+// finishPage drops the row fetched past the limit and describes the page.
+// position returns the sort value and ID of a row.
+func finishPage[T any](rows []T, q pageQuery, total int64, position func(T) (interface{}, uuid.UUID)) ([]T, models.PageInfo) {
+	info := models.PageInfo{Total: total, Limit: q.limit}
+	if len(rows) > q.limit {
+		rows = rows[:q.limit]
+		info.NextCursor = q.cursor(position(rows[len(rows)-1]))
+	}
+	return rows, info
+}
+
+// containsPattern is a LIKE pattern matching values that contain text
+func containsPattern(text string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text) + "%"
+}
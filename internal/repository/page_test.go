@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventListAppliesFiltersAndOrder(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	creatorID := uuid.New()
+	participantID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, _, err := repository.NewGormEventRepository(db).List(tenant.System(context.Background()), models.EventFilter{
+		Status:        []models.EventStatus{models.EventStatusActive, models.EventStatusFinalized},
+		CreatorID:     &creatorID,
+		ParticipantID: &participantID,
+		CreatedFrom:   &from,
+		Title:         "50%_off",
+		Page:          models.PageRequest{Limit: 20},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *statements, 2)
+	count, page := (*statements)[0], (*statements)[1]
+	for _, stmt := range []statement{count, page} {
+		assert.Contains(t, stmt.SQL, "events.status IN ($1,$2)")
+		assert.Contains(t, stmt.SQL, "events.creator_id = $")
+		assert.Contains(t, stmt.SQL, "events.id IN (SELECT event_id FROM event_participants WHERE user_id = $")
+		assert.Contains(t, stmt.SQL, "events.created_at >= $")
+		assert.Contains(t, stmt.SQL, "events.title ILIKE $")
+		assert.Contains(t, stmt.Vars, `%50\%\_off%`)
+	}
+	assert.Contains(t, count.SQL, "SELECT count(*)")
+	// Newest first, with one row more than the page to tell whether another follows
+	assert.Contains(t, page.SQL, "ORDER BY events.created_at DESC,events.id DESC LIMIT $")
+	assert.Contains(t, page.Vars, 21)
+}
+
+func TestListsStartAfterTheCursor(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	ctx := tenant.System(context.Background())
+	id := uuid.New()
+	at := time.Date(2025, 1, 15, 10, 0, 0, 123456000, time.UTC)
+
+	tests := []struct {
+		name  string
+		call  func(cursor string) error
+		sort  string
+		where string
+	}{
+		{"events", func(cursor string) error {
+			_, _, err := repository.NewGormEventRepository(db).List(ctx, models.EventFilter{Page: models.PageRequest{Limit: 10, Cursor: cursor}})
+			return err
+		}, "-created_at", "(events.created_at, events.id) < ($"},
+		{"time slots", func(cursor string) error {
+			_, _, err := repository.NewGormTimeSlotRepository(db).ListByEventID(ctx, uuid.New(), models.PageRequest{Limit: 10, Cursor: cursor})
+			return err
+		}, "start_time", "(time_slots.start_time, time_slots.id) > ($"},
+		{"availability", func(cursor string) error {
+			_, _, err := repository.NewGormAvailabilityRepository(db).ListByEventID(ctx, uuid.New(), models.PageRequest{Limit: 10, Cursor: cursor})
+			return err
+		}, "start_time", "(availabilities.start_time, availabilities.id) > ($"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := models.Cursor{Sort: tt.sort, Value: at.Format(time.RFC3339Nano), ID: id}.Encode()
+			require.NoError(t, tt.call(cursor))
+			stmt := last(t, statements)
+			assert.Contains(t, stmt.SQL, tt.where)
+			assert.Contains(t, stmt.Vars, at)
+			assert.Contains(t, stmt.Vars, id)
+
+			// A cursor from another sort order cannot be continued
+			other := models.Cursor{Sort: "created_at", Value: at.Format(time.RFC3339Nano), ID: id}.Encode()
+			assert.ErrorIs(t, tt.call(other), apperrors.ErrInvalidCursor)
+			assert.ErrorIs(t, tt.call("not a cursor"), apperrors.ErrInvalidCursor)
+		})
+	}
+}
+
+func TestEventListRejectsUnknownSort(t *testing.T) {
+	db, _ := newDryRunDB(t)
+
+	_, _, err := repository.NewGormEventRepository(db).List(tenant.System(context.Background()), models.EventFilter{
+		Page: models.PageRequest{Limit: 10, Sort: "-organization_id"},
+	})
+
+	assert.ErrorIs(t, err, apperrors.ErrInvalidSort)
+}
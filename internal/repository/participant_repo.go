@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+)
+
+// ParticipantRepository defines the interface for event participant data access
+type ParticipantRepository interface {
+	Create(ctx context.Context, participant *models.EventParticipant) error
+	Get(ctx context.Context, eventID, userID uuid.UUID) (*models.EventParticipant, error)
+	Update(ctx context.Context, participant *models.EventParticipant) error
+	Delete(ctx context.Context, eventID, userID uuid.UUID) error
+	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.EventParticipant, error)
+}
+
+// GormParticipantRepository implements ParticipantRepository using GORM
+type GormParticipantRepository struct {
+	db *gorm.DB
+}
+
+// NewGormParticipantRepository creates a new GormParticipantRepository
+func NewGormParticipantRepository(db *gorm.DB) *GormParticipantRepository {
+	return &GormParticipantRepository{db: db}
+}
+
+// Create saves a new participant
+func (r *GormParticipantRepository) Create(ctx context.Context, participant *models.EventParticipant) error {
+	if participant.ID == uuid.Nil {
+		participant.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(participant).Error
+}
+
+// Get retrieves the participant record of a user in an event
+func (r *GormParticipantRepository) Get(ctx context.Context, eventID, userID uuid.UUID) (*models.EventParticipant, error) {
+	var participant models.EventParticipant
+	if err := conn(ctx, r.db).Where("event_id = ? AND user_id = ?", eventID, userID).First(&participant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrParticipantNotFound
+		}
+		return nil, err
+	}
+	return &participant, nil
+}
+
+// Update changes the group a participant was added through
+func (r *GormParticipantRepository) Update(ctx context.Context, participant *models.EventParticipant) error {
+	return conn(ctx, r.db).Model(&models.EventParticipant{}).Where("id = ?", participant.ID).
+		Update("group_id", participant.GroupID).Error
+}
+
+// Delete removes a user from an event
+func (r *GormParticipantRepository) Delete(ctx context.Context, eventID, userID uuid.UUID) error {
+	return conn(ctx, r.db).Where("event_id = ? AND user_id = ?", eventID, userID).Delete(&models.EventParticipant{}).Error
+}
+
+// GetByEventID retrieves all participants of an event
+func (r *GormParticipantRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.EventParticipant, error) {
+	var participants []*models.EventParticipant
+	err := conn(ctx, r.db).Where("event_id = ?", eventID).Order("created_at").Find(&participants).Error
+	return participants, err
+}
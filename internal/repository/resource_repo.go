@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ResourceRepository defines the interface for resource catalog data access
+type ResourceRepository interface {
+	Create(ctx context.Context, resource *models.Resource) error
+	Update(ctx context.Context, resource *models.Resource) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Resource, error)
+	List(ctx context.Context, kind models.ResourceKind) ([]*models.Resource, error)
+	ListSuitable(ctx context.Context, kind models.ResourceKind, minCapacity int) ([]*models.Resource, error)
+	Lock(ctx context.Context, id uuid.UUID) error
+}
+
+// ResourceBookingRepository defines the interface for resource booking data access
+type ResourceBookingRepository interface {
+	Create(ctx context.Context, booking *models.ResourceBooking) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteByEventID(ctx context.Context, eventID uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ResourceBooking, error)
+	ListByResourceID(ctx context.Context, resourceID uuid.UUID) ([]*models.ResourceBooking, error)
+	ListOverlapping(ctx context.Context, resourceIDs []uuid.UUID, from, to time.Time) ([]*models.ResourceBooking, error)
+}
+
+// GormResourceRepository implements ResourceRepository using GORM
+type GormResourceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormResourceRepository creates a new GormResourceRepository
+func NewGormResourceRepository(db *gorm.DB) *GormResourceRepository {
+	return &GormResourceRepository{db: db}
+}
+
+// Create saves a new resource to the database
+func (r *GormResourceRepository) Create(ctx context.Context, resource *models.Resource) error {
+	if resource.ID == uuid.Nil {
+		resource.ID = uuid.New()
+	}
+	if err := stamp(ctx, &resource.OrganizationID); err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Create(resource).Error
+}
+
+// Update updates an existing resource
+func (r *GormResourceRepository) Update(ctx context.Context, resource *models.Resource) error {
+	return scoped(ctx, r.db, "resources").Model(&models.Resource{}).Where("id = ?", resource.ID).Select("*").Omit("organization_id").Updates(resource).Error
+}
+
+// Delete removes a resource by its ID
+func (r *GormResourceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return scoped(ctx, r.db, "resources").Delete(&models.Resource{}, id).Error
+}
+
+// GetByID retrieves a resource by its ID
+func (r *GormResourceRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Resource, error) {
+	var resource models.Resource
+	if err := scoped(ctx, r.db, "resources").Where("id = ?", id).First(&resource).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// List retrieves the resources of a kind, or all resources when kind is empty
+func (r *GormResourceRepository) List(ctx context.Context, kind models.ResourceKind) ([]*models.Resource, error) {
+	var resources []*models.Resource
+	query := scoped(ctx, r.db, "resources").Order("name")
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	err := query.Find(&resources).Error
+	return resources, err
+}
+
+// ListSuitable retrieves the resources of a kind holding at least minCapacity
+// people, smallest first
+func (r *GormResourceRepository) ListSuitable(ctx context.Context, kind models.ResourceKind, minCapacity int) ([]*models.Resource, error) {
+	var resources []*models.Resource
+	err := scoped(ctx, r.db, "resources").
+		Where("kind = ? AND capacity >= ?", kind, minCapacity).
+		Order("capacity, name").
+		Find(&resources).Error
+	return resources, err
+}
+
+// Lock locks a resource until the surrounding transaction ends, so bookings of
+// it are checked and made one at a time
+func (r *GormResourceRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	var resource models.Resource
+	err := scoped(ctx, r.db, "resources").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", id).
+		First(&resource).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.ErrResourceNotFound
+	}
+	return err
+}
+
+// GormResourceBookingRepository implements ResourceBookingRepository using GORM
+type GormResourceBookingRepository struct {
+	db *gorm.DB
+}
+
+// NewGormResourceBookingRepository creates a new GormResourceBookingRepository
+func NewGormResourceBookingRepository(db *gorm.DB) *GormResourceBookingRepository {
+	return &GormResourceBookingRepository{db: db}
+}
+
+// Create saves a new booking to the database. A booking overlapping another
+// one of the same resource violates an exclusion constraint and is reported
+// as the resource being unavailable.
+func (r *GormResourceBookingRepository) Create(ctx context.Context, booking *models.ResourceBooking) error {
+	if booking.ID == uuid.Nil {
+		booking.ID = uuid.New()
+	}
+	err := conn(ctx, r.db).Create(booking).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23P01" { // exclusion_violation
+		return apperrors.ErrResourceUnavailable
+	}
+	return err
+}
+
+// Delete removes a booking by its ID
+func (r *GormResourceBookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Delete(&models.ResourceBooking{}, id).Error
+}
+
+// DeleteByEventID removes the bookings made for an event
+func (r *GormResourceBookingRepository) DeleteByEventID(ctx context.Context, eventID uuid.UUID) error {
+	return conn(ctx, r.db).Where("event_id = ?", eventID).Delete(&models.ResourceBooking{}).Error
+}
+
+// GetByID retrieves a booking by its ID
+func (r *GormResourceBookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ResourceBooking, error) {
+	var booking models.ResourceBooking
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&booking).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrResourceBookingNotFound
+		}
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// ListByResourceID retrieves the bookings of a resource in time order
+func (r *GormResourceBookingRepository) ListByResourceID(ctx context.Context, resourceID uuid.UUID) ([]*models.ResourceBooking, error) {
+	var bookings []*models.ResourceBooking
+	err := conn(ctx, r.db).Where("resource_id = ?", resourceID).Order("start_time").Find(&bookings).Error
+	return bookings, err
+}
+
+// ListOverlapping retrieves the bookings of the given resources that overlap [from, to)
+func (r *GormResourceBookingRepository) ListOverlapping(ctx context.Context, resourceIDs []uuid.UUID, from, to time.Time) ([]*models.ResourceBooking, error) {
+	var bookings []*models.ResourceBooking
+	if len(resourceIDs) == 0 {
+		return bookings, nil
+	}
+	err := conn(ctx, r.db).
+		Where("resource_id IN ? AND start_time < ? AND end_time > ?", resourceIDs, to, from).
+		Order("start_time").
+		Find(&bookings).Error
+	return bookings, err
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+)
+
+// SearchRepository defines the interface for the full-text index of events
+type SearchRepository interface {
+	// Index adds an event to the index, or refreshes it after a change
+	Index(ctx context.Context, event *models.Event) error
+	// Remove takes a deleted event out of the index
+	Remove(ctx context.Context, eventID uuid.UUID) error
+	// Search returns up to query.Limit events matching query.Text, best first
+	Search(ctx context.Context, query models.SearchQuery) ([]*models.SearchResult, error)
+}
+
+// searchVector weighs title words above description words
+const searchVector = "setweight(to_tsvector('english', title), 'A') || " +
+	"setweight(to_tsvector('english', coalesce(description, '')), 'B')"
+
+// GormSearchRepository implements SearchRepository with Postgres full-text
+// search on the search_vector column of events
+type GormSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSearchRepository creates a new GormSearchRepository
+func NewGormSearchRepository(db *gorm.DB) *GormSearchRepository {
+	return &GormSearchRepository{db: db}
+}
+
+// Index computes the search vector of an event from its stored title and
+// description, so it must be called after the event is saved
+func (r *GormSearchRepository) Index(ctx context.Context, event *models.Event) error {
+	return scoped(ctx, r.db, "events").Unscoped().Model(&models.Event{}).
+		Where("id = ?", event.ID).
+		UpdateColumn("search_vector", gorm.Expr(searchVector)).Error
+}
+
+// Remove clears the search vector of an event
+func (r *GormSearchRepository) Remove(ctx context.Context, eventID uuid.UUID) error {
+	return scoped(ctx, r.db, "events").Unscoped().Model(&models.Event{}).
+		Where("id = ?", eventID).
+		UpdateColumn("search_vector", nil).Error
+}
+
+// Search ranks the events of the caller's organization against a web search
+// style query and highlights the matching words
+func (r *GormSearchRepository) Search(ctx context.Context, query models.SearchQuery) ([]*models.SearchResult, error) {
+	options := "StartSel=" + models.HighlightStart + ", StopSel=" + models.HighlightStop
+	var results []*models.SearchResult
+	err := scoped(ctx, r.db, "events").
+		Table("events, websearch_to_tsquery('english', ?) AS query", query.Text).
+		Select(`events.id AS event_id, events.title,
+			ts_rank(events.search_vector, query) AS rank,
+			ts_headline('english', events.title, query, ?) AS title_highlight,
+			ts_headline('english', coalesce(events.description, ''), query, ?) AS description_highlight`,
+			options+", HighlightAll=true",
+			options+", MaxFragments=2, MaxWords=20, MinWords=5",
+		).
+		Where("events.search_vector @@ query AND events.deleted_at IS NULL").
+		Order("rank DESC, events.id").
+		Limit(query.Limit).
+		Find(&results).Error
+	return results, err
+}
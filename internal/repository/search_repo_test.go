@@ -0,0 +1,46 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchIndexesFromTheStoredRow(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	orgID := uuid.New()
+	ctx := tenant.WithOrganization(context.Background(), orgID)
+	searches := repository.NewGormSearchRepository(db)
+
+	require.NoError(t, searches.Index(ctx, &models.Event{ID: uuid.New()}))
+	stmt := last(t, statements)
+	assert.Contains(t, stmt.SQL, `UPDATE "events" SET "search_vector"=setweight(to_tsvector('english', title), 'A')`)
+	assertScoped(t, stmt, "events", orgID)
+
+	require.NoError(t, searches.Remove(ctx, uuid.New()))
+	stmt = last(t, statements)
+	assert.Contains(t, stmt.SQL, `UPDATE "events" SET "search_vector"=$1`)
+	assert.Nil(t, stmt.Vars[0])
+}
+
+func TestSearchRanksMatchesOfTheOrganization(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	orgID := uuid.New()
+	ctx := tenant.WithOrganization(context.Background(), orgID)
+
+	_, err := repository.NewGormSearchRepository(db).Search(ctx, models.SearchQuery{Text: "budget review", Limit: 5})
+	require.NoError(t, err)
+
+	stmt := last(t, statements)
+	assert.Contains(t, stmt.SQL, "FROM events, websearch_to_tsquery('english', $3) AS query")
+	assert.Contains(t, stmt.SQL, "events.search_vector @@ query AND events.deleted_at IS NULL")
+	assert.Contains(t, stmt.SQL, "ORDER BY rank DESC, events.id LIMIT $5")
+	assert.Equal(t, "budget review", stmt.Vars[2])
+	assertScoped(t, stmt, "events", orgID)
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+)
+
+// ShareLinkRepository defines the interface for share link data access
+type ShareLinkRepository interface {
+	Create(ctx context.Context, link *models.ShareLink) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ShareLink, error)
+	ListByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.ShareLink, error)
+	Update(ctx context.Context, link *models.ShareLink) error
+}
+
+// GuestRepository defines the interface for guest data access
+type GuestRepository interface {
+	Create(ctx context.Context, guest *models.Guest) error
+	Update(ctx context.Context, guest *models.Guest) error
+	GetByEmail(ctx context.Context, eventID uuid.UUID, email string) (*models.Guest, error)
+	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.Guest, error)
+}
+
+// GormShareLinkRepository implements ShareLinkRepository using GORM
+type GormShareLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewGormShareLinkRepository creates a new GormShareLinkRepository
+func NewGormShareLinkRepository(db *gorm.DB) *GormShareLinkRepository {
+	return &GormShareLinkRepository{db: db}
+}
+
+// Create saves a new share link
+func (r *GormShareLinkRepository) Create(ctx context.Context, link *models.ShareLink) error {
+	if link.ID == uuid.Nil {
+		link.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(link).Error
+}
+
+// GetByID retrieves a share link by ID
+func (r *GormShareLinkRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ShareLink, error) {
+	var link models.ShareLink
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&link).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrShareLinkNotFound
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+// ListByEventID retrieves the share links of an event, newest first
+func (r *GormShareLinkRepository) ListByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.ShareLink, error) {
+	var links []*models.ShareLink
+	err := conn(ctx, r.db).Where("event_id = ?", eventID).Order("created_at DESC").Find(&links).Error
+	return links, err
+}
+
+// Update saves a share link
+func (r *GormShareLinkRepository) Update(ctx context.Context, link *models.ShareLink) error {
+	return conn(ctx, r.db).Save(link).Error
+}
+
+// GormGuestRepository implements GuestRepository using GORM
+type GormGuestRepository struct {
+	db *gorm.DB
+}
+
+// NewGormGuestRepository creates a new GormGuestRepository
+func NewGormGuestRepository(db *gorm.DB) *GormGuestRepository {
+	return &GormGuestRepository{db: db}
+}
+
+// Create saves a new guest
+func (r *GormGuestRepository) Create(ctx context.Context, guest *models.Guest) error {
+	if guest.ID == uuid.Nil {
+		guest.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(guest).Error
+}
+
+// Update saves a guest
+func (r *GormGuestRepository) Update(ctx context.Context, guest *models.Guest) error {
+	return conn(ctx, r.db).Save(guest).Error
+}
+
+// GetByEmail retrieves the guest of an event with the given email, ignoring case
+func (r *GormGuestRepository) GetByEmail(ctx context.Context, eventID uuid.UUID, email string) (*models.Guest, error) {
+	var guest models.Guest
+	if err := conn(ctx, r.db).Where("event_id = ? AND LOWER(email) = LOWER(?)", eventID, email).First(&guest).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrGuestNotFound
+		}
+		return nil, err
+	}
+	return &guest, nil
+}
+
+// GetByEventID retrieves the guests of an event
+func (r *GormGuestRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.Guest, error) {
+	var guests []*models.Guest
+	err := conn(ctx, r.db).Where("event_id = ?", eventID).Order("created_at").Find(&guests).Error
+	return guests, err
+}
@@ -0,0 +1,134 @@
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestDeletesOnlyMarkRows(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	ctx := tenant.System(context.Background())
+	id := uuid.New()
+
+	tests := []struct {
+		name  string
+		table string
+		call  func() error
+	}{
+		{"event", "events", func() error { return repository.NewGormEventRepository(db).Delete(ctx, id, 3) }},
+		{"time slot", "time_slots", func() error { return repository.NewGormTimeSlotRepository(db).Delete(ctx, id, 3) }},
+		{"availability", "availabilities", func() error { return repository.NewGormAvailabilityRepository(db).Delete(ctx, id, 3) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A dry run matches no rows, as if another writer got there first
+			require.ErrorIs(t, tt.call(), apperrors.ErrStaleVersion)
+			stmt := last(t, statements)
+			assert.True(t, strings.HasPrefix(stmt.SQL, `UPDATE "`+tt.table+`" SET "deleted_at"=`), stmt.SQL)
+			assert.Contains(t, stmt.SQL, "version = $")
+			assert.Contains(t, stmt.Vars, 3)
+		})
+	}
+}
+
+func TestDeletedRowsAreHiddenByDefault(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	ctx := tenant.System(context.Background())
+	id := uuid.New()
+
+	events := repository.NewGormEventRepository(db)
+	slots := repository.NewGormTimeSlotRepository(db)
+	availabilities := repository.NewGormAvailabilityRepository(db)
+
+	tests := []struct {
+		name  string
+		table string
+		call  func()
+	}{
+		{"event get", "events", func() { events.GetByID(ctx, id) }},
+		{"event list", "events", func() { events.List(ctx, models.EventFilter{Page: models.PageRequest{Limit: 10}}) }},
+		{"events by deadline", "events", func() { events.ListByDeadline(ctx, time.Now(), time.Now()) }},
+		{"time slots by event", "time_slots", func() { slots.GetByEventID(ctx, id) }},
+		{"availability by event", "availabilities", func() { availabilities.GetByEventID(ctx, id) }},
+		{"votes by event", "time_slots", func() { repository.NewGormVoteRepository(db).GetByEventID(ctx, id) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.call()
+			assert.Contains(t, last(t, statements).SQL, `"`+tt.table+`"."deleted_at" IS NULL`)
+		})
+	}
+}
+
+func TestConfirmedMeetingsSkipDeletedEvents(t *testing.T) {
+	db, _ := newDryRunDB(t)
+
+	var captured string
+	require.NoError(t, db.Callback().Row().After("gorm:row").Register("test:capture", func(tx *gorm.DB) {
+		captured = tx.Statement.SQL.String()
+	}))
+
+	repository.NewGormEventRepository(db).ListConfirmedMeetings(tenant.System(context.Background()), []uuid.UUID{uuid.New()}, uuid.New(), time.Now(), time.Now().Add(time.Hour))
+	assert.Contains(t, captured, "e.deleted_at IS NULL")
+	assert.Contains(t, captured, "ts.deleted_at IS NULL")
+}
+
+func TestRestoreBringsBackChildrenDeletedWithTheEvent(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	orgID := uuid.New()
+	ctx := tenant.WithOrganization(context.Background(), orgID)
+
+	require.NoError(t, repository.NewGormEventRepository(db).Restore(ctx, uuid.New()))
+
+	var children []statement
+	for _, stmt := range *statements {
+		if strings.HasPrefix(stmt.SQL, `UPDATE "time_slots"`) || strings.HasPrefix(stmt.SQL, `UPDATE "availabilities"`) {
+			children = append(children, stmt)
+		}
+	}
+	require.Len(t, children, 2)
+	for _, stmt := range children {
+		// Slots deleted on their own earlier have another deletion time and stay deleted
+		assert.Contains(t, stmt.SQL, `deleted_at = (SELECT "deleted_at" FROM "events"`)
+	}
+	assertScoped(t, last(t, statements), "events", orgID)
+}
+
+func TestPurgeRemovesRowsPastRetention(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	ctx := tenant.System(context.Background())
+	before := time.Now().Add(-30 * 24 * time.Hour)
+
+	tests := []struct {
+		name  string
+		table string
+		call  func() error
+	}{
+		{"events", "events", func() error { _, err := repository.NewGormEventRepository(db).Purge(ctx, before); return err }},
+		{"time slots", "time_slots", func() error { _, err := repository.NewGormTimeSlotRepository(db).Purge(ctx, before); return err }},
+		{"availability", "availabilities", func() error { _, err := repository.NewGormAvailabilityRepository(db).Purge(ctx, before); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.call())
+			stmt := last(t, statements)
+			// The removed rows come back for the audit trail
+			assert.Equal(t, `DELETE FROM "`+tt.table+`" WHERE deleted_at < $1 RETURNING *`, stmt.SQL)
+			assert.Equal(t, []interface{}{before}, stmt.Vars)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// scoped returns the connection for ctx restricted to the rows of table that
+// belong to the organization ctx acts for. Every query on a tenant-owned table
+// goes through it, so a guessed ID from another organization is not found.
+// System contexts see every organization; any other context without an
+// organization fails with ErrNoOrganization and matches no rows.
+func scoped(ctx context.Context, db *gorm.DB, table string) *gorm.DB {
+	tx := conn(ctx, db)
+	if organizationID, ok := tenant.OrganizationID(ctx); ok {
+		return tx.Where(table+".organization_id = ?", organizationID)
+	}
+	if tenant.IsSystem(ctx) {
+		return tx
+	}
+	// Where starts a new statement, so the error does not stick to db
+	tx = tx.Where("false")
+	tx.AddError(apperrors.ErrNoOrganization)
+	return tx
+}
+
+// stamp assigns a new row to the organization ctx acts for. Only system
+// contexts may create rows without one, and the caller must have set it.
+// Updates omit organization_id, so a row never moves to another organization
+// once created.
+func stamp(ctx context.Context, organizationID *uuid.UUID) error {
+	if id, ok := tenant.OrganizationID(ctx); ok {
+		*organizationID = id
+		return nil
+	}
+	if tenant.IsSystem(ctx) && *organizationID != uuid.Nil {
+		return nil
+	}
+	return apperrors.ErrNoOrganization
+}
@@ -0,0 +1,203 @@
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// statement is a query built by gorm, captured instead of being sent to the database
+type statement struct {
+	SQL  string
+	Vars []interface{}
+}
+
+// newDryRunDB returns a postgres dialect connection that never reaches a
+// server, and the statements issued through it
+func newDryRunDB(t *testing.T) (*gorm.DB, *[]statement) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost user=test dbname=test sslmode=disable"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	require.NoError(t, err)
+
+	var statements []statement
+	capture := func(tx *gorm.DB) {
+		statements = append(statements, statement{SQL: tx.Statement.SQL.String(), Vars: tx.Statement.Vars})
+	}
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:capture", capture))
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture", capture))
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture", capture))
+	require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:capture", capture))
+	return db, &statements
+}
+
+// last returns the most recent statement, failing the test if there is none
+func last(t *testing.T, statements *[]statement) statement {
+	t.Helper()
+	require.NotEmpty(t, *statements)
+	return (*statements)[len(*statements)-1]
+}
+
+func assertScoped(t *testing.T, stmt statement, table string, organizationID uuid.UUID) {
+	t.Helper()
+	assert.Contains(t, stmt.SQL, table+".organization_id = $", stmt.SQL)
+	assert.Contains(t, stmt.Vars, organizationID)
+}
+
+func TestRepositoriesFilterByTenant(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	orgID := uuid.New()
+	ctx := tenant.WithOrganization(context.Background(), orgID)
+	id := uuid.New()
+
+	events := repository.NewGormEventRepository(db)
+	users := repository.NewGormUserRepository(db)
+	slots := repository.NewGormTimeSlotRepository(db)
+	availabilities := repository.NewGormAvailabilityRepository(db)
+	audit := repository.NewGormAuditRepository(db)
+
+	tests := []struct {
+		name  string
+		table string
+		call  func()
+	}{
+		{"event get", "events", func() { events.GetByID(ctx, id) }},
+		{"event list", "events", func() { events.List(ctx, models.EventFilter{Page: models.PageRequest{Limit: 10}}) }},
+		{"event update", "events", func() { events.Update(ctx, &models.Event{ID: id}) }},
+		{"event delete", "events", func() { events.Delete(ctx, id, 1) }},
+		{"deleted event get", "events", func() { events.GetDeletedByID(ctx, id) }},
+		{"event restore", "events", func() { events.Restore(ctx, id) }},
+		{"user get", "users", func() { users.GetByID(ctx, id) }},
+		{"user batch get", "users", func() { users.GetByIDs(ctx, []uuid.UUID{id}) }},
+		{"user update", "users", func() { users.Update(ctx, &models.User{ID: id, Name: "Mallory"}) }},
+		{"user delete", "users", func() { users.Delete(ctx, id) }},
+		{"time slot get", "time_slots", func() { slots.GetByID(ctx, id) }},
+		{"time slots by event", "time_slots", func() { slots.GetByEventID(ctx, id) }},
+		{"time slot update", "time_slots", func() { slots.Update(ctx, &models.TimeSlot{ID: id, StartTime: time.Now()}) }},
+		{"time slot delete", "time_slots", func() { slots.Delete(ctx, id, 1) }},
+		{"time slot restore", "time_slots", func() { slots.Restore(ctx, id) }},
+		{"availability get", "availabilities", func() { availabilities.GetByID(ctx, id) }},
+		{"availability by event", "availabilities", func() { availabilities.GetByEventID(ctx, id) }},
+		{"availability by user", "availabilities", func() { availabilities.GetByUserAndEvent(ctx, id, id) }},
+		{"availability update", "availabilities", func() { availabilities.Update(ctx, &models.Availability{ID: id, StartTime: time.Now()}) }},
+		{"availability delete", "availabilities", func() { availabilities.Delete(ctx, id, 1) }},
+		{"availability restore", "availabilities", func() { availabilities.Restore(ctx, id) }},
+		{"audit trail", "audit_entries", func() { audit.List(ctx, models.AuditFilter{EventID: &id, Limit: 10}) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.call()
+			assertScoped(t, last(t, statements), tt.table, orgID)
+		})
+	}
+}
+
+func TestRepositoriesCannotMoveRowsBetweenTenants(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	ctx := tenant.WithOrganization(context.Background(), uuid.New())
+
+	event := &models.Event{ID: uuid.New(), OrganizationID: uuid.New(), Title: "Planning"}
+	repository.NewGormEventRepository(db).Update(ctx, event)
+
+	set := strings.SplitN(last(t, statements).SQL, "WHERE", 2)[0]
+	assert.NotContains(t, set, "organization_id")
+	assert.Contains(t, set, `"title"`)
+}
+
+func TestRepositoriesStampNewRowsWithTenant(t *testing.T) {
+	db, _ := newDryRunDB(t)
+	orgID := uuid.New()
+	ctx := tenant.WithOrganization(context.Background(), orgID)
+
+	// An organization ID supplied by the caller is replaced by the tenant's
+	event := &models.Event{OrganizationID: uuid.New(), Title: "Planning"}
+	user := &models.User{Name: "Ada", Email: "ada@example.com"}
+	slot := &models.TimeSlot{EventID: uuid.New()}
+	availability := &models.Availability{EventID: uuid.New(), UserID: uuid.New()}
+
+	require.NoError(t, repository.NewGormEventRepository(db).Create(ctx, event))
+	require.NoError(t, repository.NewGormUserRepository(db).Create(ctx, user))
+	require.NoError(t, repository.NewGormTimeSlotRepository(db).Create(ctx, slot))
+	require.NoError(t, repository.NewGormAvailabilityRepository(db).Create(ctx, availability))
+
+	assert.Equal(t, orgID, event.OrganizationID)
+	assert.Equal(t, orgID, user.OrganizationID)
+	assert.Equal(t, orgID, slot.OrganizationID)
+	assert.Equal(t, orgID, availability.OrganizationID)
+}
+
+func TestConfirmedMeetingsFilterByTenant(t *testing.T) {
+	db, _ := newDryRunDB(t)
+	orgID := uuid.New()
+	ctx := tenant.WithOrganization(context.Background(), orgID)
+
+	var captured statement
+	require.NoError(t, db.Callback().Row().After("gorm:row").Register("test:capture", func(tx *gorm.DB) {
+		captured = statement{SQL: tx.Statement.SQL.String(), Vars: tx.Statement.Vars}
+	}))
+
+	repository.NewGormEventRepository(db).ListConfirmedMeetings(ctx, []uuid.UUID{uuid.New()}, uuid.New(), time.Now(), time.Now().Add(time.Hour))
+	assert.Contains(t, captured.SQL, "e.organization_id = $")
+	assert.Contains(t, captured.Vars, orgID)
+}
+
+func TestRepositoriesWithoutTenantFailClosed(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	ctx := context.Background()
+
+	_, err := repository.NewGormEventRepository(db).GetByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, apperrors.ErrNoOrganization)
+	_, _, err = repository.NewGormEventRepository(db).List(ctx, models.EventFilter{Page: models.PageRequest{Limit: 10}})
+	assert.ErrorIs(t, err, apperrors.ErrNoOrganization)
+	_, err = repository.NewGormAuditRepository(db).List(ctx, models.AuditFilter{Limit: 10})
+	assert.ErrorIs(t, err, apperrors.ErrNoOrganization)
+	_, err = repository.NewGormEventRepository(db).ListConfirmedMeetings(ctx, []uuid.UUID{uuid.New()}, uuid.New(), time.Now(), time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, apperrors.ErrNoOrganization)
+
+	event := &models.Event{OrganizationID: uuid.New(), Title: "Planning"}
+	assert.ErrorIs(t, repository.NewGormEventRepository(db).Create(ctx, event), apperrors.ErrNoOrganization)
+
+	// No statement was built, so nothing reached the database
+	for _, stmt := range *statements {
+		assert.Empty(t, stmt.SQL)
+	}
+}
+
+func TestSystemContextSpansTenants(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	ctx := tenant.System(context.Background())
+	events := repository.NewGormEventRepository(db)
+
+	_, err := events.ListByDeadline(ctx, time.Now(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.NotContains(t, last(t, statements).SQL, "organization_id")
+
+	// New rows keep the organization the caller assigned
+	orgID := uuid.New()
+	event := &models.Event{OrganizationID: orgID, Title: "Planning"}
+	require.NoError(t, events.Create(ctx, event))
+	assert.Equal(t, orgID, event.OrganizationID)
+	assert.ErrorIs(t, events.Create(ctx, &models.Event{Title: "Orphan"}), apperrors.ErrNoOrganization)
+
+	// An organization set on top still restricts the system context
+	scopedID := uuid.New()
+	events.GetByID(tenant.WithOrganization(ctx, scopedID), uuid.New())
+	assertScoped(t, last(t, statements), "events", scopedID)
+}
@@ -3,19 +3,35 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
 	"github.com/npkanaka/meeting-scheduler/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TimeSlotRepository defines the interface for time slot data access
 type TimeSlotRepository interface {
 	Create(ctx context.Context, slot *models.TimeSlot) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.TimeSlot, error)
+	// Update writes a time slot read at slot.Version and increments the
+	// version. It fails with ErrStaleVersion when the time slot has changed since.
 	Update(ctx context.Context, slot *models.TimeSlot) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete soft-deletes a time slot read at version. It fails with
+	// ErrStaleVersion when the time slot has changed since.
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	// GetDeletedByID retrieves a soft-deleted time slot by its ID
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.TimeSlot, error)
+	// Restore brings back a soft-deleted time slot
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge permanently removes the time slots deleted before the given time
+	// and returns them
+	Purge(ctx context.Context, before time.Time) ([]*models.TimeSlot, error)
 	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.TimeSlot, error)
+	// ListByEventID returns a page of the time slots of an event and describes the page
+	ListByEventID(ctx context.Context, eventID uuid.UUID, page models.PageRequest) ([]*models.TimeSlot, models.PageInfo, error)
 }
 
 // GormTimeSlotRepository implements TimeSlotRepository using GORM
@@ -33,34 +49,103 @@ func (r *GormTimeSlotRepository) Create(ctx context.Context, slot *models.TimeSl
 	if slot.ID == uuid.Nil {
 		slot.ID = uuid.New()
 	}
-	return r.db.WithContext(ctx).Create(slot).Error
+	if err := stamp(ctx, &slot.OrganizationID); err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Create(slot).Error
 }
 
 // GetByID retrieves a time slot by its ID
 func (r *GormTimeSlotRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.TimeSlot, error) {
 	var slot models.TimeSlot
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&slot).Error; err != nil {
+	if err := scoped(ctx, r.db, "time_slots").Where("id = ?", id).First(&slot).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("time slot not found")
+			return nil, apperrors.ErrTimeSlotNotFound
 		}
 		return nil, err
 	}
 	return &slot, nil
 }
 
-// Update updates an existing time slot
+// Update updates an existing time slot, provided it is still at the version it was read at
 func (r *GormTimeSlotRepository) Update(ctx context.Context, slot *models.TimeSlot) error {
-	return r.db.WithContext(ctx).Model(&models.TimeSlot{}).Where("id = ?", slot.ID).Updates(slot).Error
+	version := slot.Version
+	slot.Version++
+	result := scoped(ctx, r.db, "time_slots").Model(&models.TimeSlot{}).
+		Where("id = ? AND version = ?", slot.ID, version).
+		Omit("organization_id").Updates(slot)
+	return checkVersion(result, &slot.Version, version)
+}
+
+// Delete marks a time slot as deleted
+func (r *GormTimeSlotRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return checkDeleted(scoped(ctx, r.db, "time_slots").Where("id = ? AND version = ?", id, version).Delete(&models.TimeSlot{}))
+}
+
+// GetDeletedByID retrieves a time slot that is deleted but not yet purged
+func (r *GormTimeSlotRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.TimeSlot, error) {
+	var slot models.TimeSlot
+	if err := scoped(ctx, r.db, "time_slots").Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&slot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrTimeSlotNotFound
+		}
+		return nil, err
+	}
+	return &slot, nil
 }
 
-// Delete removes a time slot by its ID
-func (r *GormTimeSlotRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.TimeSlot{}, id).Error
+// Restore clears the deletion of a time slot
+func (r *GormTimeSlotRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return scoped(ctx, r.db, "time_slots").Unscoped().Model(&models.TimeSlot{}).Where("id = ?", id).UpdateColumn("deleted_at", nil).Error
+}
+
+// Purge permanently removes time slots deleted before the given time and returns them
+func (r *GormTimeSlotRepository) Purge(ctx context.Context, before time.Time) ([]*models.TimeSlot, error) {
+	var slots []*models.TimeSlot
+	err := scoped(ctx, r.db, "time_slots").Unscoped().Clauses(clause.Returning{}).Where("deleted_at < ?", before).Delete(&slots).Error
+	return slots, err
 }
 
 // GetByEventID retrieves all time slots for an event
 func (r *GormTimeSlotRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.TimeSlot, error) {
 	var slots []*models.TimeSlot
-	err := r.db.WithContext(ctx).Where("event_id = ?", eventID).Find(&slots).Error
+	err := scoped(ctx, r.db, "time_slots").Where("event_id = ?", eventID).Find(&slots).Error
 	return slots, err
 }
+
+// slotSortKeys are the fields time slots can be listed by
+var slotSortKeys = map[string]sortKey{
+	"start_time": {column: "start_time", time: true},
+	"created_at": {column: "created_at", time: true},
+}
+
+// ListByEventID retrieves a page of the time slots of an event, earliest first
+// unless another sort is requested
+func (r *GormTimeSlotRepository) ListByEventID(ctx context.Context, eventID uuid.UUID, page models.PageRequest) ([]*models.TimeSlot, models.PageInfo, error) {
+	q, err := parsePage(page, slotSortKeys, "start_time")
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	var total int64
+	if err := scoped(ctx, r.db, "time_slots").Model(&models.TimeSlot{}).Where("event_id = ?", eventID).Count(&total).Error; err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	query, err := q.apply(scoped(ctx, r.db, "time_slots").Where("event_id = ?", eventID), "time_slots")
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	var slots []*models.TimeSlot
+	if err := query.Find(&slots).Error; err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	slots, info := finishPage(slots, q, total, func(row *models.TimeSlot) (interface{}, uuid.UUID) {
+		if q.key.column == "created_at" {
+			return row.CreatedAt, row.ID
+		}
+		return row.StartTime, row.ID
+	})
+	return slots, info, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Transactor runs a function inside a database transaction. Repositories called
+// with the context passed to fn take part in the transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// WithinSavepoint runs fn in a savepoint of the transaction carried by
+	// ctx, so that a failing fn rolls back only its own writes. Without a
+	// transaction it behaves like WithinTransaction.
+	WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// txKey is the context key for the active GORM transaction
+type txKey struct{}
+
+// GormTransactor implements Transactor using GORM
+type GormTransactor struct {
+	db *gorm.DB
+}
+
+// NewGormTransactor creates a new GormTransactor
+func NewGormTransactor(db *gorm.DB) *GormTransactor {
+	return &GormTransactor{db: db}
+}
+
+// WithinTransaction commits when fn returns nil and rolls back otherwise.
+// Nested calls join the outer transaction.
+func (t *GormTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// WithinSavepoint rolls back to a savepoint when fn returns an error
+func (t *GormTransactor) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	if !ok {
+		return t.WithinTransaction(ctx, fn)
+	}
+	// GORM nests transactions in savepoints
+	return tx.WithContext(ctx).Transaction(func(savepoint *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, savepoint))
+	})
+}
+
+// conn returns the transaction carried by ctx, or db when there is none
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
 	"github.com/npkanaka/meeting-scheduler/internal/models"
 	"gorm.io/gorm"
 )
@@ -28,20 +30,29 @@ func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
 	return &GormUserRepository{db: db}
 }
 
-// Create saves a new user to the database
+// Create saves a new user to the database. Email addresses are unique within
+// an organization, so another organization's users are never revealed.
 func (r *GormUserRepository) Create(ctx context.Context, user *models.User) error {
 	if user.ID == uuid.Nil {
 		user.ID = uuid.New()
 	}
-	return r.db.WithContext(ctx).Create(user).Error
+	if err := stamp(ctx, &user.OrganizationID); err != nil {
+		return err
+	}
+	err := conn(ctx, r.db).Create(user).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation on the email
+		return apperrors.ErrUserEmailTaken
+	}
+	return err
 }
 
 // GetByID retrieves a user by their ID
 func (r *GormUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	var user models.User
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error; err != nil {
+	if err := scoped(ctx, r.db, "users").Where("id = ?", id).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, apperrors.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -51,16 +62,16 @@ func (r *GormUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models
 // GetByIDs retrieves multiple users by their IDs
 func (r *GormUserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.User, error) {
 	var users []*models.User
-	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+	err := scoped(ctx, r.db, "users").Where("id IN ?", ids).Find(&users).Error
 	return users, err
 }
 
 // Update updates an existing user
 func (r *GormUserRepository) Update(ctx context.Context, user *models.User) error {
-	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", user.ID).Updates(user).Error
+	return scoped(ctx, r.db, "users").Model(&models.User{}).Where("id = ?", user.ID).Omit("organization_id").Updates(user).Error
 }
 
 // Delete removes a user by their ID
 func (r *GormUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.User{}, id).Error
+	return scoped(ctx, r.db, "users").Delete(&models.User{}, id).Error
 }
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"gorm.io/gorm"
+)
+
+// checkVersion finishes a conditional update of a versioned row. An update
+// that matched no row lost to another writer, so the caller's copy is put
+// back to the version it was read at and ErrStaleVersion is returned.
+func checkVersion(result *gorm.DB, version *int, readAt int) error {
+	if result.Error != nil || result.RowsAffected == 0 {
+		*version = readAt
+		if result.Error != nil {
+			return result.Error
+		}
+		return apperrors.ErrStaleVersion
+	}
+	return nil
+}
+
+// checkDeleted finishes a soft delete conditional on the version the row was
+// read at. A delete that matched no row lost to another writer.
+func checkDeleted(result *gorm.DB) error {
+	if result.Error == nil && result.RowsAffected == 0 {
+		return apperrors.ErrStaleVersion
+	}
+	return result.Error
+}
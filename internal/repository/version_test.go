@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdatesAreConditionalOnVersion(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	ctx := tenant.System(context.Background())
+
+	event := &models.Event{ID: uuid.New(), Version: 3}
+	slot := &models.TimeSlot{ID: uuid.New(), Version: 3}
+	availability := &models.Availability{ID: uuid.New(), Version: 3}
+
+	tests := []struct {
+		name    string
+		version *int
+		call    func() error
+	}{
+		{"event", &event.Version, func() error { return repository.NewGormEventRepository(db).Update(ctx, event) }},
+		{"time slot", &slot.Version, func() error { return repository.NewGormTimeSlotRepository(db).Update(ctx, slot) }},
+		{"availability", &availability.Version, func() error { return repository.NewGormAvailabilityRepository(db).Update(ctx, availability) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A dry run matches no rows, as if another writer got there first
+			err := tt.call()
+			require.ErrorIs(t, err, apperrors.ErrStaleVersion)
+			assert.Equal(t, 3, *tt.version, "a lost update keeps the version it was read at")
+
+			stmt := last(t, statements)
+			assert.Contains(t, stmt.SQL, `"version"=$`)
+			assert.Contains(t, stmt.SQL, "version = $")
+			assert.Contains(t, stmt.Vars, 4)
+			assert.Contains(t, stmt.Vars, 3)
+		})
+	}
+}
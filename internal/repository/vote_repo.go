@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+)
+
+// VoteRepository defines the interface for time slot vote data access
+type VoteRepository interface {
+	Create(ctx context.Context, vote *models.Vote) error
+	Update(ctx context.Context, vote *models.Vote) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByTimeSlotAndUser(ctx context.Context, timeSlotID, userID uuid.UUID) (*models.Vote, error)
+	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.Vote, error)
+}
+
+// GormVoteRepository implements VoteRepository using GORM
+type GormVoteRepository struct {
+	db *gorm.DB
+}
+
+// NewGormVoteRepository creates a new GormVoteRepository
+func NewGormVoteRepository(db *gorm.DB) *GormVoteRepository {
+	return &GormVoteRepository{db: db}
+}
+
+// Create saves a new vote to the database
+func (r *GormVoteRepository) Create(ctx context.Context, vote *models.Vote) error {
+	if vote.ID == uuid.Nil {
+		vote.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(vote).Error
+}
+
+// Update changes the choice of an existing vote
+func (r *GormVoteRepository) Update(ctx context.Context, vote *models.Vote) error {
+	return conn(ctx, r.db).Model(&models.Vote{}).Where("id = ?", vote.ID).Updates(map[string]interface{}{
+		"choice":     vote.Choice,
+		"updated_at": vote.UpdatedAt,
+	}).Error
+}
+
+// Delete removes a vote by its ID
+func (r *GormVoteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Delete(&models.Vote{}, id).Error
+}
+
+// GetByTimeSlotAndUser retrieves the vote a user cast on a time slot
+func (r *GormVoteRepository) GetByTimeSlotAndUser(ctx context.Context, timeSlotID, userID uuid.UUID) (*models.Vote, error) {
+	var vote models.Vote
+	if err := conn(ctx, r.db).Where("time_slot_id = ? AND user_id = ?", timeSlotID, userID).First(&vote).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrVoteNotFound
+		}
+		return nil, err
+	}
+	return &vote, nil
+}
+
+// GetByEventID retrieves all votes cast on the live time slots of an event
+func (r *GormVoteRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.Vote, error) {
+	var votes []*models.Vote
+	slots := conn(ctx, r.db).Model(&models.TimeSlot{}).Select("id").Where("event_id = ?", eventID)
+	err := conn(ctx, r.db).Where("event_id = ? AND time_slot_id IN (?)", eventID, slots).Order("created_at").Find(&votes).Error
+	return votes, err
+}
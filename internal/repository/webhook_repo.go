@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookRepository defines the interface for webhook subscription and delivery data access
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, eventID, creatorID *uuid.UUID) ([]*models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	// FindActiveSubscriptions returns active subscriptions for the event or its creator
+	FindActiveSubscriptions(ctx context.Context, eventID, creatorID uuid.UUID) ([]*models.WebhookSubscription, error)
+
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error)
+	// ClaimDueDeliveries leases up to limit pending deliveries that are due by
+	// pushing their next attempt past the lease, so other replicas skip them
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+// GormWebhookRepository implements WebhookRepository using GORM
+type GormWebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWebhookRepository creates a new GormWebhookRepository
+func NewGormWebhookRepository(db *gorm.DB) *GormWebhookRepository {
+	return &GormWebhookRepository{db: db}
+}
+
+// CreateSubscription saves a new webhook subscription
+func (r *GormWebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if subscription.ID == uuid.Nil {
+		subscription.ID = uuid.New()
+	}
+	if err := stamp(ctx, &subscription.OrganizationID); err != nil {
+		return err
+	}
+	return conn(ctx, r.db).Create(subscription).Error
+}
+
+// GetSubscription retrieves a webhook subscription by its ID
+func (r *GormWebhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	if err := scoped(ctx, r.db, "webhook_subscriptions").Where("id = ?", id).First(&subscription).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrWebhookSubscriptionNotFound
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// ListSubscriptions retrieves subscriptions, optionally filtered by event or creator
+func (r *GormWebhookRepository) ListSubscriptions(ctx context.Context, eventID, creatorID *uuid.UUID) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	query := scoped(ctx, r.db, "webhook_subscriptions").Order("created_at")
+	if eventID != nil {
+		query = query.Where("event_id = ?", *eventID)
+	}
+	if creatorID != nil {
+		query = query.Where("creator_id = ?", *creatorID)
+	}
+	err := query.Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// DeleteSubscription removes a webhook subscription by its ID
+func (r *GormWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return scoped(ctx, r.db, "webhook_subscriptions").Delete(&models.WebhookSubscription{}, id).Error
+}
+
+// FindActiveSubscriptions retrieves active subscriptions for an event or its creator
+func (r *GormWebhookRepository) FindActiveSubscriptions(ctx context.Context, eventID, creatorID uuid.UUID) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	err := scoped(ctx, r.db, "webhook_subscriptions").
+		Where("active AND (event_id = ? OR creator_id = ?)", eventID, creatorID).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// CreateDelivery saves a new webhook delivery
+func (r *GormWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if delivery.ID == uuid.Nil {
+		delivery.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(delivery).Error
+}
+
+// GetDelivery retrieves a webhook delivery by its ID
+func (r *GormWebhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&delivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries retrieves the most recent deliveries for a subscription
+func (r *GormWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := conn(ctx, r.db).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDueDeliveries leases a batch of due deliveries
+func (r *GormWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return deliveries, err
+}
+
+// UpdateDelivery saves every field of a webhook delivery
+func (r *GormWebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return conn(ctx, r.db).Save(delivery).Error
+}
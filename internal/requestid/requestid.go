@@ -0,0 +1,23 @@
+// Package requestid carries the ID of the request being served, so records
+// written while serving it can be traced back to it.
+package requestid
+
+import "context"
+
+// Header is the HTTP header a request ID is read from and echoed in
+const Header = "X-Request-ID"
+
+// ctxKey is the context key for the request ID
+type ctxKey struct{}
+
+// With returns a copy of ctx carrying the request ID
+func With(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// From returns the request ID ctx carries, or an empty string for work that
+// did not start with a request
+func From(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
@@ -0,0 +1,187 @@
+// Package search holds the in-process fallback for the full-text index of
+// events. Postgres deployments search the events table itself through
+// repository.GormSearchRepository.
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+)
+
+// Weights of a matching word in the title and in the description, as
+// Postgres weighs labels A and B
+const (
+	titleWeight       = 1.0
+	descriptionWeight = 0.4
+)
+
+// document is the indexed text of one event
+type document struct {
+	organizationID uuid.UUID
+	title          string
+	description    string
+}
+
+// MemoryIndex keeps the searchable text of events in process memory. A
+// search matches events whose title or description has a word starting with
+// every word of the query, without stemming or query operators. The index is
+// not shared between replicas, so it suits tests and single-instance setups.
+type MemoryIndex struct {
+	mu   sync.RWMutex
+	docs map[uuid.UUID]document
+}
+
+// NewMemoryIndex creates an empty MemoryIndex
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{docs: make(map[uuid.UUID]document)}
+}
+
+// Index adds or replaces the text of an event
+func (i *MemoryIndex) Index(ctx context.Context, event *models.Event) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.docs[event.ID] = document{
+		organizationID: event.OrganizationID,
+		title:          event.Title,
+		description:    event.Description,
+	}
+	return nil
+}
+
+// Remove drops an event from the index
+func (i *MemoryIndex) Remove(ctx context.Context, eventID uuid.UUID) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.docs, eventID)
+	return nil
+}
+
+// Search returns the events of the organization ctx acts for that match
+// every word of the query, best first
+func (i *MemoryIndex) Search(ctx context.Context, query models.SearchQuery) ([]*models.SearchResult, error) {
+	terms := words(query.Text)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	organizationID, scoped := tenant.OrganizationID(ctx)
+	if !scoped && !tenant.IsSystem(ctx) {
+		return nil, apperrors.ErrNoOrganization
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var results []*models.SearchResult
+	for id, doc := range i.docs {
+		if scoped && doc.organizationID != organizationID {
+			continue
+		}
+		title, titleHits := match(doc.title, terms)
+		description, descriptionHits := match(doc.description, terms)
+		if !covers(titleHits, descriptionHits, len(terms)) {
+			continue
+		}
+		results = append(results, &models.SearchResult{
+			EventID:              id,
+			Title:                doc.title,
+			Rank:                 titleWeight*float64(count(titleHits)) + descriptionWeight*float64(count(descriptionHits)),
+			TitleHighlight:       title,
+			DescriptionHighlight: description,
+		})
+	}
+
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Rank != results[b].Rank {
+			return results[a].Rank > results[b].Rank
+		}
+		return results[a].EventID.String() < results[b].EventID.String()
+	})
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
+
+// match highlights the words of text that start with a term and counts the
+// matches of each term
+func match(text string, terms []string) (string, []int) {
+	hits := make([]int, len(terms))
+	var b strings.Builder
+	start := -1
+	flush := func(end int) {
+		word := text[start:end]
+		lower := strings.ToLower(word)
+		matched := false
+		for t, term := range terms {
+			if strings.HasPrefix(lower, term) {
+				hits[t]++
+				matched = true
+			}
+		}
+		if matched {
+			b.WriteString(models.HighlightStart + word + models.HighlightStop)
+		} else {
+			b.WriteString(word)
+		}
+		start = -1
+	}
+	for pos, r := range text {
+		if isWordRune(r) {
+			if start < 0 {
+				start = pos
+			}
+			continue
+		}
+		if start >= 0 {
+			flush(pos)
+		}
+		b.WriteRune(r)
+	}
+	if start >= 0 {
+		flush(len(text))
+	}
+	return b.String(), hits
+}
+
+// covers reports whether every term matched the title or the description
+func covers(titleHits, descriptionHits []int, terms int) bool {
+	for t := 0; t < terms; t++ {
+		if titleHits[t] == 0 && descriptionHits[t] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func count(hits []int) int {
+	n := 0
+	for _, h := range hits {
+		n += h
+	}
+	return n
+}
+
+// words splits text into lower case words, dropping duplicates
+func words(text string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !isWordRune(r) }) {
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,65 @@
+package search_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/search"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryIndexRanksTitleMatchesFirst(t *testing.T) {
+	index := search.NewMemoryIndex()
+	ctx := tenant.System(context.Background())
+	inTitle := &models.Event{ID: uuid.New(), Title: "Budget review", Description: "Numbers for Q3"}
+	inDescription := &models.Event{ID: uuid.New(), Title: "Team sync", Description: "We review the budget, then lunch"}
+	unrelated := &models.Event{ID: uuid.New(), Title: "Offsite", Description: "Travel plans"}
+	for _, event := range []*models.Event{inTitle, inDescription, unrelated} {
+		require.NoError(t, index.Index(ctx, event))
+	}
+
+	results, err := index.Search(ctx, models.SearchQuery{Text: "Budget REVIEW", Limit: 10})
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	assert.Equal(t, inTitle.ID, results[0].EventID)
+	assert.Equal(t, "<b>Budget</b> <b>review</b>", results[0].TitleHighlight)
+	assert.Equal(t, inDescription.ID, results[1].EventID)
+	assert.Equal(t, "We <b>review</b> the <b>budget</b>, then lunch", results[1].DescriptionHighlight)
+	assert.Greater(t, results[0].Rank, results[1].Rank)
+}
+
+func TestMemoryIndexMatchesEveryWordByPrefix(t *testing.T) {
+	index := search.NewMemoryIndex()
+	ctx := tenant.System(context.Background())
+	event := &models.Event{ID: uuid.New(), Title: "Planning session"}
+	require.NoError(t, index.Index(ctx, event))
+
+	results, _ := index.Search(ctx, models.SearchQuery{Text: "plan", Limit: 10})
+	assert.Len(t, results, 1)
+	results, _ = index.Search(ctx, models.SearchQuery{Text: "plan retro", Limit: 10})
+	assert.Empty(t, results)
+
+	require.NoError(t, index.Remove(ctx, event.ID))
+	results, _ = index.Search(ctx, models.SearchQuery{Text: "plan", Limit: 10})
+	assert.Empty(t, results)
+}
+
+func TestMemoryIndexOnlySearchesTheCallersOrganization(t *testing.T) {
+	index := search.NewMemoryIndex()
+	ours, theirs := uuid.New(), uuid.New()
+	require.NoError(t, index.Index(context.Background(), &models.Event{ID: uuid.New(), OrganizationID: ours, Title: "Standup"}))
+	require.NoError(t, index.Index(context.Background(), &models.Event{ID: uuid.New(), OrganizationID: theirs, Title: "Standup"}))
+
+	results, err := index.Search(tenant.WithOrganization(context.Background(), ours), models.SearchQuery{Text: "standup", Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	_, err = index.Search(context.Background(), models.SearchQuery{Text: "standup", Limit: 10})
+	assert.ErrorIs(t, err, apperrors.ErrNoOrganization)
+}
@@ -0,0 +1,118 @@
+// internal/service/audit.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/requestid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// recordAudit appends a change to the audit trail. It must be called with the
+// transaction context of the change, so the entry commits with it. before is
+// nil for a created entity and after is nil for a deleted one.
+func recordAudit(ctx context.Context, auditRepo repository.AuditRepository, action, entityType string, entityID uuid.UUID, eventID *uuid.UUID, before, after interface{}) error {
+	entry := &models.AuditEntry{
+		EventID:    eventID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		RequestID:  requestid.From(ctx),
+		CreatedAt:  time.Now(),
+	}
+	entry.ActorType, entry.ActorID = auditActor(ctx)
+
+	var err error
+	if entry.Before, err = auditSnapshot(before); err != nil {
+		return err
+	}
+	if entry.After, err = auditSnapshot(after); err != nil {
+		return err
+	}
+	return auditRepo.Create(ctx, entry)
+}
+
+// auditSnapshot renders an entity the way the API returns it; nil and nil
+// pointers have no snapshot
+func auditSnapshot(entity interface{}) (json.RawMessage, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	if v := reflect.ValueOf(entity); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+	return json.Marshal(entity)
+}
+
+// guestActorKey is the context key for a guest making changes through a share link
+type guestActorKey struct{}
+
+// withGuestActor returns a copy of ctx whose changes are attributed to a guest
+func withGuestActor(ctx context.Context, guestID uuid.UUID) context.Context {
+	return context.WithValue(ctx, guestActorKey{}, guestID)
+}
+
+// auditActor identifies who makes the changes of ctx: the authenticated user,
+// a guest, or the system for background work and admin calls
+func auditActor(ctx context.Context) (models.AuditActorType, *uuid.UUID) {
+	if principal, ok := auth.PrincipalFrom(ctx); ok {
+		return models.AuditActorUser, &principal.UserID
+	}
+	if guestID, ok := ctx.Value(guestActorKey{}).(uuid.UUID); ok {
+		return models.AuditActorGuest, &guestID
+	}
+	return models.AuditActorSystem, nil
+}
+
+// AuditService reads the audit trail
+type AuditService struct {
+	auditRepo repository.AuditRepository
+	eventRepo repository.EventRepository
+}
+
+// NewAuditService creates a new AuditService
+func NewAuditService(auditRepo repository.AuditRepository, eventRepo repository.EventRepository) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+// GetEventHistory returns the changes to an event, its time slots and its
+// availability, most recent first. The history of a deleted event stays
+// available.
+func (s *AuditService) GetEventHistory(ctx context.Context, eventID uuid.UUID, limit int) ([]*models.AuditEntry, error) {
+	ctx, span := startSpan(ctx, "AuditService.GetEventHistory", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	entries, err := s.auditRepo.List(ctx, models.AuditFilter{EventID: &eventID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		// Tell an event without recorded changes from one that does not exist
+		if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+			return nil, err
+		}
+	}
+	span.SetAttributes(attribute.Int("audit.count", len(entries)))
+	return entries, nil
+}
+
+// ListEntries returns the entries matching filter, most recent first. Called
+// without an organization, as the admin routes do, it spans all of them.
+func (s *AuditService) ListEntries(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEntry, error) {
+	ctx, span := startSpan(ctx, "AuditService.ListEntries")
+	defer span.End()
+
+	entries, err := s.auditRepo.List(ctx, filter)
+	span.SetAttributes(attribute.Int("audit.count", len(entries)))
+	return entries, err
+}
@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/requestid"
+	"github.com/npkanaka/meeting-scheduler/internal/search"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeAuditRepository keeps the audit trail in memory
+type FakeAuditRepository struct {
+	Entries []*models.AuditEntry
+}
+
+func (f *FakeAuditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	// Stamped like the real repository does
+	if organizationID, ok := tenant.OrganizationID(ctx); ok {
+		entry.OrganizationID = organizationID
+	}
+	f.Entries = append(f.Entries, entry)
+	return nil
+}
+
+func (f *FakeAuditRepository) List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEntry, error) {
+	var entries []*models.AuditEntry
+	for i := len(f.Entries) - 1; i >= 0 && len(entries) < filter.Limit; i-- {
+		entry := f.Entries[i]
+		if filter.EventID == nil || (entry.EventID != nil && *entry.EventID == *filter.EventID) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func TestAuditTrailRecordsWhoChangedWhatAndHow(t *testing.T) {
+	timeslotRepo := new(MockTimeSlotRepository)
+	eventRepo := new(MockEventRepository)
+	auditRepo := &FakeAuditRepository{}
+	timeslotService := service.NewTimeSlotService(timeslotRepo, eventRepo, FakeTransactor{}, &FakeOutboxRepository{}, auditRepo, emptyResourceService())
+
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	slot := &models.TimeSlot{ID: uuid.New(), EventID: uuid.New(), StartTime: start, EndTime: start.Add(time.Hour)}
+	timeslotRepo.On("GetByID", mock.Anything, slot.ID).Return(slot, nil)
+	timeslotRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	timeslotRepo.On("Delete", mock.Anything, slot.ID, mock.Anything).Return(nil)
+
+	principal := auth.Principal{UserID: uuid.New(), OrganizationID: uuid.New()}
+	ctx := requestid.With(auth.WithPrincipal(context.Background(), principal), "req-42")
+
+	_, err := timeslotService.UpdateTimeSlot(ctx, slot.ID, &models.TimeSlotRequest{
+		StartTime: start.Add(2 * time.Hour).Format(time.RFC3339),
+		EndTime:   start.Add(3 * time.Hour).Format(time.RFC3339),
+	}, models.Precondition{})
+	require.NoError(t, err)
+	require.NoError(t, timeslotService.DeleteTimeSlot(context.Background(), slot.ID, models.Precondition{}))
+
+	require.Len(t, auditRepo.Entries, 2)
+	updated, deleted := auditRepo.Entries[0], auditRepo.Entries[1]
+
+	assert.Equal(t, models.TopicTimeSlotUpdated, updated.Action)
+	assert.Equal(t, models.AuditEntityTimeSlot, updated.EntityType)
+	assert.Equal(t, slot.ID, updated.EntityID)
+	assert.Equal(t, &slot.EventID, updated.EventID)
+	assert.Equal(t, models.AuditActorUser, updated.ActorType)
+	assert.Equal(t, &principal.UserID, updated.ActorID)
+	assert.Equal(t, "req-42", updated.RequestID)
+
+	var before, after models.TimeSlot
+	require.NoError(t, json.Unmarshal(updated.Before, &before))
+	require.NoError(t, json.Unmarshal(updated.After, &after))
+	assert.True(t, before.StartTime.Equal(start))
+	assert.True(t, after.StartTime.Equal(start.Add(2*time.Hour)))
+
+	// Background work is attributed to the system, and a deletion keeps what was removed
+	assert.Equal(t, models.TopicTimeSlotDeleted, deleted.Action)
+	assert.Equal(t, models.AuditActorSystem, deleted.ActorType)
+	assert.Nil(t, deleted.ActorID)
+	assert.NotEmpty(t, deleted.Before)
+	assert.Empty(t, deleted.After)
+}
+
+func TestEventHistoryOutlivesTheEvent(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	auditRepo := &FakeAuditRepository{}
+	eventService := service.NewEventService(eventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, auditRepo, search.NewMemoryIndex(), emptyResourceService())
+	auditService := service.NewAuditService(auditRepo, eventRepo)
+
+	ctx := context.Background()
+	eventRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	event, err := eventService.CreateEvent(ctx, &models.CreateEventRequest{Title: "Sync", Duration: 30}, uuid.New())
+	require.NoError(t, err)
+
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil).Once()
+	eventRepo.On("Delete", mock.Anything, event.ID, event.Version).Return(nil)
+	require.NoError(t, eventService.DeleteEvent(ctx, event.ID, models.Precondition{}))
+
+	eventRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, errors.ErrEventNotFound)
+
+	history, err := auditService.GetEventHistory(ctx, event.ID, 100)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, models.TopicEventDeleted, history[0].Action)
+	assert.Equal(t, models.TopicEventCreated, history[1].Action)
+	assert.Empty(t, history[1].Before)
+
+	_, err = auditService.GetEventHistory(ctx, uuid.New(), 100)
+	assert.ErrorIs(t, err, errors.ErrEventNotFound)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/npkanaka/meeting-scheduler/internal/errors"
 	"github.com/npkanaka/meeting-scheduler/internal/models"
 	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // AvailabilityService handles availability business logic
@@ -16,6 +17,9 @@ type AvailabilityService struct {
 	availabilityRepo repository.AvailabilityRepository
 	eventRepo        repository.EventRepository
 	userRepo         repository.UserRepository
+	transactor       repository.Transactor
+	outboxRepo       repository.OutboxRepository
+	auditRepo        repository.AuditRepository
 }
 
 // NewAvailabilityService creates a new AvailabilityService
@@ -23,18 +27,30 @@ func NewAvailabilityService(
 	availabilityRepo repository.AvailabilityRepository,
 	eventRepo repository.EventRepository,
 	userRepo repository.UserRepository,
+	transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository,
+	auditRepo repository.AuditRepository,
 ) *AvailabilityService {
 	return &AvailabilityService{
 		availabilityRepo: availabilityRepo,
 		eventRepo:        eventRepo,
 		userRepo:         userRepo,
+		transactor:       transactor,
+		outboxRepo:       outboxRepo,
+		auditRepo:        auditRepo,
 	}
 }
 
 // CreateAvailability creates a new availability record
 func (s *AvailabilityService) CreateAvailability(ctx context.Context, eventID uuid.UUID, req *models.AvailabilityRequest) (*models.Availability, error) {
+	ctx, span := startSpan(ctx, "AvailabilityService.CreateAvailability",
+		attribute.String("event.id", eventID.String()),
+		attribute.String("user.id", req.UserID.String()),
+	)
+	defer span.End()
+
 	// Verify the event exists
-	_, err := s.eventRepo.GetByID(ctx, eventID)
+	event, err := s.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -71,24 +87,42 @@ func (s *AvailabilityService) CreateAvailability(ctx context.Context, eventID uu
 		UpdatedAt: now,
 	}
 
-	if err := s.availabilityRepo.Create(ctx, availability); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.availabilityRepo.Create(ctx, availability); err != nil {
+			return err
+		}
+		return s.recordAvailability(ctx, models.TopicAvailabilitySubmitted, nil, availability, &event.CreatorID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return availability, nil
 }
 
-// UpdateAvailability updates an existing availability record
-func (s *AvailabilityService) UpdateAvailability(ctx context.Context, id uuid.UUID, req *models.AvailabilityRequest) (*models.Availability, error) {
+// UpdateAvailability updates a user's availability for an event. The
+// precondition applies to the user's availability for the event as a whole,
+// as listed by GetUserEventAvailability.
+func (s *AvailabilityService) UpdateAvailability(ctx context.Context, id uuid.UUID, req *models.AvailabilityRequest, pre models.Precondition) (*models.Availability, error) {
+	ctx, span := startSpan(ctx, "AvailabilityService.UpdateAvailability",
+		attribute.String("event.id", id.String()),
+		attribute.String("user.id", req.UserID.String()),
+	)
+	defer span.End()
+
 	// Get existing availabilities for this user and event
 	availabilities, err := s.availabilityRepo.GetByUserAndEvent(ctx, req.UserID, id)
 	if err != nil || len(availabilities) == 0 {
 		return nil, errors.ErrAvailabilityNotFound
 	}
+	if !pre.Allows(models.AvailabilityListETag(availabilities)) {
+		return nil, errors.ErrStaleVersion
+	}
 
 	// For simplicity, we'll update the first availability entry
 	// In a real app, you might want to handle this differently
 	availability := availabilities[0]
+	before := *availability
 
 	// Parse time strings
 	startTime, err := time.Parse(time.RFC3339, req.StartTime)
@@ -110,24 +144,117 @@ func (s *AvailabilityService) UpdateAvailability(ctx context.Context, id uuid.UU
 	availability.EndTime = endTime
 	availability.UpdatedAt = time.Now()
 
-	if err := s.availabilityRepo.Update(ctx, availability); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.availabilityRepo.Update(ctx, availability); err != nil {
+			return err
+		}
+		return s.recordAvailability(ctx, models.TopicAvailabilityUpdated, &before, availability, nil)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return availability, nil
 }
 
-// DeleteAvailability removes an availability record
-func (s *AvailabilityService) DeleteAvailability(ctx context.Context, id uuid.UUID) error {
-	return s.availabilityRepo.Delete(ctx, id)
+// DeleteAvailability deletes an availability record that meets the
+// precondition until it is restored or purged
+func (s *AvailabilityService) DeleteAvailability(ctx context.Context, id uuid.UUID, pre models.Precondition) error {
+	ctx, span := startSpan(ctx, "AvailabilityService.DeleteAvailability", attribute.String("availability.id", id.String()))
+	defer span.End()
+
+	availability, err := s.availabilityRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !pre.Allows(availability.ETag()) {
+		return errors.ErrStaleVersion
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.availabilityRepo.Delete(ctx, id, availability.Version); err != nil {
+			return err
+		}
+		return s.recordAvailability(ctx, models.TopicAvailabilityDeleted, availability, nil, nil)
+	})
+}
+
+// GetAvailability retrieves an availability record by ID
+func (s *AvailabilityService) GetAvailability(ctx context.Context, id uuid.UUID) (*models.Availability, error) {
+	ctx, span := startSpan(ctx, "AvailabilityService.GetAvailability", attribute.String("availability.id", id.String()))
+	defer span.End()
+
+	return s.availabilityRepo.GetByID(ctx, id)
+}
+
+// RestoreAvailability brings back a deleted availability record of an event
+// that is not deleted
+func (s *AvailabilityService) RestoreAvailability(ctx context.Context, id uuid.UUID) (*models.Availability, error) {
+	ctx, span := startSpan(ctx, "AvailabilityService.RestoreAvailability", attribute.String("availability.id", id.String()))
+	defer span.End()
+
+	before, err := s.availabilityRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Availability deleted with its event comes back when the event is restored
+	if _, err := s.eventRepo.GetByID(ctx, before.EventID); err != nil {
+		return nil, err
+	}
+
+	var availability *models.Availability
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.availabilityRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		if availability, err = s.availabilityRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return s.recordAvailability(ctx, models.TopicAvailabilityRestored, before, availability, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return availability, nil
 }
 
 // GetUserEventAvailability retrieves all availability records for a user and event
 func (s *AvailabilityService) GetUserEventAvailability(ctx context.Context, userID, eventID uuid.UUID) ([]*models.Availability, error) {
-	return s.availabilityRepo.GetByUserAndEvent(ctx, userID, eventID)
+	ctx, span := startSpan(ctx, "AvailabilityService.GetUserEventAvailability",
+		attribute.String("event.id", eventID.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	availabilities, err := s.availabilityRepo.GetByUserAndEvent(ctx, userID, eventID)
+	span.SetAttributes(attribute.Int("availabilities.count", len(availabilities)))
+	return availabilities, err
+}
+
+// GetEventAvailability retrieves a page of the availability records for an event
+func (s *AvailabilityService) GetEventAvailability(ctx context.Context, eventID uuid.UUID, page models.PageRequest) ([]*models.Availability, models.PageInfo, error) {
+	ctx, span := startSpan(ctx, "AvailabilityService.GetEventAvailability", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	availabilities, info, err := s.availabilityRepo.ListByEventID(ctx, eventID, page)
+	span.SetAttributes(attribute.Int("availabilities.count", len(availabilities)))
+	return availabilities, info, err
 }
 
-// GetEventAvailability retrieves all availability records for an event
-func (s *AvailabilityService) GetEventAvailability(ctx context.Context, eventID uuid.UUID) ([]*models.Availability, error) {
-	return s.availabilityRepo.GetByEventID(ctx, eventID)
+// recordAvailability writes an availability lifecycle message to the outbox
+// and the change to the audit trail. before is nil for new availability and
+// after is nil for deleted availability.
+func (s *AvailabilityService) recordAvailability(ctx context.Context, topic string, before, after *models.Availability, creatorID *uuid.UUID) error {
+	availability := after
+	if availability == nil {
+		availability = before
+	}
+	err := recordLifecycle(ctx, s.outboxRepo, topic, availability.EventID, creatorID, map[string]interface{}{
+		"availability": availability,
+	})
+	if err != nil {
+		return err
+	}
+	return recordAudit(ctx, s.auditRepo, topic, models.AuditEntityAvailability, availability.ID, &availability.EventID, before, after)
 }
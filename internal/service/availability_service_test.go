@@ -22,6 +22,9 @@ func TestCreateAvailability(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
@@ -83,6 +86,9 @@ func TestCreateAvailabilityEventNotFound(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
@@ -121,6 +127,9 @@ func TestCreateAvailabilityUserNotFound(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
@@ -166,6 +175,9 @@ func TestCreateAvailabilityInvalidTimeRange(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
@@ -213,6 +225,9 @@ func TestUpdateAvailability(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
@@ -249,7 +264,7 @@ func TestUpdateAvailability(t *testing.T) {
 	})).Return(nil)
 
 	// Execute the method
-	updatedAvailability, err := availabilityService.UpdateAvailability(context.Background(), eventID, req)
+	updatedAvailability, err := availabilityService.UpdateAvailability(context.Background(), eventID, req, models.Precondition{})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -271,6 +286,9 @@ func TestUpdateAvailabilityNoExistingAvailability(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
@@ -288,7 +306,7 @@ func TestUpdateAvailabilityNoExistingAvailability(t *testing.T) {
 	}
 
 	// Execute the method
-	updatedAvailability, err := availabilityService.UpdateAvailability(context.Background(), eventID, req)
+	updatedAvailability, err := availabilityService.UpdateAvailability(context.Background(), eventID, req, models.Precondition{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -308,6 +326,9 @@ func TestUpdateAvailabilityInvalidTimeRange(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
@@ -340,7 +361,7 @@ func TestUpdateAvailabilityInvalidTimeRange(t *testing.T) {
 	mockAvailabilityRepo.On("GetByUserAndEvent", mock.Anything, userID, eventID).Return(existingAvailabilities, nil)
 
 	// Execute the method
-	updatedAvailability, err := availabilityService.UpdateAvailability(context.Background(), eventID, req)
+	updatedAvailability, err := availabilityService.UpdateAvailability(context.Background(), eventID, req, models.Precondition{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -360,16 +381,25 @@ func TestDeleteAvailability(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
 	availabilityID := uuid.New()
 
 	// Set expectations
-	mockAvailabilityRepo.On("Delete", mock.Anything, availabilityID).Return(nil)
+	mockAvailabilityRepo.On("GetByID", mock.Anything, availabilityID).Return(&models.Availability{
+		ID:      availabilityID,
+		UserID:  uuid.New(),
+		EventID: uuid.New(),
+		Version: 2,
+	}, nil)
+	mockAvailabilityRepo.On("Delete", mock.Anything, availabilityID, 2).Return(nil)
 
 	// Execute the method
-	err := availabilityService.DeleteAvailability(context.Background(), availabilityID)
+	err := availabilityService.DeleteAvailability(context.Background(), availabilityID, models.Precondition{})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -387,16 +417,24 @@ func TestDeleteAvailabilityError(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
 	availabilityID := uuid.New()
 
 	// Set expectations
-	mockAvailabilityRepo.On("Delete", mock.Anything, availabilityID).Return(assert.AnError)
+	mockAvailabilityRepo.On("GetByID", mock.Anything, availabilityID).Return(&models.Availability{
+		ID:      availabilityID,
+		UserID:  uuid.New(),
+		EventID: uuid.New(),
+	}, nil)
+	mockAvailabilityRepo.On("Delete", mock.Anything, availabilityID, 0).Return(assert.AnError)
 
 	// Execute the method
-	err := availabilityService.DeleteAvailability(context.Background(), availabilityID)
+	err := availabilityService.DeleteAvailability(context.Background(), availabilityID, models.Precondition{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -414,6 +452,9 @@ func TestGetUserEventAvailability(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
@@ -460,6 +501,9 @@ func TestGetUserEventAvailabilityError(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
@@ -489,6 +533,9 @@ func TestGetEventAvailability(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
@@ -511,10 +558,11 @@ func TestGetEventAvailability(t *testing.T) {
 	}
 
 	// Set expectations
-	mockAvailabilityRepo.On("GetByEventID", mock.Anything, eventID).Return(expectedAvailabilities, nil)
+	page := models.PageRequest{Limit: 50}
+	mockAvailabilityRepo.On("ListByEventID", mock.Anything, eventID, page).Return(expectedAvailabilities, models.PageInfo{Total: 2, Limit: 50}, nil)
 
 	// Execute the method
-	availabilities, err := availabilityService.GetEventAvailability(context.Background(), eventID)
+	availabilities, _, err := availabilityService.GetEventAvailability(context.Background(), eventID, page)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -534,16 +582,20 @@ func TestGetEventAvailabilityError(t *testing.T) {
 		mockAvailabilityRepo,
 		mockEventRepo,
 		mockUserRepo,
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
 	)
 
 	// Prepare test data
 	eventID := uuid.New()
 
 	// Set expectations
-	mockAvailabilityRepo.On("GetByEventID", mock.Anything, eventID).Return(nil, assert.AnError)
+	page := models.PageRequest{Limit: 50}
+	mockAvailabilityRepo.On("ListByEventID", mock.Anything, eventID, page).Return(nil, models.PageInfo{}, assert.AnError)
 
 	// Execute the method
-	availabilities, err := availabilityService.GetEventAvailability(context.Background(), eventID)
+	availabilities, _, err := availabilityService.GetEventAvailability(context.Background(), eventID, page)
 
 	// Assertions
 	assert.Error(t, err)
@@ -552,3 +604,96 @@ func TestGetEventAvailabilityError(t *testing.T) {
 	// Verify mock expectations
 	mockAvailabilityRepo.AssertExpectations(t)
 }
+
+func TestRestoreAvailability(t *testing.T) {
+	// Setup mocks
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockEventRepo := new(MockEventRepository)
+	outboxRepo := &FakeOutboxRepository{}
+	availabilityService := service.NewAvailabilityService(
+		mockAvailabilityRepo,
+		mockEventRepo,
+		new(MockUserRepository),
+		FakeTransactor{},
+		outboxRepo,
+		&FakeAuditRepository{},
+	)
+
+	// Prepare test data
+	availability := &models.Availability{ID: uuid.New(), UserID: uuid.New(), EventID: uuid.New()}
+
+	// Set expectations
+	mockAvailabilityRepo.On("GetDeletedByID", mock.Anything, availability.ID).Return(availability, nil)
+	mockEventRepo.On("GetByID", mock.Anything, availability.EventID).Return(&models.Event{ID: availability.EventID}, nil)
+	mockAvailabilityRepo.On("Restore", mock.Anything, availability.ID).Return(nil)
+	mockAvailabilityRepo.On("GetByID", mock.Anything, availability.ID).Return(availability, nil)
+
+	// Execute the method
+	restored, err := availabilityService.RestoreAvailability(context.Background(), availability.ID)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, availability, restored)
+	assert.Equal(t, []string{models.TopicAvailabilityRestored}, outboxRepo.Topics())
+
+	// Verify mock expectations
+	mockAvailabilityRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+}
+
+func TestRestoreAvailabilityNotDeleted(t *testing.T) {
+	// Setup mocks
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	availabilityService := service.NewAvailabilityService(
+		mockAvailabilityRepo,
+		new(MockEventRepository),
+		new(MockUserRepository),
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
+	)
+
+	// Prepare test data
+	availabilityID := uuid.New()
+
+	// Set expectations
+	mockAvailabilityRepo.On("GetDeletedByID", mock.Anything, availabilityID).Return(nil, errors.ErrAvailabilityNotFound)
+
+	// Execute the method
+	_, err := availabilityService.RestoreAvailability(context.Background(), availabilityID)
+
+	// Assertions
+	assert.ErrorIs(t, err, errors.ErrAvailabilityNotFound)
+	mockAvailabilityRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
+
+func TestUpdateAvailabilityComparesIfMatchWithTheUserList(t *testing.T) {
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	availabilityService := service.NewAvailabilityService(
+		mockAvailabilityRepo,
+		new(MockEventRepository),
+		new(MockUserRepository),
+		FakeTransactor{},
+		&FakeOutboxRepository{},
+		&FakeAuditRepository{},
+	)
+
+	eventID := uuid.New()
+	userID := uuid.New()
+	existing := &models.Availability{ID: uuid.New(), UserID: userID, EventID: eventID, Version: 1}
+	listed := models.AvailabilityListETag([]*models.Availability{existing})
+
+	// Another client changed the list since it was read
+	existing.Version = 2
+	mockAvailabilityRepo.On("GetByUserAndEvent", mock.Anything, userID, eventID).Return([]*models.Availability{existing}, nil)
+
+	req := &models.AvailabilityRequest{
+		UserID:    userID,
+		StartTime: time.Date(2025, 1, 15, 14, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		EndTime:   time.Date(2025, 1, 15, 16, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	}
+	_, err := availabilityService.UpdateAvailability(context.Background(), eventID, req, models.Precondition{IfMatch: []string{listed}})
+
+	assert.ErrorIs(t, err, errors.ErrStaleVersion)
+	mockAvailabilityRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,199 @@
+// internal/service/deadline_scheduler.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DeadlineSchedulerConfig configures how passed response deadlines are evaluated
+type DeadlineSchedulerConfig struct {
+	BatchSize int // Maximum number of events decided per run
+}
+
+// DeadlineScheduler applies the auto-finalize policy of active events whose
+// response deadline has passed. Events are claimed with row locks, so several
+// replicas can run it at once, and each is decided in its own savepoint, so
+// one that fails does not hold up the others.
+type DeadlineScheduler struct {
+	eventRepo             repository.EventRepository
+	decisionRepo          repository.DecisionRepository
+	recommendationService *RecommendationService
+	timeslotService       *TimeSlotService
+	transactor            repository.Transactor
+	outboxRepo            repository.OutboxRepository
+	auditRepo             repository.AuditRepository
+	cfg                   DeadlineSchedulerConfig
+}
+
+// NewDeadlineScheduler creates a new DeadlineScheduler
+func NewDeadlineScheduler(
+	eventRepo repository.EventRepository,
+	decisionRepo repository.DecisionRepository,
+	recommendationService *RecommendationService,
+	timeslotService *TimeSlotService,
+	transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository,
+	auditRepo repository.AuditRepository,
+	cfg DeadlineSchedulerConfig,
+) *DeadlineScheduler {
+	return &DeadlineScheduler{
+		eventRepo:             eventRepo,
+		decisionRepo:          decisionRepo,
+		recommendationService: recommendationService,
+		timeslotService:       timeslotService,
+		transactor:            transactor,
+		outboxRepo:            outboxRepo,
+		auditRepo:             auditRepo,
+		cfg:                   cfg,
+	}
+}
+
+// ProcessDue decides up to BatchSize events of any organization whose response
+// deadline is at or before now and returns the decisions made. An event that
+// cannot be decided is logged and left for the next run.
+func (s *DeadlineScheduler) ProcessDue(ctx context.Context, now time.Time) ([]*models.EventDecision, error) {
+	ctx, span := startSpan(tenant.System(ctx), "DeadlineScheduler.ProcessDue")
+	defer span.End()
+
+	var decisions []*models.EventDecision
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		events, err := s.eventRepo.ClaimPastDeadline(ctx, now, s.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			var decision *models.EventDecision
+			err := s.transactor.WithinSavepoint(ctx, func(ctx context.Context) error {
+				var err error
+				decision, err = s.decide(ctx, event, now)
+				return err
+			})
+			if err != nil {
+				// Shutting down is not the event's fault
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				log.Printf("deciding event %s past its deadline failed: %v", event.ID, err)
+				continue
+			}
+			decisions = append(decisions, decision)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("decisions.count", len(decisions)))
+
+	return decisions, nil
+}
+
+// ListDecisions returns the deadline decisions made for an event, oldest first
+func (s *DeadlineScheduler) ListDecisions(ctx context.Context, eventID uuid.UUID) ([]*models.EventDecision, error) {
+	ctx, span := startSpan(ctx, "DeadlineScheduler.ListDecisions", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return s.decisionRepo.ListByEventID(ctx, eventID)
+}
+
+// decide finalizes a claimed event with its top recommendation when the policy
+// allows it and the recommendation has quorum, as RecommendationService judges
+// it, and otherwise hands the decision to the organizer
+func (s *DeadlineScheduler) decide(ctx context.Context, event *models.Event, now time.Time) (*models.EventDecision, error) {
+	ctx, span := startSpan(ctx, "DeadlineScheduler.decide", attribute.String("event.id", event.ID.String()))
+	defer span.End()
+
+	// Act for the event's organization, so resources are booked and messages
+	// recorded within it
+	ctx = tenant.WithOrganization(ctx, event.OrganizationID)
+
+	recommendations, err := s.recommendationService.GetRecommendations(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &models.EventDecision{
+		EventID:   event.ID,
+		Policy:    event.AutoFinalize,
+		Outcome:   models.DecisionOrganizerNotified,
+		DecidedAt: now,
+	}
+	top := topRecommendation(recommendations.Recommendations)
+	if top != nil {
+		decision.TimeSlotID = &top.TimeSlot.ID
+		decision.Attendees = top.Score
+	}
+
+	switch {
+	case top == nil:
+		decision.Reason = "no proposed time slot fits the event duration"
+	case event.AutoFinalize != models.AutoFinalizeTopRecommendation:
+		decision.Reason = "automatic finalization is turned off"
+	case len(top.MissingGroups) > 0:
+		decision.Reason = fmt.Sprintf("the top recommendation has %d of the %d attendees required but none or too few from %s",
+			top.Score, recommendations.Quorum, strings.Join(top.MissingGroups, ", "))
+	case !top.MeetsQuorum:
+		decision.Reason = fmt.Sprintf("the top recommendation has %d of the %d attendees required", top.Score, recommendations.Quorum)
+	default:
+		decision.Outcome = models.DecisionFinalized
+		decision.Reason = fmt.Sprintf("the top recommendation has %d of the %d attendees required", top.Score, recommendations.Quorum)
+	}
+
+	if decision.Outcome == models.DecisionFinalized {
+		// Joins the surrounding transaction, so the finalization and the
+		// decision commit together. A finalization that fails rolls back to
+		// its savepoint and leaves the decision to the organizer.
+		var finalized *models.Event
+		err := s.transactor.WithinSavepoint(ctx, func(ctx context.Context) error {
+			var err error
+			finalized, err = s.timeslotService.FinalizeTimeSlot(ctx, top.TimeSlot.ID)
+			return err
+		})
+		if err != nil {
+			decision.Outcome = models.DecisionOrganizerNotified
+			decision.Reason = fmt.Sprintf("finalizing the top recommendation failed: %v", err)
+		} else {
+			event = finalized
+		}
+	}
+
+	before := *event
+	event.DeadlineProcessedAt = &now
+	if err := s.eventRepo.Update(ctx, event); err != nil {
+		return nil, err
+	}
+	if err := s.decisionRepo.Create(ctx, decision); err != nil {
+		return nil, err
+	}
+	// Recorded as a system change, since no caller is behind the decision
+	err = recordAudit(ctx, s.auditRepo, models.TopicEventDeadlinePassed, models.AuditEntityEvent, event.ID, &event.ID, &before, event)
+	if err != nil {
+		return nil, err
+	}
+	if decision.Outcome == models.DecisionOrganizerNotified {
+		err := recordLifecycle(ctx, s.outboxRepo, models.TopicEventDeadlinePassed, event.ID, &event.CreatorID, map[string]interface{}{
+			"event":    event,
+			"decision": decision,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	span.SetAttributes(attribute.String("decision.outcome", string(decision.Outcome)))
+
+	return decision, nil
+}
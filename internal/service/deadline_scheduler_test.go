@@ -0,0 +1,222 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeDecisionRepository keeps decisions in memory
+type FakeDecisionRepository struct {
+	Decisions []*models.EventDecision
+}
+
+func (f *FakeDecisionRepository) Create(ctx context.Context, decision *models.EventDecision) error {
+	if decision.ID == uuid.Nil {
+		decision.ID = uuid.New()
+	}
+	f.Decisions = append(f.Decisions, decision)
+	return nil
+}
+
+func (f *FakeDecisionRepository) ListByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.EventDecision, error) {
+	var decisions []*models.EventDecision
+	for _, decision := range f.Decisions {
+		if decision.EventID == eventID {
+			decisions = append(decisions, decision)
+		}
+	}
+	return decisions, nil
+}
+
+// expectPastDeadline sets the mocks up with an active event past its deadline
+// and two slots: one user can make the early slot and two users can make the
+// late one. It returns the event and the late slot.
+func expectPastDeadline(eventRepo *MockEventRepository, timeslotRepo *MockTimeSlotRepository, availabilityRepo *MockAvailabilityRepository, userRepo *MockUserRepository, policy models.AutoFinalizePolicy, quorum int) (*models.Event, *models.TimeSlot) {
+	deadline := time.Date(2025, 1, 10, 17, 0, 0, 0, time.UTC)
+	event := &models.Event{
+		ID:               uuid.New(),
+		Title:            "Planning",
+		CreatorID:        uuid.New(),
+		Duration:         60,
+		Status:           models.EventStatusActive,
+		ResponseDeadline: &deadline,
+		AutoFinalize:     policy,
+		Quorum:           quorum,
+	}
+	earlyStart := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	lateStart := time.Date(2025, 1, 15, 14, 0, 0, 0, time.UTC)
+	early := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: earlyStart, EndTime: earlyStart.Add(time.Hour)}
+	late := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: lateStart, EndTime: lateStart.Add(time.Hour)}
+
+	alice := &models.User{ID: uuid.New(), Name: "Alice", Email: "alice@example.com"}
+	bob := &models.User{ID: uuid.New(), Name: "Bob", Email: "bob@example.com"}
+	availabilities := []*models.Availability{
+		{ID: uuid.New(), EventID: event.ID, UserID: alice.ID, StartTime: earlyStart, EndTime: lateStart.Add(time.Hour)},
+		{ID: uuid.New(), EventID: event.ID, UserID: bob.ID, StartTime: lateStart, EndTime: lateStart.Add(time.Hour)},
+	}
+
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	timeslotRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.TimeSlot{early, late}, nil)
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return(availabilities, nil)
+	userRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*models.User{alice, bob}, nil)
+
+	return event, late
+}
+
+func TestDeadlineSchedulerFinalizesTopRecommendationWithQuorum(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	mockTimeSlotRepo := new(MockTimeSlotRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockUserRepo := new(MockUserRepository)
+	decisionRepo := &FakeDecisionRepository{}
+	outboxRepo := &FakeOutboxRepository{}
+	auditRepo := &FakeAuditRepository{}
+	recommendationService := service.NewRecommendationService(mockEventRepo, mockTimeSlotRepo, mockAvailabilityRepo, mockUserRepo, &FakeGuestRepository{}, &FakeVoteRepository{}, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+	timeslotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, outboxRepo, &FakeAuditRepository{}, emptyResourceService())
+	scheduler := service.NewDeadlineScheduler(mockEventRepo, decisionRepo, recommendationService, timeslotService, FakeTransactor{}, outboxRepo, auditRepo, service.DeadlineSchedulerConfig{BatchSize: 10})
+
+	event, late := expectPastDeadline(mockEventRepo, mockTimeSlotRepo, mockAvailabilityRepo, mockUserRepo, models.AutoFinalizeTopRecommendation, 2)
+	event.OrganizationID = uuid.New()
+	mockEventRepo.On("ClaimPastDeadline", mock.Anything, mock.Anything, 10).Return([]*models.Event{event}, nil)
+	mockEventRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockTimeSlotRepo.On("GetByID", mock.Anything, late.ID).Return(late, nil)
+	now := event.ResponseDeadline.Add(time.Minute)
+
+	decisions, err := scheduler.ProcessDue(context.Background(), now)
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+
+	decision := decisions[0]
+	assert.Equal(t, models.DecisionFinalized, decision.Outcome)
+	assert.Equal(t, late.ID, *decision.TimeSlotID)
+	assert.Equal(t, 2, decision.Attendees)
+	assert.Equal(t, models.EventStatusFinalized, event.Status)
+	assert.Equal(t, late.ID, *event.FinalTimeSlotID)
+	require.NotNil(t, event.DeadlineProcessedAt)
+	assert.Equal(t, now, *event.DeadlineProcessedAt)
+	assert.Equal(t, []string{models.TopicTimeSlotFinalized}, outboxRepo.Topics())
+	assert.Equal(t, decisions, decisionRepo.Decisions)
+
+	require.Len(t, auditRepo.Entries, 1)
+	entry := auditRepo.Entries[0]
+	assert.Equal(t, models.TopicEventDeadlinePassed, entry.Action)
+	assert.Equal(t, event.ID, entry.EntityID)
+	assert.Equal(t, event.OrganizationID, entry.OrganizationID)
+	assert.Equal(t, models.AuditActorSystem, entry.ActorType)
+	assert.Nil(t, entry.ActorID)
+	assert.NotContains(t, string(entry.Before), `"deadline_processed_at"`)
+	assert.Contains(t, string(entry.After), `"deadline_processed_at":"`)
+}
+
+func TestDeadlineSchedulerNotifiesOrganizerBelowQuorum(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	mockTimeSlotRepo := new(MockTimeSlotRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockUserRepo := new(MockUserRepository)
+	outboxRepo := &FakeOutboxRepository{}
+	recommendationService := service.NewRecommendationService(mockEventRepo, mockTimeSlotRepo, mockAvailabilityRepo, mockUserRepo, &FakeGuestRepository{}, &FakeVoteRepository{}, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+	timeslotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, outboxRepo, &FakeAuditRepository{}, emptyResourceService())
+	scheduler := service.NewDeadlineScheduler(mockEventRepo, &FakeDecisionRepository{}, recommendationService, timeslotService, FakeTransactor{}, outboxRepo, &FakeAuditRepository{}, service.DeadlineSchedulerConfig{BatchSize: 10})
+
+	event, _ := expectPastDeadline(mockEventRepo, mockTimeSlotRepo, mockAvailabilityRepo, mockUserRepo, models.AutoFinalizeTopRecommendation, 3)
+	mockEventRepo.On("ClaimPastDeadline", mock.Anything, mock.Anything, 10).Return([]*models.Event{event}, nil)
+	mockEventRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	decisions, err := scheduler.ProcessDue(context.Background(), event.ResponseDeadline.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+
+	decision := decisions[0]
+	assert.Equal(t, models.DecisionOrganizerNotified, decision.Outcome)
+	assert.Equal(t, "the top recommendation has 2 of the 3 attendees required", decision.Reason)
+	assert.Equal(t, models.EventStatusActive, event.Status)
+	assert.NotNil(t, event.DeadlineProcessedAt)
+	assert.Equal(t, []string{models.TopicEventDeadlinePassed}, outboxRepo.Topics())
+}
+
+func TestDeadlineSchedulerLeavesDecisionToOrganizerWithoutPolicy(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	mockTimeSlotRepo := new(MockTimeSlotRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockUserRepo := new(MockUserRepository)
+	outboxRepo := &FakeOutboxRepository{}
+	recommendationService := service.NewRecommendationService(mockEventRepo, mockTimeSlotRepo, mockAvailabilityRepo, mockUserRepo, &FakeGuestRepository{}, &FakeVoteRepository{}, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+	timeslotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, outboxRepo, &FakeAuditRepository{}, emptyResourceService())
+	scheduler := service.NewDeadlineScheduler(mockEventRepo, &FakeDecisionRepository{}, recommendationService, timeslotService, FakeTransactor{}, outboxRepo, &FakeAuditRepository{}, service.DeadlineSchedulerConfig{BatchSize: 10})
+
+	event, _ := expectPastDeadline(mockEventRepo, mockTimeSlotRepo, mockAvailabilityRepo, mockUserRepo, models.AutoFinalizeNone, 1)
+	mockEventRepo.On("ClaimPastDeadline", mock.Anything, mock.Anything, 10).Return([]*models.Event{event}, nil)
+	mockEventRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	decisions, err := scheduler.ProcessDue(context.Background(), event.ResponseDeadline.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+
+	assert.Equal(t, models.DecisionOrganizerNotified, decisions[0].Outcome)
+	assert.Equal(t, "automatic finalization is turned off", decisions[0].Reason)
+	assert.Nil(t, event.FinalTimeSlotID)
+	assert.Equal(t, []string{models.TopicEventDeadlinePassed}, outboxRepo.Topics())
+}
+
+func TestDeadlineSchedulerNotifiesOrganizerWhenFinalizingFails(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	mockTimeSlotRepo := new(MockTimeSlotRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockUserRepo := new(MockUserRepository)
+	outboxRepo := &FakeOutboxRepository{}
+	recommendationService := service.NewRecommendationService(mockEventRepo, mockTimeSlotRepo, mockAvailabilityRepo, mockUserRepo, &FakeGuestRepository{}, &FakeVoteRepository{}, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+	timeslotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, outboxRepo, &FakeAuditRepository{}, emptyResourceService())
+	scheduler := service.NewDeadlineScheduler(mockEventRepo, &FakeDecisionRepository{}, recommendationService, timeslotService, FakeTransactor{}, outboxRepo, &FakeAuditRepository{}, service.DeadlineSchedulerConfig{BatchSize: 10})
+
+	event, late := expectPastDeadline(mockEventRepo, mockTimeSlotRepo, mockAvailabilityRepo, mockUserRepo, models.AutoFinalizeTopRecommendation, 2)
+	mockEventRepo.On("ClaimPastDeadline", mock.Anything, mock.Anything, 10).Return([]*models.Event{event}, nil)
+	mockEventRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockTimeSlotRepo.On("GetByID", mock.Anything, late.ID).Return(nil, assert.AnError)
+	now := event.ResponseDeadline.Add(time.Minute)
+
+	decisions, err := scheduler.ProcessDue(context.Background(), now)
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+
+	decision := decisions[0]
+	assert.Equal(t, models.DecisionOrganizerNotified, decision.Outcome)
+	assert.Equal(t, "finalizing the top recommendation failed: "+assert.AnError.Error(), decision.Reason)
+	assert.Equal(t, models.EventStatusActive, event.Status)
+	require.NotNil(t, event.DeadlineProcessedAt)
+	assert.Equal(t, now, *event.DeadlineProcessedAt)
+	assert.Equal(t, []string{models.TopicEventDeadlinePassed}, outboxRepo.Topics())
+}
+
+func TestDeadlineSchedulerDecidesTheRestOfTheBatchWhenOneEventFails(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	mockTimeSlotRepo := new(MockTimeSlotRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockUserRepo := new(MockUserRepository)
+	outboxRepo := &FakeOutboxRepository{}
+	recommendationService := service.NewRecommendationService(mockEventRepo, mockTimeSlotRepo, mockAvailabilityRepo, mockUserRepo, &FakeGuestRepository{}, &FakeVoteRepository{}, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+	timeslotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, outboxRepo, &FakeAuditRepository{}, emptyResourceService())
+	scheduler := service.NewDeadlineScheduler(mockEventRepo, &FakeDecisionRepository{}, recommendationService, timeslotService, FakeTransactor{}, outboxRepo, &FakeAuditRepository{}, service.DeadlineSchedulerConfig{BatchSize: 10})
+
+	event, _ := expectPastDeadline(mockEventRepo, mockTimeSlotRepo, mockAvailabilityRepo, mockUserRepo, models.AutoFinalizeNone, 1)
+	broken := &models.Event{ID: uuid.New(), Status: models.EventStatusActive, ResponseDeadline: event.ResponseDeadline}
+	mockEventRepo.On("GetByID", mock.Anything, broken.ID).Return(nil, assert.AnError)
+	mockEventRepo.On("ClaimPastDeadline", mock.Anything, mock.Anything, 10).Return([]*models.Event{broken, event}, nil)
+	mockEventRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	decisions, err := scheduler.ProcessDue(context.Background(), event.ResponseDeadline.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+
+	assert.Equal(t, event.ID, decisions[0].EventID)
+	assert.NotNil(t, event.DeadlineProcessedAt)
+	assert.Nil(t, broken.DeadlineProcessedAt)
+}
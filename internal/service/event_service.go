@@ -3,75 +3,417 @@ package service
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
 	"github.com/npkanaka/meeting-scheduler/internal/models"
 	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// rebuildBatchSize is how many events RebuildSearchIndex reads at a time
+const rebuildBatchSize = 500
+
 // EventService handles event business logic
 type EventService struct {
-	eventRepo repository.EventRepository
+	eventRepo       repository.EventRepository
+	timeslotRepo    repository.TimeSlotRepository
+	transactor      repository.Transactor
+	outboxRepo      repository.OutboxRepository
+	auditRepo       repository.AuditRepository
+	searchRepo      repository.SearchRepository
+	resourceService *ResourceService
 }
 
 // NewEventService creates a new EventService
-func NewEventService(eventRepo repository.EventRepository) *EventService {
+func NewEventService(
+	eventRepo repository.EventRepository,
+	timeslotRepo repository.TimeSlotRepository,
+	transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository,
+	auditRepo repository.AuditRepository,
+	searchRepo repository.SearchRepository,
+	resourceService *ResourceService,
+) *EventService {
 	return &EventService{
-		eventRepo: eventRepo,
+		eventRepo:       eventRepo,
+		timeslotRepo:    timeslotRepo,
+		transactor:      transactor,
+		outboxRepo:      outboxRepo,
+		auditRepo:       auditRepo,
+		searchRepo:      searchRepo,
+		resourceService: resourceService,
 	}
 }
 
 // CreateEvent creates a new event
 func (s *EventService) CreateEvent(ctx context.Context, req *models.CreateEventRequest, creatorID uuid.UUID) (*models.Event, error) {
+	ctx, span := startSpan(ctx, "EventService.CreateEvent", attribute.String("creator.id", creatorID.String()))
+	defer span.End()
+
+	if err := validateQuorum(req); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	event := &models.Event{
-		Title:       req.Title,
-		Description: req.Description,
-		CreatorID:   creatorID,
-		Duration:    req.Duration,
-		Status:      models.EventStatusDraft,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		Title:               req.Title,
+		Description:         req.Description,
+		CreatorID:           creatorID,
+		Duration:            req.Duration,
+		Status:              models.EventStatusDraft,
+		ResponseDeadline:    req.ResponseDeadline,
+		AutoFinalize:        autoFinalizePolicy(req.AutoFinalize),
+		Quorum:              quorum(req.Quorum),
+		QuorumPercent:       req.QuorumPercent,
+		RequiredGroups:      requiredGroups(req.RequiredGroups),
+		Scoring:             scoringMode(req.Scoring),
+		ResourceRequirement: resourceRequirement(req.ResourceRequirement),
+		BufferBefore:        req.BufferBefore,
+		BufferAfter:         req.BufferAfter,
+		CreatedAt:           now,
+		UpdatedAt:           now,
 	}
 
-	if err := s.eventRepo.Create(ctx, event); err != nil {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.eventRepo.Create(ctx, event); err != nil {
+			return err
+		}
+		if err := s.searchRepo.Index(ctx, event); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, models.TopicEventCreated, nil, event)
+	})
+	if err != nil {
 		return nil, err
 	}
+	span.SetAttributes(attribute.String("event.id", event.ID.String()))
 
 	return event, nil
 }
 
 // GetEvent retrieves an event by ID
 func (s *EventService) GetEvent(ctx context.Context, id uuid.UUID) (*models.Event, error) {
+	ctx, span := startSpan(ctx, "EventService.GetEvent", attribute.String("event.id", id.String()))
+	defer span.End()
+
 	return s.eventRepo.GetByID(ctx, id)
 }
 
-// UpdateEvent updates an existing event
-func (s *EventService) UpdateEvent(ctx context.Context, id uuid.UUID, req *models.CreateEventRequest) (*models.Event, error) {
+// UpdateEvent updates an existing event that meets the precondition
+func (s *EventService) UpdateEvent(ctx context.Context, id uuid.UUID, req *models.CreateEventRequest, pre models.Precondition) (*models.Event, error) {
+	ctx, span := startSpan(ctx, "EventService.UpdateEvent", attribute.String("event.id", id.String()))
+	defer span.End()
+
+	if err := validateQuorum(req); err != nil {
+		return nil, err
+	}
+
 	event, err := s.eventRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if !pre.Allows(event.ETag()) {
+		return nil, errors.ErrStaleVersion
+	}
+	before := *event
 
 	event.Title = req.Title
 	event.Description = req.Description
 	event.Duration = req.Duration
+	if !sameTime(event.ResponseDeadline, req.ResponseDeadline) {
+		// A moved deadline has to be evaluated again
+		event.DeadlineProcessedAt = nil
+	}
+	event.ResponseDeadline = req.ResponseDeadline
+	event.AutoFinalize = autoFinalizePolicy(req.AutoFinalize)
+	event.Quorum = quorum(req.Quorum)
+	event.QuorumPercent = req.QuorumPercent
+	event.RequiredGroups = requiredGroups(req.RequiredGroups)
+	event.Scoring = scoringMode(req.Scoring)
+	event.ResourceRequirement = resourceRequirement(req.ResourceRequirement)
+	event.BufferBefore = req.BufferBefore
+	event.BufferAfter = req.BufferAfter
 	event.UpdatedAt = time.Now()
 
-	if err := s.eventRepo.Update(ctx, event); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.eventRepo.Update(ctx, event); err != nil {
+			return err
+		}
+		if err := s.searchRepo.Index(ctx, event); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, models.TopicEventUpdated, &before, event)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return event, nil
 }
 
-// DeleteEvent removes an event
-func (s *EventService) DeleteEvent(ctx context.Context, id uuid.UUID) error {
-	return s.eventRepo.Delete(ctx, id)
+// PublishEvent moves a draft event to active so participants can respond
+func (s *EventService) PublishEvent(ctx context.Context, id uuid.UUID) (*models.Event, error) {
+	ctx, span := startSpan(ctx, "EventService.PublishEvent", attribute.String("event.id", id.String()))
+	defer span.End()
+
+	event, err := s.eventRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status != models.EventStatusDraft {
+		return nil, errors.ErrInvalidStatusTransition
+	}
+	before := *event
+
+	event.Status = models.EventStatusActive
+	event.UpdatedAt = time.Now()
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.eventRepo.Update(ctx, event); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, models.TopicEventPublished, &before, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
 }
 
-// ListEvents returns a paginated list of events
-func (s *EventService) ListEvents(ctx context.Context, limit, offset int) ([]*models.Event, error) {
-	return s.eventRepo.List(ctx, limit, offset)
+// DeleteEvent deletes an event that meets the precondition with its time slots
+// and availability and frees its resource booking. It can be restored until
+// the trash retention has passed.
+func (s *EventService) DeleteEvent(ctx context.Context, id uuid.UUID, pre models.Precondition) error {
+	ctx, span := startSpan(ctx, "EventService.DeleteEvent", attribute.String("event.id", id.String()))
+	defer span.End()
+
+	event, err := s.eventRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !pre.Allows(event.ETag()) {
+		return errors.ErrStaleVersion
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.resourceService.ReleaseForEvent(ctx, id); err != nil {
+			return err
+		}
+		if err := s.eventRepo.Delete(ctx, id, event.Version); err != nil {
+			return err
+		}
+		if err := s.searchRepo.Remove(ctx, id); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, models.TopicEventDeleted, event, nil)
+	})
+}
+
+// RestoreEvent brings back a deleted event with the time slots and
+// availability that were deleted together with it. A finalized event that
+// requires a resource gets one booked again, and is not restored when none
+// is free.
+func (s *EventService) RestoreEvent(ctx context.Context, id uuid.UUID) (*models.Event, error) {
+	ctx, span := startSpan(ctx, "EventService.RestoreEvent", attribute.String("event.id", id.String()))
+	defer span.End()
+
+	before, err := s.eventRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var event *models.Event
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.eventRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		if event, err = s.eventRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+		if err := s.rebook(ctx, event); err != nil {
+			return err
+		}
+		if err := s.searchRepo.Index(ctx, event); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, models.TopicEventRestored, before, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ListEvents returns a page of the events matching filter
+func (s *EventService) ListEvents(ctx context.Context, filter models.EventFilter) ([]*models.Event, models.PageInfo, error) {
+	ctx, span := startSpan(ctx, "EventService.ListEvents",
+		attribute.Int("page.limit", filter.Page.Limit),
+		attribute.String("page.sort", filter.Page.Sort),
+	)
+	defer span.End()
+
+	events, info, err := s.eventRepo.List(ctx, filter)
+	span.SetAttributes(attribute.Int("events.count", len(events)), attribute.Int64("events.total", info.Total))
+	return events, info, err
+}
+
+// SearchEvents returns the events matching a full-text query, best match first
+func (s *EventService) SearchEvents(ctx context.Context, query models.SearchQuery) ([]*models.SearchResult, error) {
+	ctx, span := startSpan(ctx, "EventService.SearchEvents", attribute.Int("search.limit", query.Limit))
+	defer span.End()
+
+	results, err := s.searchRepo.Search(ctx, query)
+	span.SetAttributes(attribute.Int("search.results", len(results)))
+	return results, err
+}
+
+// RebuildSearchIndex indexes every event, across organizations when ctx acts
+// for the system. It fills an index kept outside the database, such as the
+// memory index after a restart.
+func (s *EventService) RebuildSearchIndex(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "EventService.RebuildSearchIndex")
+	defer span.End()
+
+	indexed := 0
+	page := models.PageRequest{Limit: rebuildBatchSize}
+	for {
+		events, info, err := s.eventRepo.List(ctx, models.EventFilter{Page: page})
+		if err != nil {
+			return indexed, err
+		}
+		for _, event := range events {
+			if err := s.searchRepo.Index(ctx, event); err != nil {
+				return indexed, err
+			}
+			indexed++
+		}
+		if info.NextCursor == "" {
+			break
+		}
+		page.Cursor = info.NextCursor
+	}
+	span.SetAttributes(attribute.Int("search.indexed", indexed))
+	return indexed, nil
+}
+
+// rebook books a resource for the final time slot of a restored event that
+// requires one, as finalizing it did
+func (s *EventService) rebook(ctx context.Context, event *models.Event) error {
+	if event.Status != models.EventStatusFinalized || event.ResourceRequirement == nil || event.FinalTimeSlotID == nil {
+		return nil
+	}
+	slot, err := s.timeslotRepo.GetByID(ctx, *event.FinalTimeSlotID)
+	if err != nil {
+		return err
+	}
+	end := slot.StartTime.Add(time.Duration(event.Duration) * time.Minute)
+	booking, err := s.resourceService.BookForEvent(ctx, event, slot, slot.StartTime, end)
+	if err != nil {
+		return err
+	}
+	event.ResourceID = &booking.ResourceID
+	return s.eventRepo.Update(ctx, event)
+}
+
+// recordEvent writes an event lifecycle message to the outbox and the change
+// to the audit trail. before is nil for a new event and after is nil for a
+// deleted one.
+func (s *EventService) recordEvent(ctx context.Context, topic string, before, after *models.Event) error {
+	event := after
+	if event == nil {
+		event = before
+	}
+	err := recordLifecycle(ctx, s.outboxRepo, topic, event.ID, &event.CreatorID, map[string]interface{}{
+		"event": event,
+	})
+	if err != nil {
+		return err
+	}
+	return recordAudit(ctx, s.auditRepo, topic, models.AuditEntityEvent, event.ID, &event.ID, before, after)
+}
+
+// autoFinalizePolicy defaults an empty policy to none
+func autoFinalizePolicy(policy models.AutoFinalizePolicy) models.AutoFinalizePolicy {
+	if policy == "" {
+		return models.AutoFinalizeNone
+	}
+	return policy
+}
+
+// quorum defaults an unset quorum to a single attendee
+func quorum(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// validateQuorum checks that an event asks for a fixed or a relative quorum,
+// not both, and that every required group can be satisfied
+func validateQuorum(req *models.CreateEventRequest) error {
+	if req.Quorum > 0 && req.QuorumPercent > 0 {
+		return errors.ErrInvalidQuorum
+	}
+	for _, group := range requiredGroups(req.RequiredGroups) {
+		if group.Min > len(group.UserIDs) {
+			return errors.ErrInvalidQuorum
+		}
+	}
+	return nil
+}
+
+// requiredGroups copies required groups with duplicate members dropped and
+// an unset minimum defaulted to a single attendee
+func requiredGroups(groups []models.RequiredGroup) []models.RequiredGroup {
+	if len(groups) == 0 {
+		return nil
+	}
+	normalized := make([]models.RequiredGroup, len(groups))
+	for i, group := range groups {
+		seen := make(map[uuid.UUID]bool, len(group.UserIDs))
+		var userIDs []uuid.UUID
+		for _, id := range group.UserIDs {
+			if !seen[id] {
+				seen[id] = true
+				userIDs = append(userIDs, id)
+			}
+		}
+		normalized[i] = models.RequiredGroup{Name: strings.TrimSpace(group.Name), UserIDs: userIDs, Min: quorum(group.Min)}
+	}
+	return normalized
+}
+
+// scoringMode defaults an empty scoring mode to availability
+func scoringMode(mode models.ScoringMode) models.ScoringMode {
+	if mode == "" {
+		return models.ScoringAvailability
+	}
+	return mode
+}
+
+// resourceRequirement copies a requirement with its attributes normalized the
+// way resource attributes are
+func resourceRequirement(req *models.ResourceRequirement) *models.ResourceRequirement {
+	if req == nil {
+		return nil
+	}
+	return &models.ResourceRequirement{
+		Kind:        req.Kind,
+		MinCapacity: req.MinCapacity,
+		Attributes:  normalizeAttributes(req.Attributes),
+	}
+}
+
+// sameTime reports whether two optional times are equal
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
 }
@@ -6,16 +6,21 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
 	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/search"
 	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestCreateEvent(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Prepare test data
 	creatorID := uuid.New()
@@ -55,7 +60,7 @@ func TestCreateEvent(t *testing.T) {
 func TestCreateEventRepositoryError(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Prepare test data
 	creatorID := uuid.New()
@@ -79,10 +84,31 @@ func TestCreateEventRepositoryError(t *testing.T) {
 	mockEventRepo.AssertExpectations(t)
 }
 
+func TestCreateEventRejectsConflictingQuorum(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
+	member := uuid.New()
+
+	// A fixed and a relative quorum cannot both be set
+	_, err := eventService.CreateEvent(context.Background(), &models.CreateEventRequest{
+		Title: "Planning", Duration: 60, Quorum: 3, QuorumPercent: 50,
+	}, uuid.New())
+	assert.ErrorIs(t, err, apperrors.ErrInvalidQuorum)
+
+	// Duplicate members do not make a group bigger
+	_, err = eventService.CreateEvent(context.Background(), &models.CreateEventRequest{
+		Title: "Planning", Duration: 60,
+		RequiredGroups: []models.RequiredGroup{{Name: "Platform", UserIDs: []uuid.UUID{member, member}, Min: 2}},
+	}, uuid.New())
+	assert.ErrorIs(t, err, apperrors.ErrInvalidQuorum)
+
+	mockEventRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestGetEvent(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Prepare test data
 	eventID := uuid.New()
@@ -115,7 +141,7 @@ func TestGetEvent(t *testing.T) {
 func TestGetEventNotFound(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Prepare test data
 	eventID := uuid.New()
@@ -137,7 +163,7 @@ func TestGetEventNotFound(t *testing.T) {
 func TestUpdateEvent(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Prepare test data
 	eventID := uuid.New()
@@ -164,7 +190,7 @@ func TestUpdateEvent(t *testing.T) {
 	})).Return(nil)
 
 	// Execute the method
-	updatedEvent, err := eventService.UpdateEvent(context.Background(), eventID, updateReq)
+	updatedEvent, err := eventService.UpdateEvent(context.Background(), eventID, updateReq, models.Precondition{})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -181,7 +207,7 @@ func TestUpdateEvent(t *testing.T) {
 func TestUpdateEventNotFound(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Prepare test data
 	eventID := uuid.New()
@@ -193,7 +219,7 @@ func TestUpdateEventNotFound(t *testing.T) {
 	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(nil, assert.AnError)
 
 	// Execute the method
-	updatedEvent, err := eventService.UpdateEvent(context.Background(), eventID, updateReq)
+	updatedEvent, err := eventService.UpdateEvent(context.Background(), eventID, updateReq, models.Precondition{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -206,7 +232,7 @@ func TestUpdateEventNotFound(t *testing.T) {
 func TestUpdateEventRepositoryError(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Prepare test data
 	eventID := uuid.New()
@@ -225,7 +251,7 @@ func TestUpdateEventRepositoryError(t *testing.T) {
 	mockEventRepo.On("Update", mock.Anything, mock.Anything).Return(assert.AnError)
 
 	// Execute the method
-	updatedEvent, err := eventService.UpdateEvent(context.Background(), eventID, updateReq)
+	updatedEvent, err := eventService.UpdateEvent(context.Background(), eventID, updateReq, models.Precondition{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -238,16 +264,17 @@ func TestUpdateEventRepositoryError(t *testing.T) {
 func TestDeleteEvent(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Prepare test data
 	eventID := uuid.New()
 
 	// Set expectations
-	mockEventRepo.On("Delete", mock.Anything, eventID).Return(nil)
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&models.Event{ID: eventID, CreatorID: uuid.New(), Version: 2}, nil)
+	mockEventRepo.On("Delete", mock.Anything, eventID, 2).Return(nil)
 
 	// Execute the method
-	err := eventService.DeleteEvent(context.Background(), eventID)
+	err := eventService.DeleteEvent(context.Background(), eventID, models.Precondition{})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -259,16 +286,17 @@ func TestDeleteEvent(t *testing.T) {
 func TestDeleteEventRepositoryError(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Prepare test data
 	eventID := uuid.New()
 
 	// Set expectations
-	mockEventRepo.On("Delete", mock.Anything, eventID).Return(assert.AnError)
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&models.Event{ID: eventID, CreatorID: uuid.New()}, nil)
+	mockEventRepo.On("Delete", mock.Anything, eventID, 0).Return(assert.AnError)
 
 	// Execute the method
-	err := eventService.DeleteEvent(context.Background(), eventID)
+	err := eventService.DeleteEvent(context.Background(), eventID, models.Precondition{})
 
 	// Assertions
 	assert.Error(t, err)
@@ -280,7 +308,7 @@ func TestDeleteEventRepositoryError(t *testing.T) {
 func TestListEvents(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Prepare test data
 	expectedEvents := []*models.Event{
@@ -295,15 +323,17 @@ func TestListEvents(t *testing.T) {
 	}
 
 	// Set expectations
-	mockEventRepo.On("List", mock.Anything, 10, 0).Return(expectedEvents, nil)
+	filter := models.EventFilter{Page: models.PageRequest{Limit: 10}}
+	mockEventRepo.On("List", mock.Anything, filter).Return(expectedEvents, models.PageInfo{Total: 2, Limit: 10}, nil)
 
 	// Execute the method
-	events, err := eventService.ListEvents(context.Background(), 10, 0)
+	events, page, err := eventService.ListEvents(context.Background(), filter)
 
 	// Assertions
 	assert.NoError(t, err)
 	assert.Len(t, events, 2)
 	assert.Equal(t, expectedEvents, events)
+	assert.Equal(t, int64(2), page.Total)
 
 	// Verify mock expectations
 	mockEventRepo.AssertExpectations(t)
@@ -312,13 +342,14 @@ func TestListEvents(t *testing.T) {
 func TestListEventsRepositoryError(t *testing.T) {
 	// Setup mock repository
 	mockEventRepo := new(MockEventRepository)
-	eventService := service.NewEventService(mockEventRepo)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
 
 	// Set expectations
-	mockEventRepo.On("List", mock.Anything, 10, 0).Return(nil, assert.AnError)
+	filter := models.EventFilter{Page: models.PageRequest{Limit: 10}}
+	mockEventRepo.On("List", mock.Anything, filter).Return(nil, models.PageInfo{}, assert.AnError)
 
 	// Execute the method
-	events, err := eventService.ListEvents(context.Background(), 10, 0)
+	events, _, err := eventService.ListEvents(context.Background(), filter)
 
 	// Assertions
 	assert.Error(t, err)
@@ -327,3 +358,125 @@ func TestListEventsRepositoryError(t *testing.T) {
 	// Verify mock expectations
 	mockEventRepo.AssertExpectations(t)
 }
+
+func TestRestoreEvent(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	outboxRepo := &FakeOutboxRepository{}
+	auditRepo := &FakeAuditRepository{}
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, outboxRepo, auditRepo, search.NewMemoryIndex(), emptyResourceService())
+
+	eventID := uuid.New()
+	deleted := &models.Event{ID: eventID, CreatorID: uuid.New(), DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+	restored := &models.Event{ID: eventID, CreatorID: deleted.CreatorID}
+	mockEventRepo.On("GetDeletedByID", mock.Anything, eventID).Return(deleted, nil)
+	mockEventRepo.On("Restore", mock.Anything, eventID).Return(nil)
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(restored, nil)
+
+	event, err := eventService.RestoreEvent(context.Background(), eventID)
+
+	require.NoError(t, err)
+	assert.Equal(t, restored, event)
+	assert.Equal(t, []string{models.TopicEventRestored}, outboxRepo.Topics())
+	require.Len(t, auditRepo.Entries, 1)
+	assert.Equal(t, models.TopicEventRestored, auditRepo.Entries[0].Action)
+	assert.Contains(t, string(auditRepo.Entries[0].Before), `"deleted_at":"`)
+	assert.Contains(t, string(auditRepo.Entries[0].After), `"deleted_at":null`)
+	mockEventRepo.AssertExpectations(t)
+}
+
+func TestRestoreEventThatIsNotDeleted(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
+
+	eventID := uuid.New()
+	mockEventRepo.On("GetDeletedByID", mock.Anything, eventID).Return(nil, apperrors.ErrEventNotFound)
+
+	_, err := eventService.RestoreEvent(context.Background(), eventID)
+
+	assert.ErrorIs(t, err, apperrors.ErrEventNotFound)
+	mockEventRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
+
+func TestUpdateEventHonorsIfMatch(t *testing.T) {
+	eventID := uuid.New()
+
+	tests := []struct {
+		name    string
+		ifMatch []string
+		wantErr error
+	}{
+		{"current version", []string{`"2"`}, nil},
+		{"any version", []string{"*"}, nil},
+		{"one of several", []string{`"1"`, `"2"`}, nil},
+		{"stale version", []string{`"1"`}, apperrors.ErrStaleVersion},
+		{"weak tag", []string{`W/"2"`}, apperrors.ErrStaleVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEventRepo := new(MockEventRepository)
+			eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
+
+			mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&models.Event{ID: eventID, Title: "Planning", Version: 2}, nil)
+			mockEventRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			_, err := eventService.UpdateEvent(context.Background(), eventID, &models.CreateEventRequest{Title: "Replanning"}, models.Precondition{IfMatch: tt.ifMatch})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockEventRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteEventRejectsStaleIfMatch(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
+
+	eventID := uuid.New()
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&models.Event{ID: eventID, Version: 5}, nil)
+
+	err := eventService.DeleteEvent(context.Background(), eventID, models.Precondition{IfMatch: []string{`"4"`}})
+
+	assert.ErrorIs(t, err, apperrors.ErrStaleVersion)
+	mockEventRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestEventChangesKeepTheSearchIndexCurrent(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	index := search.NewMemoryIndex()
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, index, emptyResourceService())
+	ctx := tenant.System(context.Background())
+	eventID := uuid.New()
+
+	mockEventRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Event).ID = eventID
+	}).Return(nil)
+	event, err := eventService.CreateEvent(ctx, &models.CreateEventRequest{Title: "Quarterly planning", Duration: 60}, uuid.New())
+	require.NoError(t, err)
+
+	results, err := eventService.SearchEvents(ctx, models.SearchQuery{Text: "planning", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, eventID, results[0].EventID)
+	assert.Equal(t, "Quarterly <b>planning</b>", results[0].TitleHighlight)
+
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(event, nil)
+	mockEventRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	_, err = eventService.UpdateEvent(ctx, eventID, &models.CreateEventRequest{Title: "Quarterly retro", Duration: 60}, models.Precondition{})
+	require.NoError(t, err)
+
+	results, _ = eventService.SearchEvents(ctx, models.SearchQuery{Text: "planning", Limit: 10})
+	assert.Empty(t, results, "the old title is no longer indexed")
+	results, _ = eventService.SearchEvents(ctx, models.SearchQuery{Text: "retro", Limit: 10})
+	assert.Len(t, results, 1)
+
+	mockEventRepo.On("Delete", mock.Anything, eventID, mock.Anything).Return(nil)
+	require.NoError(t, eventService.DeleteEvent(ctx, eventID, models.Precondition{}))
+
+	results, _ = eventService.SearchEvents(ctx, models.SearchQuery{Text: "retro", Limit: 10})
+	assert.Empty(t, results, "deleted events are not found")
+}
@@ -0,0 +1,519 @@
+// internal/service/group_service.go
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GroupService manages groups, their members and their invitations to events.
+// The members of an invited group are kept in sync with the participants of
+// the event until it is finalized or canceled.
+type GroupService struct {
+	groupRepo       repository.GroupRepository
+	eventGroupRepo  repository.EventGroupRepository
+	participantRepo repository.ParticipantRepository
+	eventRepo       repository.EventRepository
+	userRepo        repository.UserRepository
+	transactor      repository.Transactor
+	outboxRepo      repository.OutboxRepository
+}
+
+// EventGroupMembers is a group invited to an event with the users it expands to
+type EventGroupMembers struct {
+	Group   *models.Group
+	UserIDs []uuid.UUID
+}
+
+// NewGroupService creates a new GroupService
+func NewGroupService(
+	groupRepo repository.GroupRepository,
+	eventGroupRepo repository.EventGroupRepository,
+	participantRepo repository.ParticipantRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository,
+) *GroupService {
+	return &GroupService{
+		groupRepo:       groupRepo,
+		eventGroupRepo:  eventGroupRepo,
+		participantRepo: participantRepo,
+		eventRepo:       eventRepo,
+		userRepo:        userRepo,
+		transactor:      transactor,
+		outboxRepo:      outboxRepo,
+	}
+}
+
+// CreateGroup creates a new group without members
+func (s *GroupService) CreateGroup(ctx context.Context, req *models.GroupRequest) (*models.Group, error) {
+	ctx, span := startSpan(ctx, "GroupService.CreateGroup")
+	defer span.End()
+
+	now := time.Now()
+	group := &models.Group{
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.groupRepo.Create(ctx, group); err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("group.id", group.ID.String()))
+
+	return group, nil
+}
+
+// GetGroup retrieves a group with its direct members
+func (s *GroupService) GetGroup(ctx context.Context, id uuid.UUID) (*models.GroupResponse, error) {
+	ctx, span := startSpan(ctx, "GroupService.GetGroup", attribute.String("group.id", id.String()))
+	defer span.End()
+
+	group, err := s.groupRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.groupRepo.ListMembers(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	return &models.GroupResponse{Group: group, Members: members}, nil
+}
+
+// ListGroups returns all groups
+func (s *GroupService) ListGroups(ctx context.Context) ([]*models.Group, error) {
+	ctx, span := startSpan(ctx, "GroupService.ListGroups")
+	defer span.End()
+
+	return s.groupRepo.List(ctx)
+}
+
+// UpdateGroup renames or redescribes a group
+func (s *GroupService) UpdateGroup(ctx context.Context, id uuid.UUID, req *models.GroupRequest) (*models.Group, error) {
+	ctx, span := startSpan(ctx, "GroupService.UpdateGroup", attribute.String("group.id", id.String()))
+	defer span.End()
+
+	group, err := s.groupRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Name = req.Name
+	group.Description = req.Description
+	group.UpdatedAt = time.Now()
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// DeleteGroup deletes a group. Its members leave the open events the group,
+// or a group containing it, was invited to.
+func (s *GroupService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "GroupService.DeleteGroup", attribute.String("group.id", id.String()))
+	defer span.End()
+
+	if _, err := s.groupRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Find the affected events before the invitations are deleted with the group
+		events, err := s.openEvents(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.groupRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.syncEvents(ctx, events)
+	})
+}
+
+// AddMember adds a user or a nested group to a group and brings the new
+// members into the open events the group is invited to
+func (s *GroupService) AddMember(ctx context.Context, groupID uuid.UUID, req *models.GroupMemberRequest) (*models.GroupMember, error) {
+	ctx, span := startSpan(ctx, "GroupService.AddMember", attribute.String("group.id", groupID.String()))
+	defer span.End()
+
+	if (req.UserID == nil) == (req.GroupID == nil) {
+		return nil, errors.ErrInvalidGroupMember
+	}
+	if _, err := s.groupRepo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	if req.UserID != nil {
+		if _, err := s.userRepo.GetByID(ctx, *req.UserID); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := s.groupRepo.GetByID(ctx, *req.GroupID); err != nil {
+			return nil, err
+		}
+		// The nested group must not contain the group it is added to
+		_, subgroups, err := s.expand(ctx, *req.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		if subgroups[groupID] {
+			return nil, errors.ErrGroupCycle
+		}
+	}
+
+	members, err := s.groupRepo.ListMembers(ctx, []uuid.UUID{groupID})
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if sameID(member.UserID, req.UserID) && sameID(member.MemberGroupID, req.GroupID) {
+			return nil, errors.ErrGroupMemberExists
+		}
+	}
+
+	member := &models.GroupMember{
+		GroupID:       groupID,
+		UserID:        req.UserID,
+		MemberGroupID: req.GroupID,
+		CreatedAt:     time.Now(),
+	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.groupRepo.AddMember(ctx, member); err != nil {
+			return err
+		}
+		events, err := s.openEvents(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		return s.syncEvents(ctx, events)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember removes a member from a group, and the users who were only
+// invited through it from the open events the group is invited to
+func (s *GroupService) RemoveMember(ctx context.Context, groupID, memberID uuid.UUID) error {
+	ctx, span := startSpan(ctx, "GroupService.RemoveMember",
+		attribute.String("group.id", groupID.String()),
+		attribute.String("group_member.id", memberID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.groupRepo.GetByID(ctx, groupID); err != nil {
+		return err
+	}
+	members, err := s.groupRepo.ListMembers(ctx, []uuid.UUID{groupID})
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, member := range members {
+		found = found || member.ID == memberID
+	}
+	if !found {
+		return errors.ErrGroupMemberNotFound
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.groupRepo.RemoveMember(ctx, memberID); err != nil {
+			return err
+		}
+		events, err := s.openEvents(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		return s.syncEvents(ctx, events)
+	})
+}
+
+// InviteGroup invites a group to an event, adding its members as participants
+func (s *GroupService) InviteGroup(ctx context.Context, eventID uuid.UUID, req *models.InviteGroupRequest) (*models.EventGroup, error) {
+	ctx, span := startSpan(ctx, "GroupService.InviteGroup",
+		attribute.String("event.id", eventID.String()),
+		attribute.String("group.id", req.GroupID.String()),
+	)
+	defer span.End()
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.groupRepo.GetByID(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	_, err = s.eventGroupRepo.Get(ctx, eventID, req.GroupID)
+	switch {
+	case err == nil:
+		return nil, errors.ErrEventGroupExists
+	case !stderrors.Is(err, errors.ErrEventGroupNotFound):
+		return nil, err
+	}
+
+	eventGroup := &models.EventGroup{
+		EventID:   eventID,
+		GroupID:   req.GroupID,
+		CreatedAt: time.Now(),
+	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.eventGroupRepo.Create(ctx, eventGroup); err != nil {
+			return err
+		}
+		return s.syncEvent(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return eventGroup, nil
+}
+
+// UninviteGroup withdraws a group's invitation to an event. Members who were
+// not invited directly or through another group stop being participants.
+func (s *GroupService) UninviteGroup(ctx context.Context, eventID, groupID uuid.UUID) error {
+	ctx, span := startSpan(ctx, "GroupService.UninviteGroup",
+		attribute.String("event.id", eventID.String()),
+		attribute.String("group.id", groupID.String()),
+	)
+	defer span.End()
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.eventGroupRepo.Get(ctx, eventID, groupID); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.eventGroupRepo.Delete(ctx, eventID, groupID); err != nil {
+			return err
+		}
+		return s.syncEvent(ctx, event)
+	})
+}
+
+// ListEventGroups returns the groups invited to an event
+func (s *GroupService) ListEventGroups(ctx context.Context, eventID uuid.UUID) ([]*models.Group, error) {
+	ctx, span := startSpan(ctx, "GroupService.ListEventGroups", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	eventGroups, err := s.eventGroupRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	groupIDs := make([]uuid.UUID, len(eventGroups))
+	for i, eventGroup := range eventGroups {
+		groupIDs[i] = eventGroup.GroupID
+	}
+	return s.groupRepo.GetByIDs(ctx, groupIDs)
+}
+
+// EventGroupMembers returns the groups invited to an event, in the order they
+// were invited, with the users each one expands to
+func (s *GroupService) EventGroupMembers(ctx context.Context, eventID uuid.UUID) ([]EventGroupMembers, error) {
+	eventGroups, err := s.eventGroupRepo.GetByEventID(ctx, eventID)
+	if err != nil || len(eventGroups) == 0 {
+		return nil, err
+	}
+
+	groupIDs := make([]uuid.UUID, len(eventGroups))
+	for i, eventGroup := range eventGroups {
+		groupIDs[i] = eventGroup.GroupID
+	}
+	groups, err := s.groupRepo.GetByIDs(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	groupMap := make(map[uuid.UUID]*models.Group, len(groups))
+	for _, group := range groups {
+		groupMap[group.ID] = group
+	}
+
+	result := make([]EventGroupMembers, 0, len(eventGroups))
+	for _, eventGroup := range eventGroups {
+		group, ok := groupMap[eventGroup.GroupID]
+		if !ok {
+			continue
+		}
+		userIDs, _, err := s.expand(ctx, group.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, EventGroupMembers{Group: group, UserIDs: userIDs})
+	}
+	return result, nil
+}
+
+// expand returns the users in a group and its nested groups, in the order
+// they were added, and the IDs of the group and every group nested in it
+func (s *GroupService) expand(ctx context.Context, groupID uuid.UUID) ([]uuid.UUID, map[uuid.UUID]bool, error) {
+	var userIDs []uuid.UUID
+	seenUsers := make(map[uuid.UUID]bool)
+	groups := map[uuid.UUID]bool{groupID: true}
+
+	for level := []uuid.UUID{groupID}; len(level) > 0; {
+		members, err := s.groupRepo.ListMembers(ctx, level)
+		if err != nil {
+			return nil, nil, err
+		}
+		level = nil
+		for _, member := range members {
+			switch {
+			case member.UserID != nil && !seenUsers[*member.UserID]:
+				seenUsers[*member.UserID] = true
+				userIDs = append(userIDs, *member.UserID)
+			case member.MemberGroupID != nil && !groups[*member.MemberGroupID]:
+				groups[*member.MemberGroupID] = true
+				level = append(level, *member.MemberGroupID)
+			}
+		}
+	}
+	return userIDs, groups, nil
+}
+
+// openEvents returns the draft and active events a group, or a group
+// containing it, is invited to
+func (s *GroupService) openEvents(ctx context.Context, groupID uuid.UUID) ([]*models.Event, error) {
+	ancestors := []uuid.UUID{groupID}
+	seen := map[uuid.UUID]bool{groupID: true}
+	for level := ancestors; len(level) > 0; {
+		parents, err := s.groupRepo.ListParentIDs(ctx, level)
+		if err != nil {
+			return nil, err
+		}
+		level = nil
+		for _, id := range parents {
+			if !seen[id] {
+				seen[id] = true
+				level = append(level, id)
+				ancestors = append(ancestors, id)
+			}
+		}
+	}
+
+	eventGroups, err := s.eventGroupRepo.ListByGroupIDs(ctx, ancestors)
+	if err != nil {
+		return nil, err
+	}
+	var events []*models.Event
+	seenEvents := make(map[uuid.UUID]bool)
+	for _, eventGroup := range eventGroups {
+		if seenEvents[eventGroup.EventID] {
+			continue
+		}
+		seenEvents[eventGroup.EventID] = true
+		event, err := s.eventRepo.GetByID(ctx, eventGroup.EventID)
+		if err != nil {
+			return nil, err
+		}
+		if event.Status == models.EventStatusDraft || event.Status == models.EventStatusActive {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s *GroupService) syncEvents(ctx context.Context, events []*models.Event) error {
+	for _, event := range events {
+		if err := s.syncEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncEvent makes the participants added through groups match the members of
+// the groups invited to an event. Participants invited directly are kept.
+func (s *GroupService) syncEvent(ctx context.Context, event *models.Event) error {
+	groups, err := s.EventGroupMembers(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+	// Users in several invited groups are attributed to the first one invited
+	wanted := make(map[uuid.UUID]uuid.UUID)
+	var order []uuid.UUID
+	for _, group := range groups {
+		for _, userID := range group.UserIDs {
+			if _, ok := wanted[userID]; !ok {
+				wanted[userID] = group.Group.ID
+				order = append(order, userID)
+			}
+		}
+	}
+
+	participants, err := s.participantRepo.GetByEventID(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+	existing := make(map[uuid.UUID]bool, len(participants))
+	for _, participant := range participants {
+		existing[participant.UserID] = true
+		if participant.GroupID == nil {
+			continue
+		}
+		groupID, ok := wanted[participant.UserID]
+		switch {
+		case !ok:
+			if err := s.participantRepo.Delete(ctx, event.ID, participant.UserID); err != nil {
+				return err
+			}
+			err := recordLifecycle(ctx, s.outboxRepo, models.TopicParticipantRemoved, event.ID, nil, map[string]interface{}{
+				"participant": participant,
+			})
+			if err != nil {
+				return err
+			}
+		case *participant.GroupID != groupID:
+			// Still invited, now through another group
+			participant.GroupID = &groupID
+			if err := s.participantRepo.Update(ctx, participant); err != nil {
+				return err
+			}
+		}
+	}
+
+	now := time.Now()
+	for _, userID := range order {
+		if existing[userID] {
+			continue
+		}
+		groupID := wanted[userID]
+		participant := &models.EventParticipant{
+			EventID:   event.ID,
+			UserID:    userID,
+			GroupID:   &groupID,
+			CreatedAt: now,
+		}
+		if err := s.participantRepo.Create(ctx, participant); err != nil {
+			return err
+		}
+		err := recordLifecycle(ctx, s.outboxRepo, models.TopicParticipantAdded, event.ID, &event.CreatorID, map[string]interface{}{
+			"participant": participant,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameID reports whether two optional IDs are equal
+func sameID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
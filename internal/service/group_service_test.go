@@ -0,0 +1,329 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeGroupRepository keeps groups and their members in memory
+type FakeGroupRepository struct {
+	Groups  []*models.Group
+	Members []*models.GroupMember
+}
+
+func (f *FakeGroupRepository) Create(ctx context.Context, group *models.Group) error {
+	if group.ID == uuid.Nil {
+		group.ID = uuid.New()
+	}
+	f.Groups = append(f.Groups, group)
+	return nil
+}
+
+func (f *FakeGroupRepository) Update(ctx context.Context, group *models.Group) error {
+	return nil
+}
+
+func (f *FakeGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	for i, group := range f.Groups {
+		if group.ID == id {
+			f.Groups = append(f.Groups[:i], f.Groups[i+1:]...)
+			break
+		}
+	}
+	var members []*models.GroupMember
+	for _, member := range f.Members {
+		if member.GroupID != id && (member.MemberGroupID == nil || *member.MemberGroupID != id) {
+			members = append(members, member)
+		}
+	}
+	f.Members = members
+	return nil
+}
+
+func (f *FakeGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Group, error) {
+	for _, group := range f.Groups {
+		if group.ID == id {
+			return group, nil
+		}
+	}
+	return nil, apperrors.ErrGroupNotFound
+}
+
+func (f *FakeGroupRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Group, error) {
+	var groups []*models.Group
+	for _, group := range f.Groups {
+		for _, id := range ids {
+			if group.ID == id {
+				groups = append(groups, group)
+			}
+		}
+	}
+	return groups, nil
+}
+
+func (f *FakeGroupRepository) List(ctx context.Context) ([]*models.Group, error) {
+	return f.Groups, nil
+}
+
+func (f *FakeGroupRepository) AddMember(ctx context.Context, member *models.GroupMember) error {
+	if member.ID == uuid.Nil {
+		member.ID = uuid.New()
+	}
+	f.Members = append(f.Members, member)
+	return nil
+}
+
+func (f *FakeGroupRepository) RemoveMember(ctx context.Context, id uuid.UUID) error {
+	for i, member := range f.Members {
+		if member.ID == id {
+			f.Members = append(f.Members[:i], f.Members[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (f *FakeGroupRepository) ListMembers(ctx context.Context, groupIDs []uuid.UUID) ([]*models.GroupMember, error) {
+	var members []*models.GroupMember
+	for _, member := range f.Members {
+		for _, id := range groupIDs {
+			if member.GroupID == id {
+				members = append(members, member)
+			}
+		}
+	}
+	return members, nil
+}
+
+func (f *FakeGroupRepository) ListParentIDs(ctx context.Context, groupIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, member := range f.Members {
+		for _, id := range groupIDs {
+			if member.MemberGroupID != nil && *member.MemberGroupID == id {
+				ids = append(ids, member.GroupID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// FakeEventGroupRepository keeps group invitations in memory
+type FakeEventGroupRepository struct {
+	EventGroups []*models.EventGroup
+}
+
+func (f *FakeEventGroupRepository) Create(ctx context.Context, eventGroup *models.EventGroup) error {
+	if eventGroup.ID == uuid.Nil {
+		eventGroup.ID = uuid.New()
+	}
+	f.EventGroups = append(f.EventGroups, eventGroup)
+	return nil
+}
+
+func (f *FakeEventGroupRepository) Delete(ctx context.Context, eventID, groupID uuid.UUID) error {
+	for i, eventGroup := range f.EventGroups {
+		if eventGroup.EventID == eventID && eventGroup.GroupID == groupID {
+			f.EventGroups = append(f.EventGroups[:i], f.EventGroups[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (f *FakeEventGroupRepository) Get(ctx context.Context, eventID, groupID uuid.UUID) (*models.EventGroup, error) {
+	for _, eventGroup := range f.EventGroups {
+		if eventGroup.EventID == eventID && eventGroup.GroupID == groupID {
+			return eventGroup, nil
+		}
+	}
+	return nil, apperrors.ErrEventGroupNotFound
+}
+
+func (f *FakeEventGroupRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.EventGroup, error) {
+	var eventGroups []*models.EventGroup
+	for _, eventGroup := range f.EventGroups {
+		if eventGroup.EventID == eventID {
+			eventGroups = append(eventGroups, eventGroup)
+		}
+	}
+	return eventGroups, nil
+}
+
+func (f *FakeEventGroupRepository) ListByGroupIDs(ctx context.Context, groupIDs []uuid.UUID) ([]*models.EventGroup, error) {
+	var eventGroups []*models.EventGroup
+	for _, eventGroup := range f.EventGroups {
+		for _, id := range groupIDs {
+			if eventGroup.GroupID == id {
+				eventGroups = append(eventGroups, eventGroup)
+			}
+		}
+	}
+	return eventGroups, nil
+}
+
+func emptyGroupService() *service.GroupService {
+	return service.NewGroupService(&FakeGroupRepository{}, &FakeEventGroupRepository{}, &FakeParticipantRepository{},
+		new(MockEventRepository), new(MockUserRepository), FakeTransactor{}, &FakeOutboxRepository{})
+}
+
+// expectEvent returns an event with the given status that eventRepo finds
+func expectEvent(eventRepo *MockEventRepository, status models.EventStatus) *models.Event {
+	event := &models.Event{ID: uuid.New(), CreatorID: uuid.New(), Duration: 60, Status: status}
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	return event
+}
+
+// createGroup creates a group with the given users as members
+func createGroup(t *testing.T, groupService *service.GroupService, name string, userIDs ...uuid.UUID) *models.Group {
+	t.Helper()
+	group, err := groupService.CreateGroup(context.Background(), &models.GroupRequest{Name: name})
+	require.NoError(t, err)
+	for _, userID := range userIDs {
+		_, err := groupService.AddMember(context.Background(), group.ID, &models.GroupMemberRequest{UserID: &userID})
+		require.NoError(t, err)
+	}
+	return group
+}
+
+// eventParticipants maps the participants of an event to the group they came through
+func eventParticipants(participantRepo *FakeParticipantRepository, eventID uuid.UUID) map[uuid.UUID]*uuid.UUID {
+	participants := make(map[uuid.UUID]*uuid.UUID)
+	for _, participant := range participantRepo.Participants {
+		if participant.EventID == eventID {
+			participants[participant.UserID] = participant.GroupID
+		}
+	}
+	return participants
+}
+
+func TestInvitedGroupsStayInSyncWithMembership(t *testing.T) {
+	participantRepo := &FakeParticipantRepository{}
+	eventRepo := new(MockEventRepository)
+	outboxRepo := &FakeOutboxRepository{}
+	userRepo := new(MockUserRepository)
+	userRepo.On("GetByID", mock.Anything, mock.Anything).Return(&models.User{}, nil)
+	groupService := service.NewGroupService(&FakeGroupRepository{}, &FakeEventGroupRepository{}, participantRepo, eventRepo, userRepo, FakeTransactor{}, outboxRepo)
+	ctx := context.Background()
+	ana, ben, cleo, dan := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	// Platform contains the Storage team
+	storage := createGroup(t, groupService, "Storage", ben)
+	platform := createGroup(t, groupService, "Platform", ana)
+	_, err := groupService.AddMember(ctx, platform.ID, &models.GroupMemberRequest{GroupID: &storage.ID})
+	require.NoError(t, err)
+
+	open := expectEvent(eventRepo, models.EventStatusActive)
+	finalized := expectEvent(eventRepo, models.EventStatusFinalized)
+	_, err = groupService.InviteGroup(ctx, open.ID, &models.InviteGroupRequest{GroupID: platform.ID})
+	require.NoError(t, err)
+	_, err = groupService.InviteGroup(ctx, finalized.ID, &models.InviteGroupRequest{GroupID: platform.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]*uuid.UUID{ana: &platform.ID, ben: &platform.ID}, eventParticipants(participantRepo, open.ID))
+
+	_, err = groupService.InviteGroup(ctx, open.ID, &models.InviteGroupRequest{GroupID: platform.ID})
+	assert.ErrorIs(t, err, apperrors.ErrEventGroupExists)
+
+	// Dan is also invited directly, so he stays when he leaves the group
+	require.NoError(t, participantRepo.Create(ctx, &models.EventParticipant{EventID: open.ID, UserID: dan}))
+
+	// Joining a nested group reaches open events only
+	cleoMember, err := groupService.AddMember(ctx, storage.ID, &models.GroupMemberRequest{UserID: &cleo})
+	require.NoError(t, err)
+	danMember, err := groupService.AddMember(ctx, storage.ID, &models.GroupMemberRequest{UserID: &dan})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]*uuid.UUID{ana: &platform.ID, ben: &platform.ID, cleo: &platform.ID, dan: nil}, eventParticipants(participantRepo, open.ID))
+	assert.Len(t, eventParticipants(participantRepo, finalized.ID), 2)
+
+	require.NoError(t, groupService.RemoveMember(ctx, storage.ID, cleoMember.ID))
+	require.NoError(t, groupService.RemoveMember(ctx, storage.ID, danMember.ID))
+	assert.Equal(t, map[uuid.UUID]*uuid.UUID{ana: &platform.ID, ben: &platform.ID, dan: nil}, eventParticipants(participantRepo, open.ID))
+
+	// Withdrawing the invitation removes everyone who came through the group
+	require.NoError(t, groupService.UninviteGroup(ctx, open.ID, platform.ID))
+	assert.Equal(t, map[uuid.UUID]*uuid.UUID{dan: nil}, eventParticipants(participantRepo, open.ID))
+
+	var added, removed int
+	for _, message := range outboxRepo.Messages {
+		if message.EventID != open.ID {
+			continue
+		}
+		switch message.Topic {
+		case models.TopicParticipantAdded:
+			added++
+		case models.TopicParticipantRemoved:
+			removed++
+		}
+	}
+	assert.Equal(t, 3, added)
+	assert.Equal(t, 3, removed)
+}
+
+func TestGroupsCannotContainThemselves(t *testing.T) {
+	groupService := emptyGroupService()
+	ctx := context.Background()
+
+	platform := createGroup(t, groupService, "Platform")
+	storage := createGroup(t, groupService, "Storage")
+	_, err := groupService.AddMember(ctx, platform.ID, &models.GroupMemberRequest{GroupID: &storage.ID})
+	require.NoError(t, err)
+
+	_, err = groupService.AddMember(ctx, storage.ID, &models.GroupMemberRequest{GroupID: &platform.ID})
+	assert.ErrorIs(t, err, apperrors.ErrGroupCycle)
+	_, err = groupService.AddMember(ctx, platform.ID, &models.GroupMemberRequest{GroupID: &platform.ID})
+	assert.ErrorIs(t, err, apperrors.ErrGroupCycle)
+	_, err = groupService.AddMember(ctx, platform.ID, &models.GroupMemberRequest{GroupID: &storage.ID})
+	assert.ErrorIs(t, err, apperrors.ErrGroupMemberExists)
+	_, err = groupService.AddMember(ctx, platform.ID, &models.GroupMemberRequest{})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidGroupMember)
+}
+
+func TestRecommendationsSummarizeInvitedGroups(t *testing.T) {
+	participantRepo := &FakeParticipantRepository{}
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	userRepo.On("GetByID", mock.Anything, mock.Anything).Return(&models.User{}, nil)
+	groupService := service.NewGroupService(&FakeGroupRepository{}, &FakeEventGroupRepository{}, participantRepo, eventRepo, userRepo, FakeTransactor{}, &FakeOutboxRepository{})
+	ctx := context.Background()
+	ana, ben := &models.User{ID: uuid.New(), Name: "Ana"}, &models.User{ID: uuid.New(), Name: "Ben"}
+
+	platform := createGroup(t, groupService, "Platform", ana.ID)
+	design := createGroup(t, groupService, "Design", ben.ID)
+	event := expectEvent(eventRepo, models.EventStatusActive)
+	for _, group := range []*models.Group{platform, design} {
+		_, err := groupService.InviteGroup(ctx, event.ID, &models.InviteGroupRequest{GroupID: group.ID})
+		require.NoError(t, err)
+	}
+
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	slot := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	timeslotRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.TimeSlot{slot}, nil)
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.Availability{
+		{ID: uuid.New(), EventID: event.ID, UserID: ana.ID, StartTime: start, EndTime: start.Add(time.Hour)},
+		{ID: uuid.New(), EventID: event.ID, UserID: ben.ID, StartTime: start.Add(time.Hour), EndTime: start.Add(2 * time.Hour)},
+	}, nil)
+	userRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*models.User{ana, ben}, nil)
+
+	recommendationService := service.NewRecommendationService(eventRepo, timeslotRepo, availabilityRepo, userRepo,
+		&FakeGuestRepository{}, &FakeVoteRepository{}, participantRepo, emptyResourceService(), groupService)
+	result, err := recommendationService.GetRecommendations(ctx, event.ID)
+	require.NoError(t, err)
+	require.Len(t, result.Recommendations, 1)
+
+	// Nobody from Design can make it
+	assert.Equal(t, []models.GroupAttendance{
+		{GroupID: platform.ID, Name: "Platform", Members: 1, Attending: 1},
+		{GroupID: design.ID, Name: "Design", Members: 1, Attending: 0},
+	}, result.Recommendations[0].Groups)
+}
@@ -0,0 +1,100 @@
+// internal/service/notification_dispatcher.go
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/notification"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/pkg/timeutil"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// NotificationDispatcherConfig configures email sending
+type NotificationDispatcherConfig struct {
+	From           string
+	BatchSize      int
+	SendTimeout    time.Duration
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// NotificationDispatcher sends queued notifications and retries failures with exponential backoff
+type NotificationDispatcher struct {
+	notificationRepo repository.NotificationRepository
+	mailer           notification.Mailer
+	cfg              NotificationDispatcherConfig
+}
+
+// NewNotificationDispatcher creates a new NotificationDispatcher
+func NewNotificationDispatcher(
+	notificationRepo repository.NotificationRepository,
+	mailer notification.Mailer,
+	cfg NotificationDispatcherConfig,
+) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		notificationRepo: notificationRepo,
+		mailer:           mailer,
+		cfg:              cfg,
+	}
+}
+
+// SendDue sends one batch of due notifications and returns how many were attempted
+func (d *NotificationDispatcher) SendDue(ctx context.Context) (int, error) {
+	// Lease the batch for longer than the attempts can take
+	lease := 2 * d.cfg.SendTimeout * time.Duration(d.cfg.BatchSize)
+	notifications, err := d.notificationRepo.ClaimDue(ctx, time.Now(), lease, d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, n := range notifications {
+		if err := d.send(ctx, n); err != nil {
+			return 0, err
+		}
+	}
+	return len(notifications), nil
+}
+
+// send makes one attempt at a notification and records the outcome
+func (d *NotificationDispatcher) send(ctx context.Context, n *models.Notification) error {
+	ctx, span := startSpan(ctx, "NotificationDispatcher.send",
+		attribute.String("notification.id", n.ID.String()),
+		attribute.String("notification.kind", string(n.Kind)),
+	)
+	defer span.End()
+
+	sendCtx, cancel := context.WithTimeout(ctx, d.cfg.SendTimeout)
+	err := d.mailer.Send(sendCtx, &notification.Message{
+		From:     d.cfg.From,
+		To:       n.Recipient,
+		Subject:  n.Subject,
+		Text:     n.TextBody,
+		HTML:     n.HTMLBody,
+		Calendar: n.Calendar,
+	})
+	cancel()
+
+	now := time.Now()
+	n.Attempts++
+	n.UpdatedAt = now
+	span.SetAttributes(attribute.Int("notification.attempts", n.Attempts))
+
+	if err == nil {
+		n.Status = models.NotificationSent
+		n.LastError = ""
+		n.SentAt = &now
+	} else {
+		n.LastError = err.Error()
+		if n.Attempts >= d.cfg.MaxAttempts {
+			n.Status = models.NotificationFailed
+		} else {
+			n.NextAttemptAt = now.Add(timeutil.Backoff(n.Attempts, d.cfg.InitialBackoff, d.cfg.MaxBackoff))
+		}
+	}
+
+	return d.notificationRepo.Update(ctx, n)
+}
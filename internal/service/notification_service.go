@@ -0,0 +1,372 @@
+// internal/service/notification_service.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/notification"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// NotificationConfig configures which notifications are queued and how they link back
+type NotificationConfig struct {
+	BaseURL      string        // Public URL of the scheduler, used for links in emails
+	ReminderLead time.Duration // How long before the response deadline non-responders are reminded
+}
+
+// NotificationService queues email notifications for participants. It renders
+// invitations and confirmations from outbox messages and reminders from a
+// periodic scan of upcoming response deadlines.
+type NotificationService struct {
+	notificationRepo repository.NotificationRepository
+	eventRepo        repository.EventRepository
+	timeslotRepo     repository.TimeSlotRepository
+	participantRepo  repository.ParticipantRepository
+	availabilityRepo repository.AvailabilityRepository
+	voteRepo         repository.VoteRepository
+	userRepo         repository.UserRepository
+	templates        *notification.Templates
+	cfg              NotificationConfig
+}
+
+// NewNotificationService creates a new NotificationService
+func NewNotificationService(
+	notificationRepo repository.NotificationRepository,
+	eventRepo repository.EventRepository,
+	timeslotRepo repository.TimeSlotRepository,
+	participantRepo repository.ParticipantRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	voteRepo repository.VoteRepository,
+	userRepo repository.UserRepository,
+	templates *notification.Templates,
+	cfg NotificationConfig,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		eventRepo:        eventRepo,
+		timeslotRepo:     timeslotRepo,
+		participantRepo:  participantRepo,
+		availabilityRepo: availabilityRepo,
+		voteRepo:         voteRepo,
+		userRepo:         userRepo,
+		templates:        templates,
+		cfg:              cfg,
+	}
+}
+
+// HandleOutboxMessage implements OutboxHandler by queuing invitations for new
+// participants, confirmations for finalized events and deadline notices for
+// organizers who have to pick a time themselves
+func (s *NotificationService) HandleOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	switch message.Topic {
+	case models.TopicParticipantAdded:
+		var payload struct {
+			Participant models.EventParticipant `json:"participant"`
+		}
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			return err
+		}
+		return s.queueInvitation(ctx, &payload.Participant)
+
+	case models.TopicTimeSlotFinalized:
+		var payload struct {
+			TimeSlot models.TimeSlot `json:"time_slot"`
+		}
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			return err
+		}
+		return s.queueConfirmations(ctx, message.EventID, payload.TimeSlot.ID)
+
+	case models.TopicEventDeadlinePassed:
+		var payload struct {
+			Decision models.EventDecision `json:"decision"`
+		}
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			return err
+		}
+		return s.queueDeadlineNotice(ctx, &payload.Decision)
+	}
+	return nil
+}
+
+// queueInvitation queues the invitation of a new participant
+func (s *NotificationService) queueInvitation(ctx context.Context, participant *models.EventParticipant) error {
+	ctx, span := startSpan(ctx, "NotificationService.queueInvitation",
+		attribute.String("event.id", participant.EventID.String()),
+		attribute.String("user.id", participant.UserID.String()),
+	)
+	defer span.End()
+
+	event, err := s.eventRepo.GetByID(ctx, participant.EventID)
+	if err != nil {
+		return ignoreNotFound(err)
+	}
+	user, err := s.userRepo.GetByID(ctx, participant.UserID)
+	if err != nil {
+		return ignoreNotFound(err)
+	}
+	organizer, err := s.organizer(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	data := s.templateData(event, user, organizer)
+	return s.enqueue(ctx, models.NotificationInvitation, event, user, "invitation:"+participant.ID.String(), data, "")
+}
+
+// queueConfirmations queues the final decision, with a calendar invite, for
+// everyone involved in an event
+func (s *NotificationService) queueConfirmations(ctx context.Context, eventID, slotID uuid.UUID) error {
+	ctx, span := startSpan(ctx, "NotificationService.queueConfirmations",
+		attribute.String("event.id", eventID.String()),
+		attribute.String("timeslot.id", slotID.String()),
+	)
+	defer span.End()
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return ignoreNotFound(err)
+	}
+	slot, err := s.timeslotRepo.GetByID(ctx, slotID)
+	if err != nil {
+		return ignoreNotFound(err)
+	}
+	organizer, err := s.organizer(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := s.involvedUsers(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	start := slot.StartTime
+	end := start.Add(time.Duration(event.Duration) * time.Minute)
+	attendees := make([]notification.CalendarAttendee, len(recipients))
+	for i, user := range recipients {
+		attendees[i] = notification.CalendarAttendee{Name: user.Name, Email: user.Email}
+	}
+	calendar := notification.BuildCalendar(notification.CalendarEvent{
+		UID:         event.ID.String() + "@meeting-scheduler",
+		Summary:     event.Title,
+		Description: event.Description,
+		Start:       start,
+		End:         end,
+		Organizer:   notification.CalendarAttendee{Name: organizer.Name, Email: organizer.Email},
+		Attendees:   attendees,
+	}, time.Now())
+
+	for _, user := range recipients {
+		data := s.templateData(event, user, organizer)
+		data.Start, data.End = &start, &end
+		dedupeKey := fmt.Sprintf("confirmation:%s:%s:%s", event.ID, slot.ID, user.ID)
+		if err := s.enqueue(ctx, models.NotificationConfirmation, event, user, dedupeKey, data, calendar); err != nil {
+			return err
+		}
+	}
+	span.SetAttributes(attribute.Int("notifications.count", len(recipients)))
+
+	return nil
+}
+
+// queueDeadlineNotice asks the organizer to pick a time for an event that was
+// not finalized automatically at its response deadline
+func (s *NotificationService) queueDeadlineNotice(ctx context.Context, decision *models.EventDecision) error {
+	ctx, span := startSpan(ctx, "NotificationService.queueDeadlineNotice", attribute.String("event.id", decision.EventID.String()))
+	defer span.End()
+
+	event, err := s.eventRepo.GetByID(ctx, decision.EventID)
+	if err != nil {
+		return ignoreNotFound(err)
+	}
+	organizer, err := s.userRepo.GetByID(ctx, event.CreatorID)
+	if err != nil {
+		// Nobody to notify when the creator has no account
+		return ignoreNotFound(err)
+	}
+
+	data := s.templateData(event, organizer, organizer)
+	data.Reason = decision.Reason
+	return s.enqueue(ctx, models.NotificationDeadline, event, organizer, "deadline:"+decision.ID.String(), data, "")
+}
+
+// QueueReminders queues a reminder for every participant who has neither
+// submitted availability nor voted for an active event whose response
+// deadline is within the reminder lead time. Each participant is reminded
+// once per deadline, so the scan is safe to repeat and to run on several
+// replicas.
+func (s *NotificationService) QueueReminders(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := startSpan(ctx, "NotificationService.QueueReminders")
+	defer span.End()
+
+	events, err := s.eventRepo.ListByDeadline(tenant.System(ctx), now, now.Add(s.cfg.ReminderLead))
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, event := range events {
+		ctx := tenant.WithOrganization(ctx, event.OrganizationID)
+		participants, err := s.participantRepo.GetByEventID(ctx, event.ID)
+		if err != nil {
+			return queued, err
+		}
+		availabilities, err := s.availabilityRepo.GetByEventID(ctx, event.ID)
+		if err != nil {
+			return queued, err
+		}
+		votes, err := s.voteRepo.GetByEventID(ctx, event.ID)
+		if err != nil {
+			return queued, err
+		}
+		responded := make(map[uuid.UUID]bool, len(availabilities)+len(votes))
+		for _, availability := range availabilities {
+			responded[availability.UserID] = true
+		}
+		for _, vote := range votes {
+			responded[vote.UserID] = true
+		}
+
+		var pending []uuid.UUID
+		for _, participant := range participants {
+			if !responded[participant.UserID] {
+				pending = append(pending, participant.UserID)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		users, err := s.userRepo.GetByIDs(ctx, pending)
+		if err != nil {
+			return queued, err
+		}
+		organizer, err := s.organizer(ctx, event)
+		if err != nil {
+			return queued, err
+		}
+		for _, user := range users {
+			dedupeKey := fmt.Sprintf("reminder:%s:%s:%d", event.ID, user.ID, event.ResponseDeadline.Unix())
+			if err := s.enqueue(ctx, models.NotificationReminder, event, user, dedupeKey, s.templateData(event, user, organizer), ""); err != nil {
+				return queued, err
+			}
+			queued++
+		}
+	}
+	span.SetAttributes(attribute.Int("events.count", len(events)))
+
+	return queued, nil
+}
+
+// enqueue renders a notification and adds it to the send queue
+func (s *NotificationService) enqueue(ctx context.Context, kind models.NotificationKind, event *models.Event, user *models.User, dedupeKey string, data *notification.TemplateData, calendar string) error {
+	rendered, err := s.templates.Render(string(kind), data)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	_, err = s.notificationRepo.Enqueue(ctx, &models.Notification{
+		Kind:          kind,
+		EventID:       event.ID,
+		UserID:        user.ID,
+		Recipient:     formatAddress(user.Name, user.Email),
+		DedupeKey:     dedupeKey,
+		Subject:       rendered.Subject,
+		TextBody:      rendered.Text,
+		HTMLBody:      rendered.HTML,
+		Calendar:      calendar,
+		Status:        models.NotificationPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	return err
+}
+
+// involvedUsers returns the organizer, the participants and anyone else who
+// submitted availability for an event or voted on it
+func (s *NotificationService) involvedUsers(ctx context.Context, event *models.Event) ([]*models.User, error) {
+	participants, err := s.participantRepo.GetByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	availabilities, err := s.availabilityRepo.GetByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[uuid.UUID]bool{event.CreatorID: true}
+	userIDs := []uuid.UUID{event.CreatorID}
+	add := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+	for _, participant := range participants {
+		add(participant.UserID)
+	}
+	for _, availability := range availabilities {
+		add(availability.UserID)
+	}
+	votes, err := s.voteRepo.GetByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, vote := range votes {
+		add(vote.UserID)
+	}
+
+	return s.userRepo.GetByIDs(ctx, userIDs)
+}
+
+// organizer returns the creator of an event, or a placeholder when the creator has no account
+func (s *NotificationService) organizer(ctx context.Context, event *models.Event) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, event.CreatorID)
+	if stderrors.Is(err, errors.ErrUserNotFound) {
+		return &models.User{ID: event.CreatorID, Name: "The organizer"}, nil
+	}
+	return user, err
+}
+
+func (s *NotificationService) templateData(event *models.Event, user, organizer *models.User) *notification.TemplateData {
+	return &notification.TemplateData{
+		Recipient:   notification.Person{Name: user.Name, Email: user.Email},
+		Organizer:   notification.Person{Name: organizer.Name, Email: organizer.Email},
+		Title:       event.Title,
+		Description: event.Description,
+		Duration:    event.Duration,
+		Deadline:    event.ResponseDeadline,
+		EventURL:    strings.TrimRight(s.cfg.BaseURL, "/") + "/events/" + event.ID.String(),
+	}
+}
+
+// formatAddress formats a name and email as a mail address
+func formatAddress(name, email string) string {
+	if name == "" {
+		return email
+	}
+	return fmt.Sprintf("%q <%s>", name, email)
+}
+
+// ignoreNotFound drops not-found errors, for messages about rows deleted since they were written
+func ignoreNotFound(err error) error {
+	if stderrors.Is(err, errors.ErrEventNotFound) ||
+		stderrors.Is(err, errors.ErrTimeSlotNotFound) ||
+		stderrors.Is(err, errors.ErrUserNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,366 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/notification"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeParticipantRepository is an in-memory ParticipantRepository
+type FakeParticipantRepository struct {
+	Participants []*models.EventParticipant
+}
+
+func (f *FakeParticipantRepository) Create(ctx context.Context, participant *models.EventParticipant) error {
+	if participant.ID == uuid.Nil {
+		participant.ID = uuid.New()
+	}
+	f.Participants = append(f.Participants, participant)
+	return nil
+}
+
+func (f *FakeParticipantRepository) Get(ctx context.Context, eventID, userID uuid.UUID) (*models.EventParticipant, error) {
+	for _, participant := range f.Participants {
+		if participant.EventID == eventID && participant.UserID == userID {
+			return participant, nil
+		}
+	}
+	return nil, apperrors.ErrParticipantNotFound
+}
+
+func (f *FakeParticipantRepository) Update(ctx context.Context, participant *models.EventParticipant) error {
+	return nil
+}
+
+func (f *FakeParticipantRepository) Delete(ctx context.Context, eventID, userID uuid.UUID) error {
+	for i, participant := range f.Participants {
+		if participant.EventID == eventID && participant.UserID == userID {
+			f.Participants = append(f.Participants[:i], f.Participants[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (f *FakeParticipantRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.EventParticipant, error) {
+	var participants []*models.EventParticipant
+	for _, participant := range f.Participants {
+		if participant.EventID == eventID {
+			participants = append(participants, participant)
+		}
+	}
+	return participants, nil
+}
+
+// FakeNotificationRepository is an in-memory NotificationRepository
+type FakeNotificationRepository struct {
+	Notifications []*models.Notification
+}
+
+func (f *FakeNotificationRepository) Enqueue(ctx context.Context, n *models.Notification) (bool, error) {
+	for _, existing := range f.Notifications {
+		if existing.DedupeKey == n.DedupeKey {
+			return false, nil
+		}
+	}
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	f.Notifications = append(f.Notifications, n)
+	return true, nil
+}
+
+func (f *FakeNotificationRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Notification, error) {
+	var due []*models.Notification
+	for _, n := range f.Notifications {
+		if n.Status == models.NotificationPending && !n.NextAttemptAt.After(now) && len(due) < limit {
+			n.NextAttemptAt = now.Add(lease)
+			due = append(due, n)
+		}
+	}
+	return due, nil
+}
+
+func (f *FakeNotificationRepository) Update(ctx context.Context, n *models.Notification) error {
+	return nil
+}
+
+// FakeMailer records sent messages, failing the first Failures sends
+type FakeMailer struct {
+	Failures int
+	Sent     []*notification.Message
+}
+
+func (f *FakeMailer) Send(ctx context.Context, msg *notification.Message) error {
+	if f.Failures > 0 {
+		f.Failures--
+		return errors.New("connection refused")
+	}
+	f.Sent = append(f.Sent, msg)
+	return nil
+}
+
+func TestNotificationServiceQueuesInvitation(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	userRepo := new(MockUserRepository)
+	participantRepo := &FakeParticipantRepository{}
+	voteRepo := &FakeVoteRepository{}
+	notificationRepo := &FakeNotificationRepository{}
+	templates, err := notification.LoadTemplates("")
+	require.NoError(t, err)
+	notificationService := service.NewNotificationService(
+		notificationRepo, eventRepo, timeslotRepo, participantRepo, availabilityRepo, voteRepo, userRepo, templates,
+		service.NotificationConfig{BaseURL: "https://scheduler.example.com/", ReminderLead: 24 * time.Hour},
+	)
+
+	organizer := &models.User{ID: uuid.New(), Name: "Admin User", Email: "admin@example.com"}
+	invitee := &models.User{ID: uuid.New(), Name: "Jane Smith", Email: "jane@example.com"}
+	event := &models.Event{ID: uuid.New(), Title: "Team Brainstorming", CreatorID: organizer.ID, Duration: 60}
+	participant := &models.EventParticipant{ID: uuid.New(), EventID: event.ID, UserID: invitee.ID}
+
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	userRepo.On("GetByID", mock.Anything, invitee.ID).Return(invitee, nil)
+	userRepo.On("GetByID", mock.Anything, organizer.ID).Return(organizer, nil)
+
+	payload, err := json.Marshal(map[string]interface{}{"participant": participant})
+	require.NoError(t, err)
+	message := &models.OutboxMessage{Topic: models.TopicParticipantAdded, EventID: event.ID, Payload: payload}
+
+	// Relaying the same message twice queues a single invitation
+	require.NoError(t, notificationService.HandleOutboxMessage(context.Background(), message))
+	require.NoError(t, notificationService.HandleOutboxMessage(context.Background(), message))
+
+	require.Len(t, notificationRepo.Notifications, 1)
+	n := notificationRepo.Notifications[0]
+	assert.Equal(t, models.NotificationInvitation, n.Kind)
+	assert.Equal(t, `"Jane Smith" <jane@example.com>`, n.Recipient)
+	assert.Equal(t, "You're invited: Team Brainstorming", n.Subject)
+	assert.Contains(t, n.TextBody, "Admin User invited you")
+	assert.Contains(t, n.TextBody, "https://scheduler.example.com/events/"+event.ID.String())
+	assert.Equal(t, models.NotificationPending, n.Status)
+}
+
+func TestNotificationServiceRemindsOnlyNonResponders(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	userRepo := new(MockUserRepository)
+	participantRepo := &FakeParticipantRepository{}
+	voteRepo := &FakeVoteRepository{}
+	notificationRepo := &FakeNotificationRepository{}
+	templates, err := notification.LoadTemplates("")
+	require.NoError(t, err)
+	notificationService := service.NewNotificationService(
+		notificationRepo, eventRepo, timeslotRepo, participantRepo, availabilityRepo, voteRepo, userRepo, templates,
+		service.NotificationConfig{BaseURL: "https://scheduler.example.com/", ReminderLead: 24 * time.Hour},
+	)
+	now := time.Date(2025, 1, 14, 12, 0, 0, 0, time.UTC)
+	deadline := now.Add(6 * time.Hour)
+
+	organizer := &models.User{ID: uuid.New(), Name: "Admin User", Email: "admin@example.com"}
+	responder := &models.User{ID: uuid.New(), Name: "John Doe", Email: "john@example.com"}
+	silent := &models.User{ID: uuid.New(), Name: "Jane Smith", Email: "jane@example.com"}
+	voter := &models.User{ID: uuid.New(), Name: "Bob Johnson", Email: "bob@example.com"}
+	event := &models.Event{ID: uuid.New(), Title: "Kickoff", CreatorID: organizer.ID, Status: models.EventStatusActive, ResponseDeadline: &deadline}
+	for _, user := range []*models.User{responder, silent, voter} {
+		require.NoError(t, participantRepo.Create(context.Background(), &models.EventParticipant{EventID: event.ID, UserID: user.ID}))
+	}
+	// Voting on a slot counts as responding
+	require.NoError(t, voteRepo.Create(context.Background(), &models.Vote{EventID: event.ID, TimeSlotID: uuid.New(), UserID: voter.ID, Choice: models.VoteMaybe}))
+
+	eventRepo.On("ListByDeadline", mock.Anything, now, now.Add(24*time.Hour)).Return([]*models.Event{event}, nil)
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.Availability{
+		{UserID: responder.ID, EventID: event.ID},
+	}, nil)
+	userRepo.On("GetByIDs", mock.Anything, []uuid.UUID{silent.ID}).Return([]*models.User{silent}, nil)
+	userRepo.On("GetByID", mock.Anything, organizer.ID).Return(organizer, nil)
+
+	queued, err := notificationService.QueueReminders(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+
+	// A later scan before the deadline does not remind the same participant again
+	_, err = notificationService.QueueReminders(context.Background(), now)
+	require.NoError(t, err)
+
+	require.Len(t, notificationRepo.Notifications, 1)
+	n := notificationRepo.Notifications[0]
+	assert.Equal(t, models.NotificationReminder, n.Kind)
+	assert.Equal(t, silent.ID, n.UserID)
+	assert.Contains(t, n.TextBody, "Responses close Tue, 14 Jan 2025 18:00 UTC")
+}
+
+func TestNotificationServiceQueuesConfirmationsWithCalendar(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	userRepo := new(MockUserRepository)
+	participantRepo := &FakeParticipantRepository{}
+	voteRepo := &FakeVoteRepository{}
+	notificationRepo := &FakeNotificationRepository{}
+	templates, err := notification.LoadTemplates("")
+	require.NoError(t, err)
+	notificationService := service.NewNotificationService(
+		notificationRepo, eventRepo, timeslotRepo, participantRepo, availabilityRepo, voteRepo, userRepo, templates,
+		service.NotificationConfig{BaseURL: "https://scheduler.example.com/", ReminderLead: 24 * time.Hour},
+	)
+
+	organizer := &models.User{ID: uuid.New(), Name: "Admin User", Email: "admin@example.com"}
+	participant := &models.User{ID: uuid.New(), Name: "Jane Smith", Email: "jane@example.com"}
+	respondent := &models.User{ID: uuid.New(), Name: "John Doe", Email: "john@example.com"}
+	event := &models.Event{ID: uuid.New(), Title: "Review", CreatorID: organizer.ID, Duration: 45, Status: models.EventStatusFinalized}
+	slot := &models.TimeSlot{
+		ID:        uuid.New(),
+		EventID:   event.ID,
+		StartTime: time.Date(2025, 1, 25, 11, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 1, 25, 13, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, participantRepo.Create(context.Background(), &models.EventParticipant{EventID: event.ID, UserID: participant.ID}))
+
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	timeslotRepo.On("GetByID", mock.Anything, slot.ID).Return(slot, nil)
+	userRepo.On("GetByID", mock.Anything, organizer.ID).Return(organizer, nil)
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.Availability{
+		{UserID: respondent.ID, EventID: event.ID},
+		{UserID: participant.ID, EventID: event.ID},
+	}, nil)
+	userRepo.On("GetByIDs", mock.Anything, []uuid.UUID{organizer.ID, participant.ID, respondent.ID}).
+		Return([]*models.User{organizer, participant, respondent}, nil)
+
+	payload, err := json.Marshal(map[string]interface{}{"event": event, "time_slot": slot})
+	require.NoError(t, err)
+	require.NoError(t, notificationService.HandleOutboxMessage(context.Background(), &models.OutboxMessage{
+		Topic:   models.TopicTimeSlotFinalized,
+		EventID: event.ID,
+		Payload: payload,
+	}))
+
+	require.Len(t, notificationRepo.Notifications, 3)
+	for _, n := range notificationRepo.Notifications {
+		assert.Equal(t, models.NotificationConfirmation, n.Kind)
+		assert.Equal(t, "Confirmed: Review on Sat, 25 Jan 2025 11:00 UTC", n.Subject)
+		// The meeting lasts the event duration, not the whole slot
+		assert.Contains(t, n.Calendar, "DTEND:20250125T114500Z")
+	}
+}
+
+func TestNotificationServiceAsksOrganizerToDecideAtDeadline(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	userRepo := new(MockUserRepository)
+	participantRepo := &FakeParticipantRepository{}
+	voteRepo := &FakeVoteRepository{}
+	notificationRepo := &FakeNotificationRepository{}
+	templates, err := notification.LoadTemplates("")
+	require.NoError(t, err)
+	notificationService := service.NewNotificationService(
+		notificationRepo, eventRepo, timeslotRepo, participantRepo, availabilityRepo, voteRepo, userRepo, templates,
+		service.NotificationConfig{BaseURL: "https://scheduler.example.com/", ReminderLead: 24 * time.Hour},
+	)
+
+	organizer := &models.User{ID: uuid.New(), Name: "Admin User", Email: "admin@example.com"}
+	event := &models.Event{ID: uuid.New(), Title: "Offsite", CreatorID: organizer.ID, Status: models.EventStatusActive}
+	decision := &models.EventDecision{
+		ID:      uuid.New(),
+		EventID: event.ID,
+		Outcome: models.DecisionOrganizerNotified,
+		Reason:  "the top recommendation has 2 of the 3 attendees required",
+	}
+
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	userRepo.On("GetByID", mock.Anything, organizer.ID).Return(organizer, nil)
+
+	payload, err := json.Marshal(map[string]interface{}{"event": event, "decision": decision})
+	require.NoError(t, err)
+	require.NoError(t, notificationService.HandleOutboxMessage(context.Background(), &models.OutboxMessage{
+		Topic:   models.TopicEventDeadlinePassed,
+		EventID: event.ID,
+		Payload: payload,
+	}))
+
+	require.Len(t, notificationRepo.Notifications, 1)
+	n := notificationRepo.Notifications[0]
+	assert.Equal(t, models.NotificationDeadline, n.Kind)
+	assert.Equal(t, organizer.ID, n.UserID)
+	assert.Equal(t, "Responses for Offsite are in: please pick a time", n.Subject)
+	assert.Contains(t, n.TextBody, "not finalized automatically: the top recommendation has 2 of the 3 attendees required.")
+}
+
+func TestNotificationDispatcherRetriesWithBackoff(t *testing.T) {
+	notificationRepo := &FakeNotificationRepository{}
+	n := &models.Notification{
+		Kind:          models.NotificationReminder,
+		Recipient:     "jane@example.com",
+		DedupeKey:     "reminder:1",
+		Subject:       "Reminder",
+		Status:        models.NotificationPending,
+		NextAttemptAt: time.Now(),
+	}
+	_, err := notificationRepo.Enqueue(context.Background(), n)
+	require.NoError(t, err)
+
+	mailer := &FakeMailer{Failures: 1}
+	dispatcher := service.NewNotificationDispatcher(notificationRepo, mailer, service.NotificationDispatcherConfig{
+		From:           "no-reply@example.com",
+		BatchSize:      10,
+		SendTimeout:    time.Second,
+		MaxAttempts:    3,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+	})
+
+	// The first attempt fails and is rescheduled
+	sent, err := dispatcher.SendDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, models.NotificationPending, n.Status)
+	assert.Equal(t, "connection refused", n.LastError)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), n.NextAttemptAt, 5*time.Second)
+
+	n.NextAttemptAt = time.Now()
+	_, err = dispatcher.SendDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.NotificationSent, n.Status)
+	assert.Equal(t, 2, n.Attempts)
+	require.Len(t, mailer.Sent, 1)
+	assert.Equal(t, "no-reply@example.com", mailer.Sent[0].From)
+	assert.Equal(t, "jane@example.com", mailer.Sent[0].To)
+}
+
+func TestAddParticipantRejectsDuplicates(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	mockUserRepo := new(MockUserRepository)
+	participantRepo := &FakeParticipantRepository{}
+	outbox := &FakeOutboxRepository{}
+	participantService := service.NewParticipantService(
+		participantRepo, mockEventRepo, mockUserRepo, new(MockAvailabilityRepository), &FakeGuestRepository{}, &FakeVoteRepository{}, FakeTransactor{}, outbox,
+	)
+
+	event := &models.Event{ID: uuid.New(), CreatorID: uuid.New()}
+	userID := uuid.New()
+	mockEventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	mockUserRepo.On("GetByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
+
+	participant, err := participantService.AddParticipant(context.Background(), event.ID, &models.AddParticipantRequest{UserID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, userID, participant.UserID)
+	assert.Equal(t, []string{models.TopicParticipantAdded}, outbox.Topics())
+
+	_, err = participantService.AddParticipant(context.Background(), event.ID, &models.AddParticipantRequest{UserID: userID})
+	assert.ErrorIs(t, err, apperrors.ErrParticipantExists)
+	assert.Len(t, participantRepo.Participants, 1)
+}
@@ -0,0 +1,134 @@
+// internal/service/organization_service.go
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/auth"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TokenConfig configures the bearer tokens issued to users
+type TokenConfig struct {
+	Secret string        // Key tokens are signed with
+	TTL    time.Duration // Lifetime of tokens issued without an expiry
+}
+
+// OrganizationService manages organizations, their users and the tokens users
+// call the API with. It backs the admin routes, which act across organizations.
+type OrganizationService struct {
+	organizationRepo repository.OrganizationRepository
+	userRepo         repository.UserRepository
+	transactor       repository.Transactor
+	auditRepo        repository.AuditRepository
+	cfg              TokenConfig
+}
+
+// NewOrganizationService creates a new OrganizationService
+func NewOrganizationService(
+	organizationRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	transactor repository.Transactor,
+	auditRepo repository.AuditRepository,
+	cfg TokenConfig,
+) *OrganizationService {
+	return &OrganizationService{
+		organizationRepo: organizationRepo,
+		userRepo:         userRepo,
+		transactor:       transactor,
+		auditRepo:        auditRepo,
+		cfg:              cfg,
+	}
+}
+
+// CreateOrganization creates a new organization
+func (s *OrganizationService) CreateOrganization(ctx context.Context, req *models.OrganizationRequest) (*models.Organization, error) {
+	ctx, span := startSpan(ctx, "OrganizationService.CreateOrganization")
+	defer span.End()
+
+	now := time.Now()
+	organization := &models.Organization{
+		Name:      strings.TrimSpace(req.Name),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.organizationRepo.Create(ctx, organization); err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// ListOrganizations returns all organizations
+func (s *OrganizationService) ListOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	ctx, span := startSpan(ctx, "OrganizationService.ListOrganizations")
+	defer span.End()
+
+	return s.organizationRepo.List(ctx)
+}
+
+// CreateUser adds a user to an organization
+func (s *OrganizationService) CreateUser(ctx context.Context, organizationID uuid.UUID, req *models.CreateUserRequest) (*models.User, error) {
+	ctx, span := startSpan(ctx, "OrganizationService.CreateUser", attribute.String("organization.id", organizationID.String()))
+	defer span.End()
+
+	if _, err := s.organizationRepo.GetByID(ctx, organizationID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user := &models.User{
+		OrganizationID: organizationID,
+		Name:           strings.TrimSpace(req.Name),
+		Email:          strings.TrimSpace(req.Email),
+		DefaultBuffer:  req.DefaultBuffer,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	ctx = tenant.WithOrganization(ctx, organizationID)
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, models.AuditUserCreated, models.AuditEntityUser, user.ID, nil, nil, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// IssueToken signs a bearer token for a user. The token acts for the user's
+// organization until it expires.
+func (s *OrganizationService) IssueToken(ctx context.Context, req *models.TokenRequest) (*models.TokenResponse, error) {
+	ctx, span := startSpan(ctx, "OrganizationService.IssueToken", attribute.String("user.id", req.UserID.String()))
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.cfg.TTL)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			return nil, errors.ErrInvalidTokenExpiry
+		}
+		expiresAt = *req.ExpiresAt
+	}
+
+	principal := auth.Principal{UserID: user.ID, OrganizationID: user.OrganizationID}
+	return &models.TokenResponse{
+		Token:          auth.Sign(s.cfg.Secret, principal, now, expiresAt),
+		UserID:         user.ID,
+		OrganizationID: user.OrganizationID,
+		ExpiresAt:      expiresAt.UTC().Truncate(time.Second),
+	}, nil
+}
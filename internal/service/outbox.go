@@ -0,0 +1,146 @@
+// internal/service/outbox.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/npkanaka/meeting-scheduler/pkg/timeutil"
+)
+
+// recordLifecycle writes a lifecycle message to the outbox. It must be called
+// with the transaction context of the change it describes, whose organization
+// the message is tagged with.
+func recordLifecycle(ctx context.Context, outboxRepo repository.OutboxRepository, topic string, eventID uuid.UUID, creatorID *uuid.UUID, data map[string]interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	message := &models.OutboxMessage{
+		Topic:     topic,
+		EventID:   eventID,
+		CreatorID: creatorID,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}
+	if organizationID, ok := tenant.OrganizationID(ctx); ok {
+		message.OrganizationID = &organizationID
+	}
+	return outboxRepo.Create(ctx, message)
+}
+
+// OutboxHandler consumes committed outbox messages. Handlers run inside the
+// relay transaction, so any rows they write commit together with the message
+// being marked processed, and act for the organization of the message.
+type OutboxHandler interface {
+	HandleOutboxMessage(ctx context.Context, message *models.OutboxMessage) error
+}
+
+// OutboxRelayConfig configures the OutboxRelay
+type OutboxRelayConfig struct {
+	BatchSize      int
+	MaxAttempts    int           // Failed attempts before a message is dead-lettered
+	InitialBackoff time.Duration // Doubled after every failed attempt
+	MaxBackoff     time.Duration
+}
+
+// OutboxRelay moves committed outbox messages to their consumers
+type OutboxRelay struct {
+	transactor repository.Transactor
+	outboxRepo repository.OutboxRepository
+	handlers   []OutboxHandler
+	cfg        OutboxRelayConfig
+}
+
+// NewOutboxRelay creates a new OutboxRelay
+func NewOutboxRelay(
+	transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository,
+	cfg OutboxRelayConfig,
+	handlers ...OutboxHandler,
+) *OutboxRelay {
+	return &OutboxRelay{
+		transactor: transactor,
+		outboxRepo: outboxRepo,
+		handlers:   handlers,
+		cfg:        cfg,
+	}
+}
+
+// RelayPending relays batches of messages until the backlog is drained
+func (r *OutboxRelay) RelayPending(ctx context.Context) error {
+	for {
+		n, err := r.RelayOnce(ctx)
+		if err != nil || n < r.cfg.BatchSize {
+			return err
+		}
+	}
+}
+
+// RelayOnce hands one batch of messages to the handlers and returns how many
+// were claimed. Each message is relayed in its own savepoint: a message whose
+// handlers fail is rolled back alone and retried with backoff, and dead-lettered
+// once it used up its attempts, while the rest of the batch commits.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	relayed := 0
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		messages, err := r.outboxRepo.ClaimPending(ctx, time.Now(), r.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			err := r.transactor.WithinSavepoint(ctx, func(ctx context.Context) error {
+				return r.relay(ctx, message)
+			})
+			if err == nil {
+				continue
+			}
+			// Shutting down is not the message's fault
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := r.recordFailure(ctx, message, err); err != nil {
+				return err
+			}
+		}
+		relayed = len(messages)
+		return nil
+	})
+	return relayed, err
+}
+
+// relay hands a message to every handler and marks it processed
+func (r *OutboxRelay) relay(ctx context.Context, message *models.OutboxMessage) error {
+	handlerCtx := ctx
+	if message.OrganizationID != nil {
+		handlerCtx = tenant.WithOrganization(ctx, *message.OrganizationID)
+	}
+	for _, handler := range r.handlers {
+		if err := handler.HandleOutboxMessage(handlerCtx, message); err != nil {
+			return err
+		}
+	}
+	return r.outboxRepo.MarkProcessed(ctx, message.ID, time.Now())
+}
+
+// recordFailure schedules the next attempt at a message, or dead-letters it
+func (r *OutboxRelay) recordFailure(ctx context.Context, message *models.OutboxMessage, cause error) error {
+	now := time.Now()
+	message.Attempts++
+	message.LastError = cause.Error()
+	if message.Attempts >= r.cfg.MaxAttempts {
+		message.DeadAt = &now
+		log.Printf("outbox message %s (%s) dead after %d attempts: %v", message.ID, message.Topic, message.Attempts, cause)
+	} else {
+		message.NextAttemptAt = now.Add(timeutil.Backoff(message.Attempts, r.cfg.InitialBackoff, r.cfg.MaxBackoff))
+	}
+	return r.outboxRepo.RecordFailure(ctx, message)
+}
@@ -0,0 +1,216 @@
+// internal/service/participant_service.go
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ParticipantService handles event participant business logic
+type ParticipantService struct {
+	participantRepo  repository.ParticipantRepository
+	eventRepo        repository.EventRepository
+	userRepo         repository.UserRepository
+	availabilityRepo repository.AvailabilityRepository
+	guestRepo        repository.GuestRepository
+	voteRepo         repository.VoteRepository
+	transactor       repository.Transactor
+	outboxRepo       repository.OutboxRepository
+}
+
+// NewParticipantService creates a new ParticipantService
+func NewParticipantService(
+	participantRepo repository.ParticipantRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	guestRepo repository.GuestRepository,
+	voteRepo repository.VoteRepository,
+	transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository,
+) *ParticipantService {
+	return &ParticipantService{
+		participantRepo:  participantRepo,
+		eventRepo:        eventRepo,
+		userRepo:         userRepo,
+		availabilityRepo: availabilityRepo,
+		guestRepo:        guestRepo,
+		voteRepo:         voteRepo,
+		transactor:       transactor,
+		outboxRepo:       outboxRepo,
+	}
+}
+
+// AddParticipant invites a user to an event
+func (s *ParticipantService) AddParticipant(ctx context.Context, eventID uuid.UUID, req *models.AddParticipantRequest) (*models.EventParticipant, error) {
+	ctx, span := startSpan(ctx, "ParticipantService.AddParticipant",
+		attribute.String("event.id", eventID.String()),
+		attribute.String("user.id", req.UserID.String()),
+	)
+	defer span.End()
+
+	// Verify the event and user exist
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.GetByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.participantRepo.Get(ctx, eventID, req.UserID)
+	switch {
+	case err == nil && existing.GroupID != nil:
+		// Inviting a member of an invited group directly keeps them when they leave the group
+		existing.GroupID = nil
+		if err := s.participantRepo.Update(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	case err == nil:
+		return nil, errors.ErrParticipantExists
+	case !stderrors.Is(err, errors.ErrParticipantNotFound):
+		return nil, err
+	}
+
+	participant := &models.EventParticipant{
+		EventID:   eventID,
+		UserID:    req.UserID,
+		CreatedAt: time.Now(),
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.participantRepo.Create(ctx, participant); err != nil {
+			return err
+		}
+		return recordLifecycle(ctx, s.outboxRepo, models.TopicParticipantAdded, eventID, &event.CreatorID, map[string]interface{}{
+			"participant": participant,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return participant, nil
+}
+
+// RemoveParticipant removes a user from an event
+func (s *ParticipantService) RemoveParticipant(ctx context.Context, eventID, userID uuid.UUID) error {
+	ctx, span := startSpan(ctx, "ParticipantService.RemoveParticipant",
+		attribute.String("event.id", eventID.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return err
+	}
+	participant, err := s.participantRepo.Get(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.participantRepo.Delete(ctx, eventID, userID); err != nil {
+			return err
+		}
+		return recordLifecycle(ctx, s.outboxRepo, models.TopicParticipantRemoved, eventID, nil, map[string]interface{}{
+			"participant": participant,
+		})
+	})
+}
+
+// ListParticipants returns the participants of an event, followed by its
+// guests, and whether each has responded
+func (s *ParticipantService) ListParticipants(ctx context.Context, eventID uuid.UUID) ([]models.ParticipantResponse, error) {
+	ctx, span := startSpan(ctx, "ParticipantService.ListParticipants", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	participants, err := s.participantRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	responded, err := s.respondedUsers(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, len(participants))
+	for i, participant := range participants {
+		userIDs[i] = participant.UserID
+	}
+	users, err := s.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userMap := make(map[uuid.UUID]*models.User, len(users))
+	for _, user := range users {
+		userMap[user.ID] = user
+	}
+
+	responses := make([]models.ParticipantResponse, 0, len(participants))
+	for _, participant := range participants {
+		user, ok := userMap[participant.UserID]
+		if !ok {
+			continue
+		}
+		responses = append(responses, models.ParticipantResponse{
+			UserID:    user.ID,
+			Name:      user.Name,
+			Email:     user.Email,
+			Responded: responded[user.ID],
+			InvitedAt: participant.CreatedAt,
+			GroupID:   participant.GroupID,
+		})
+	}
+
+	guests, err := s.guestRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, guest := range guests {
+		responses = append(responses, models.ParticipantResponse{
+			UserID:    guest.ID,
+			Name:      guest.Name,
+			Email:     guest.Email,
+			Responded: responded[guest.ID],
+			InvitedAt: guest.CreatedAt,
+			Guest:     true,
+		})
+	}
+	span.SetAttributes(attribute.Int("participants.count", len(responses)))
+
+	return responses, nil
+}
+
+// respondedUsers returns the users who have submitted availability for an
+// event or voted on its time slots
+func (s *ParticipantService) respondedUsers(ctx context.Context, eventID uuid.UUID) (map[uuid.UUID]bool, error) {
+	availabilities, err := s.availabilityRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := s.voteRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	responded := make(map[uuid.UUID]bool, len(availabilities)+len(votes))
+	for _, availability := range availabilities {
+		responded[availability.UserID] = true
+	}
+	for _, vote := range votes {
+		responded[vote.UserID] = true
+	}
+	return responded, nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/npkanaka/meeting-scheduler/internal/models"
 	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/pkg/timeutil"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // RecommendationService handles meeting time recommendations
@@ -17,6 +19,26 @@ type RecommendationService struct {
 	timeslotRepo     repository.TimeSlotRepository
 	availabilityRepo repository.AvailabilityRepository
 	userRepo         repository.UserRepository
+	guestRepo        repository.GuestRepository
+	voteRepo         repository.VoteRepository
+	participantRepo  repository.ParticipantRepository
+	resourceService  *ResourceService
+	groupService     *GroupService
+}
+
+// QuorumFilter narrows recommendations down by whether they have quorum
+type QuorumFilter string
+
+const (
+	QuorumAny   QuorumFilter = ""      // All recommendations
+	QuorumMet   QuorumFilter = "met"   // Recommendations that meet the quorum
+	QuorumUnmet QuorumFilter = "unmet" // Recommendations below the quorum
+)
+
+// QuorumRule is what a time slot's attendees need to reach for the slot to have quorum
+type QuorumRule struct {
+	Attendees int                    // Attendees needed overall
+	Groups    []models.RequiredGroup // Groups that each need some of the attendees
 }
 
 // NewRecommendationService creates a new RecommendationService
@@ -25,22 +47,42 @@ func NewRecommendationService(
 	timeslotRepo repository.TimeSlotRepository,
 	availabilityRepo repository.AvailabilityRepository,
 	userRepo repository.UserRepository,
+	guestRepo repository.GuestRepository,
+	voteRepo repository.VoteRepository,
+	participantRepo repository.ParticipantRepository,
+	resourceService *ResourceService,
+	groupService *GroupService,
 ) *RecommendationService {
 	return &RecommendationService{
 		eventRepo:        eventRepo,
 		timeslotRepo:     timeslotRepo,
 		availabilityRepo: availabilityRepo,
 		userRepo:         userRepo,
+		guestRepo:        guestRepo,
+		voteRepo:         voteRepo,
+		participantRepo:  participantRepo,
+		resourceService:  resourceService,
+		groupService:     groupService,
 	}
 }
 
-// GetRecommendations generates time slot recommendations for an event
+// GetRecommendations generates time slot recommendations for an event, scored
+// from availability ranges, votes or both as the event's scoring mode says.
+// Events that require a resource only get slots where a suitable one is free.
+// Availability only counts when it covers the meeting and its buffers without
+// touching another confirmed meeting of the respondent. Slots that meet the
+// event's quorum rank before those that do not.
 func (s *RecommendationService) GetRecommendations(ctx context.Context, eventID uuid.UUID) (*models.RecommendationResponse, error) {
+	ctx, span := startSpan(ctx, "RecommendationService.GetRecommendations", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
 	// Get the event
 	event, err := s.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
+	mode := scoringMode(event.Scoring)
+	span.SetAttributes(attribute.String("scoring", string(mode)))
 
 	// Get all proposed time slots for the event
 	timeSlots, err := s.timeslotRepo.GetByEventID(ctx, eventID)
@@ -48,43 +90,97 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, eventID
 		return nil, err
 	}
 
+	rule, err := s.QuorumRule(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(timeSlots) == 0 {
-		return &models.RecommendationResponse{Recommendations: []models.Recommendation{}}, nil
+		span.SetAttributes(attribute.Int("timeslots.count", 0))
+		return &models.RecommendationResponse{Scoring: mode, Quorum: rule.Attendees, Recommendations: []models.Recommendation{}}, nil
 	}
 
-	// Get all availability data for the event
-	availabilities, err := s.availabilityRepo.GetByEventID(ctx, eventID)
+	schedule, err := s.resourceSchedule(ctx, event, timeSlots)
 	if err != nil {
 		return nil, err
 	}
 
+	// Respondents are listed in the order they first responded
+	var respondentIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	addRespondent := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			respondentIDs = append(respondentIDs, id)
+		}
+	}
+
 	// Group availabilities by user
 	userAvailabilities := make(map[uuid.UUID][]*models.Availability)
-	userIDs := make(map[uuid.UUID]bool)
+	var availabilities []*models.Availability
+	if mode != models.ScoringVotes {
+		availabilities, err = s.availabilityRepo.GetByEventID(ctx, eventID)
+		if err != nil {
+			return nil, err
+		}
+		for _, avail := range availabilities {
+			userAvailabilities[avail.UserID] = append(userAvailabilities[avail.UserID], avail)
+			addRespondent(avail.UserID)
+		}
+	}
 
-	for _, avail := range availabilities {
-		userAvailabilities[avail.UserID] = append(userAvailabilities[avail.UserID], avail)
-		userIDs[avail.UserID] = true
+	// Votes are always tallied, and count towards the score unless only availability does
+	votes, err := s.voteRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	slotVotes := make(map[uuid.UUID]map[uuid.UUID]models.VoteChoice)
+	for _, vote := range votes {
+		if slotVotes[vote.TimeSlotID] == nil {
+			slotVotes[vote.TimeSlotID] = make(map[uuid.UUID]models.VoteChoice)
+		}
+		slotVotes[vote.TimeSlotID][vote.UserID] = vote.Choice
+		if mode != models.ScoringAvailability {
+			addRespondent(vote.UserID)
+		}
 	}
 
-	// Get all unique users
-	var uniqueUserIDs []uuid.UUID
-	for userID := range userIDs {
-		uniqueUserIDs = append(uniqueUserIDs, userID)
+	userMap, buffers, err := s.respondents(ctx, eventID, respondentIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	users, err := s.userRepo.GetByIDs(ctx, uniqueUserIDs)
+	groups, err := s.groupService.EventGroupMembers(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create a map of user IDs to User objects for quick lookup
-	userMap := make(map[uuid.UUID]*models.User)
-	for _, user := range users {
-		userMap[user.ID] = user
+	// Meetings respondents already committed to around the proposed slots
+	confirmed := make(map[uuid.UUID][]timeutil.TimeRange)
+	if len(userAvailabilities) > 0 {
+		window := slotSpan(timeSlots).Pad(maxBuffer(event, buffers))
+		availableIDs := make([]uuid.UUID, 0, len(userAvailabilities))
+		for userID := range userAvailabilities {
+			availableIDs = append(availableIDs, userID)
+		}
+		meetings, err := s.eventRepo.ListConfirmedMeetings(ctx, availableIDs, eventID, window.Start, window.End)
+		if err != nil {
+			return nil, err
+		}
+		for _, meeting := range meetings {
+			confirmed[meeting.UserID] = append(confirmed[meeting.UserID], timeutil.TimeRange{Start: meeting.StartTime, End: meeting.EndTime})
+		}
 	}
 
-	// Calculate recommendations
+	span.SetAttributes(
+		attribute.Int("timeslots.count", len(timeSlots)),
+		attribute.Int("availabilities.count", len(availabilities)),
+		attribute.Int("votes.count", len(votes)),
+		attribute.Int("users.count", len(userMap)),
+	)
+
+	// Calculate recommendations in a child span so the scoring loop can be told apart from the queries
+	_, scoreSpan := startSpan(ctx, "RecommendationService.scoreTimeSlots")
 	var recommendations []models.Recommendation
 
 	for _, slot := range timeSlots {
@@ -96,37 +192,35 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, eventID
 			continue
 		}
 
+		// If the event needs a resource and none is free, skip this slot too
+		var resource *models.Resource
+		if schedule != nil {
+			if resource = schedule.Free(slot.StartTime, meetingEndTime); resource == nil {
+				continue
+			}
+		}
+
 		var attendees []models.UserResponse
+		var tight []models.UserResponse
+		var maybe []models.UserResponse
 		var nonAttendees []models.UserResponse
 
-		// Check each user's availability for this slot
-		for userID, userAvails := range userAvailabilities {
-			user, exists := userMap[userID]
+		meeting := timeutil.TimeRange{Start: slot.StartTime, End: meetingEndTime}
+		for _, userID := range respondentIDs {
+			userResponse, exists := userMap[userID]
 			if !exists {
 				continue
 			}
 
-			userResponse := models.UserResponse{
-				ID:    user.ID,
-				Name:  user.Name,
-				Email: user.Email,
-			}
-
-			// Check if the user is available for this slot
-			available := false
-			for _, avail := range userAvails {
-				// The user is available if the slot starts after or at the same time as their availability start
-				// and the meeting ends before or at the same time as their availability end
-				if (slot.StartTime.Equal(avail.StartTime) || slot.StartTime.After(avail.StartTime)) &&
-					(meetingEndTime.Equal(avail.EndTime) || meetingEndTime.Before(avail.EndTime)) {
-					available = true
-					break
-				}
-			}
-
-			if available {
+			before, after := userBuffers(event, buffers[userID])
+			switch respondentAttendance(mode, meeting, before, after, slotVotes[slot.ID], userID, userAvailabilities[userID], confirmed[userID]) {
+			case attendanceYes:
 				attendees = append(attendees, userResponse)
-			} else {
+			case attendanceTight:
+				tight = append(tight, userResponse)
+			case attendanceMaybe:
+				maybe = append(maybe, userResponse)
+			default:
 				nonAttendees = append(nonAttendees, userResponse)
 			}
 		}
@@ -138,20 +232,278 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, eventID
 			EndTime:   meetingEndTime, // Use the calculated meeting end time
 		}
 
+		// Respondents who only fit without buffers count as non-attendees, but
+		// tell the organizer whether skipping the buffers would reach the quorum
+		meetsQuorum, missingGroups := rule.Check(attendees)
+		meetsQuorumWithoutBuffers, _ := rule.Check(append(append([]models.UserResponse(nil), attendees...), tight...))
 		recommendations = append(recommendations, models.Recommendation{
-			TimeSlot:     timeSlotResponse,
-			Attendees:    attendees,
-			NonAttendees: nonAttendees,
-			Score:        len(attendees),
+			TimeSlot:                 timeSlotResponse,
+			Attendees:                attendees,
+			Tight:                    tight,
+			Maybe:                    maybe,
+			NonAttendees:             nonAttendees,
+			Groups:                   groupAttendance(groups, attendees),
+			Votes:                    tally(slotVotes[slot.ID]),
+			Resource:                 resource,
+			Score:                    len(attendees),
+			MeetsQuorum:              meetsQuorum,
+			MissingGroups:            missingGroups,
+			ScoreWithoutBuffers:      len(attendees) + len(tight),
+			OnlyViableWithoutBuffers: !meetsQuorum && meetsQuorumWithoutBuffers,
 		})
 	}
 
-	// Sort recommendations by score (number of attendees) in descending order
-	sort.Slice(recommendations, func(i, j int) bool {
-		return recommendations[i].Score > recommendations[j].Score
+	scoreSpan.SetAttributes(attribute.Int("recommendations.count", len(recommendations)))
+	scoreSpan.End()
+
+	// Sort recommendations with quorum first, then by score (number of attendees)
+	// in descending order, preferring slots more people might make among equal scores
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].MeetsQuorum != recommendations[j].MeetsQuorum {
+			return recommendations[i].MeetsQuorum
+		}
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return len(recommendations[i].Maybe) > len(recommendations[j].Maybe)
 	})
 
 	return &models.RecommendationResponse{
+		Scoring:         mode,
+		Quorum:          rule.Attendees,
 		Recommendations: recommendations,
 	}, nil
 }
+
+// FindRecommendations returns the recommendations for an event that pass the quorum filter
+func (s *RecommendationService) FindRecommendations(ctx context.Context, eventID uuid.UUID, filter QuorumFilter) (*models.RecommendationResponse, error) {
+	response, err := s.GetRecommendations(ctx, eventID)
+	if err != nil || filter == QuorumAny {
+		return response, err
+	}
+
+	filtered := []models.Recommendation{}
+	for _, recommendation := range response.Recommendations {
+		if recommendation.MeetsQuorum == (filter == QuorumMet) {
+			filtered = append(filtered, recommendation)
+		}
+	}
+	response.Recommendations = filtered
+	return response, nil
+}
+
+// QuorumRule resolves what an event's time slots need to have quorum. A
+// percentage quorum is taken of the invited participants and rounded up.
+func (s *RecommendationService) QuorumRule(ctx context.Context, event *models.Event) (QuorumRule, error) {
+	rule := QuorumRule{Attendees: quorum(event.Quorum), Groups: event.RequiredGroups}
+	if event.QuorumPercent > 0 {
+		participants, err := s.participantRepo.GetByEventID(ctx, event.ID)
+		if err != nil {
+			return QuorumRule{}, err
+		}
+		rule.Attendees = quorum((len(participants)*event.QuorumPercent + 99) / 100)
+	}
+	return rule, nil
+}
+
+// Check reports whether attendees meet the rule, and names the required
+// groups that are short of attendees
+func (r QuorumRule) Check(attendees []models.UserResponse) (bool, []string) {
+	attending := make(map[uuid.UUID]bool, len(attendees))
+	for _, attendee := range attendees {
+		attending[attendee.ID] = true
+	}
+
+	var missing []string
+	for _, group := range r.Groups {
+		count := 0
+		for _, userID := range group.UserIDs {
+			if attending[userID] {
+				count++
+			}
+		}
+		if count < quorum(group.Min) {
+			missing = append(missing, group.Name)
+		}
+	}
+	return len(attendees) >= r.Attendees && len(missing) == 0, missing
+}
+
+// topRecommendation returns the recommendation to finalize: the one with the
+// most attendees among those with quorum, or among all of them when none has
+// quorum, preferring the earliest slot on a tie
+func topRecommendation(recommendations []models.Recommendation) *models.Recommendation {
+	var top *models.Recommendation
+	for i := range recommendations {
+		r := &recommendations[i]
+		if top == nil || (r.MeetsQuorum && !top.MeetsQuorum) ||
+			(r.MeetsQuorum == top.MeetsQuorum && (r.Score > top.Score ||
+				(r.Score == top.Score && r.TimeSlot.StartTime.Before(top.TimeSlot.StartTime)))) {
+			top = r
+		}
+	}
+	return top
+}
+
+// resourceSchedule loads the resources an event could be held with over the
+// span of its time slots, or returns nil when the event needs no resource
+func (s *RecommendationService) resourceSchedule(ctx context.Context, event *models.Event, timeSlots []*models.TimeSlot) (*ResourceSchedule, error) {
+	if event.ResourceRequirement == nil {
+		return nil, nil
+	}
+
+	span := slotSpan(timeSlots)
+	return s.resourceService.Schedule(ctx, event.ID, event.ResourceRequirement, span.Start, span.End)
+}
+
+// slotSpan returns the time range from the earliest start to the latest end of the slots
+func slotSpan(timeSlots []*models.TimeSlot) timeutil.TimeRange {
+	span := timeutil.TimeRange{Start: timeSlots[0].StartTime, End: timeSlots[0].EndTime}
+	for _, slot := range timeSlots[1:] {
+		if slot.StartTime.Before(span.Start) {
+			span.Start = slot.StartTime
+		}
+		if slot.EndTime.After(span.End) {
+			span.End = slot.EndTime
+		}
+	}
+	return span
+}
+
+// userBuffers returns the time a respondent needs free before and after the
+// meeting: the event's buffers, or their own default where that is longer
+func userBuffers(event *models.Event, defaultBuffer int) (before, after time.Duration) {
+	return time.Duration(max(event.BufferBefore, defaultBuffer)) * time.Minute,
+		time.Duration(max(event.BufferAfter, defaultBuffer)) * time.Minute
+}
+
+// maxBuffer returns the longest buffers any respondent needs around the meeting
+func maxBuffer(event *models.Event, buffers map[uuid.UUID]int) (before, after time.Duration) {
+	longest := 0
+	for _, buffer := range buffers {
+		longest = max(longest, buffer)
+	}
+	return userBuffers(event, longest)
+}
+
+// attendance is how well a respondent can make a meeting, from worst to best
+type attendance int
+
+const (
+	attendanceNo    attendance = iota
+	attendanceMaybe            // Voted maybe
+	attendanceTight            // Free for the meeting, but not for its buffers
+	attendanceYes
+)
+
+// respondentAttendance decides whether a respondent can attend a meeting. A
+// vote on the slot answers directly unless only availability is scored, and
+// availability ranges answer unless only votes are scored. Availability must
+// cover the meeting padded by the respondent's buffers, and the padded meeting
+// must not even touch one of their confirmed meetings. Without buffers, back
+// to back meetings are fine.
+func respondentAttendance(mode models.ScoringMode, meeting timeutil.TimeRange, before, after time.Duration, votes map[uuid.UUID]models.VoteChoice, userID uuid.UUID, availabilities []*models.Availability, confirmed []timeutil.TimeRange) attendance {
+	if mode != models.ScoringAvailability {
+		switch votes[userID] {
+		case models.VoteYes:
+			return attendanceYes
+		case models.VoteMaybe:
+			return attendanceMaybe
+		case models.VoteNo:
+			return attendanceNo
+		}
+	}
+	if mode == models.ScoringVotes {
+		return attendanceNo
+	}
+
+	padded := meeting.Pad(before, after)
+	result := attendanceNo
+	for _, avail := range availabilities {
+		free := timeutil.TimeRange{Start: avail.StartTime, End: avail.EndTime}
+		if free.Contains(padded) {
+			result = attendanceYes
+			break
+		}
+		if free.Contains(meeting) {
+			result = attendanceTight
+		}
+	}
+
+	for _, other := range confirmed {
+		if other.Intersects(meeting) {
+			return attendanceNo
+		}
+		if result == attendanceYes && (before > 0 || after > 0) && other.Overlaps(padded) {
+			result = attendanceTight
+		}
+	}
+	return result
+}
+
+// groupAttendance counts the attendees among the members of each invited group
+func groupAttendance(groups []EventGroupMembers, attendees []models.UserResponse) []models.GroupAttendance {
+	if len(groups) == 0 {
+		return nil
+	}
+	attending := make(map[uuid.UUID]bool, len(attendees))
+	for _, attendee := range attendees {
+		attending[attendee.ID] = true
+	}
+
+	summaries := make([]models.GroupAttendance, len(groups))
+	for i, group := range groups {
+		summaries[i] = models.GroupAttendance{GroupID: group.Group.ID, Name: group.Group.Name, Members: len(group.UserIDs)}
+		for _, userID := range group.UserIDs {
+			if attending[userID] {
+				summaries[i].Attending++
+			}
+		}
+	}
+	return summaries
+}
+
+// tally counts the votes cast on a slot
+func tally(votes map[uuid.UUID]models.VoteChoice) models.VoteTally {
+	var t models.VoteTally
+	for _, choice := range votes {
+		switch choice {
+		case models.VoteYes:
+			t.Yes++
+		case models.VoteNo:
+			t.No++
+		case models.VoteMaybe:
+			t.Maybe++
+		}
+	}
+	return t
+}
+
+// respondents resolves the IDs of everyone who responded, looking up guests
+// of the event for IDs that are not users, along with the users' default buffers
+func (s *RecommendationService) respondents(ctx context.Context, eventID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]models.UserResponse, map[uuid.UUID]int, error) {
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Guests have no default buffer
+	respondents := make(map[uuid.UUID]models.UserResponse, len(ids))
+	buffers := make(map[uuid.UUID]int, len(users))
+	for _, user := range users {
+		respondents[user.ID] = models.UserResponse{ID: user.ID, Name: user.Name, Email: user.Email}
+		buffers[user.ID] = user.DefaultBuffer
+	}
+	if len(respondents) == len(ids) {
+		return respondents, buffers, nil
+	}
+
+	guests, err := s.guestRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, guest := range guests {
+		respondents[guest.ID] = models.UserResponse{ID: guest.ID, Name: guest.Name, Email: guest.Email, Guest: true}
+	}
+	return respondents, buffers, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/npkanaka/meeting-scheduler/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockEventRepository is a mock for the EventRepository
@@ -36,19 +37,64 @@ func (m *MockEventRepository) Update(ctx context.Context, event *models.Event) e
 	return args.Error(0)
 }
 
-func (m *MockEventRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockEventRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Event, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) Restore(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockEventRepository) List(ctx context.Context, limit, offset int) ([]*models.Event, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockEventRepository) Purge(ctx context.Context, before time.Time) ([]*models.Event, error) {
+	args := m.Called(ctx, before)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.Event), args.Error(1)
 }
 
+func (m *MockEventRepository) List(ctx context.Context, filter models.EventFilter) ([]*models.Event, models.PageInfo, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, models.PageInfo{}, args.Error(2)
+	}
+	return args.Get(0).([]*models.Event), args.Get(1).(models.PageInfo), args.Error(2)
+}
+
+func (m *MockEventRepository) ListByDeadline(ctx context.Context, from, to time.Time) ([]*models.Event, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) ClaimPastDeadline(ctx context.Context, now time.Time, limit int) ([]*models.Event, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) ListConfirmedMeetings(ctx context.Context, userIDs []uuid.UUID, excludeEventID uuid.UUID, from, to time.Time) ([]*models.ConfirmedMeeting, error) {
+	args := m.Called(ctx, userIDs, excludeEventID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ConfirmedMeeting), args.Error(1)
+}
+
 // MockTimeSlotRepository is a mock for the TimeSlotRepository
 type MockTimeSlotRepository struct {
 	mock.Mock
@@ -72,11 +118,32 @@ func (m *MockTimeSlotRepository) Update(ctx context.Context, slot *models.TimeSl
 	return args.Error(0)
 }
 
-func (m *MockTimeSlotRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockTimeSlotRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockTimeSlotRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.TimeSlot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TimeSlot), args.Error(1)
+}
+
+func (m *MockTimeSlotRepository) Restore(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockTimeSlotRepository) Purge(ctx context.Context, before time.Time) ([]*models.TimeSlot, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TimeSlot), args.Error(1)
+}
+
 func (m *MockTimeSlotRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.TimeSlot, error) {
 	args := m.Called(ctx, eventID)
 	if args.Get(0) == nil {
@@ -85,6 +152,14 @@ func (m *MockTimeSlotRepository) GetByEventID(ctx context.Context, eventID uuid.
 	return args.Get(0).([]*models.TimeSlot), args.Error(1)
 }
 
+func (m *MockTimeSlotRepository) ListByEventID(ctx context.Context, eventID uuid.UUID, page models.PageRequest) ([]*models.TimeSlot, models.PageInfo, error) {
+	args := m.Called(ctx, eventID, page)
+	if args.Get(0) == nil {
+		return nil, models.PageInfo{}, args.Error(2)
+	}
+	return args.Get(0).([]*models.TimeSlot), args.Get(1).(models.PageInfo), args.Error(2)
+}
+
 // MockAvailabilityRepository is a mock for the AvailabilityRepository
 type MockAvailabilityRepository struct {
 	mock.Mock
@@ -95,16 +170,45 @@ func (m *MockAvailabilityRepository) Create(ctx context.Context, availability *m
 	return args.Error(0)
 }
 
+func (m *MockAvailabilityRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Availability, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Availability), args.Error(1)
+}
+
 func (m *MockAvailabilityRepository) Update(ctx context.Context, availability *models.Availability) error {
 	args := m.Called(ctx, availability)
 	return args.Error(0)
 }
 
-func (m *MockAvailabilityRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockAvailabilityRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Availability, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Availability), args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) Restore(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockAvailabilityRepository) Purge(ctx context.Context, before time.Time) ([]*models.Availability, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Availability), args.Error(1)
+}
+
 func (m *MockAvailabilityRepository) GetByUserAndEvent(ctx context.Context, userID, eventID uuid.UUID) ([]*models.Availability, error) {
 	args := m.Called(ctx, userID, eventID)
 	if args.Get(0) == nil {
@@ -121,6 +225,14 @@ func (m *MockAvailabilityRepository) GetByEventID(ctx context.Context, eventID u
 	return args.Get(0).([]*models.Availability), args.Error(1)
 }
 
+func (m *MockAvailabilityRepository) ListByEventID(ctx context.Context, eventID uuid.UUID, page models.PageRequest) ([]*models.Availability, models.PageInfo, error) {
+	args := m.Called(ctx, eventID, page)
+	if args.Get(0) == nil {
+		return nil, models.PageInfo{}, args.Error(2)
+	}
+	return args.Get(0).([]*models.Availability), args.Get(1).(models.PageInfo), args.Error(2)
+}
+
 // MockUserRepository is a mock for the UserRepository
 type MockUserRepository struct {
 	mock.Mock
@@ -157,6 +269,62 @@ func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+// FakeTransactor runs transactional functions directly
+type FakeTransactor struct{}
+
+func (FakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (FakeTransactor) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// FakeOutboxRepository records the messages written to the outbox
+type FakeOutboxRepository struct {
+	Messages []*models.OutboxMessage
+}
+
+func (f *FakeOutboxRepository) Create(ctx context.Context, message *models.OutboxMessage) error {
+	if message.ID == uuid.Nil {
+		message.ID = uuid.New()
+	}
+	f.Messages = append(f.Messages, message)
+	return nil
+}
+
+func (f *FakeOutboxRepository) ClaimPending(ctx context.Context, now time.Time, limit int) ([]*models.OutboxMessage, error) {
+	var pending []*models.OutboxMessage
+	for _, message := range f.Messages {
+		if message.ProcessedAt == nil && message.DeadAt == nil && !message.NextAttemptAt.After(now) && len(pending) < limit {
+			pending = append(pending, message)
+		}
+	}
+	return pending, nil
+}
+
+func (f *FakeOutboxRepository) MarkProcessed(ctx context.Context, id uuid.UUID, processedAt time.Time) error {
+	for _, message := range f.Messages {
+		if message.ID == id {
+			message.ProcessedAt = &processedAt
+		}
+	}
+	return nil
+}
+
+func (f *FakeOutboxRepository) RecordFailure(ctx context.Context, message *models.OutboxMessage) error {
+	return nil
+}
+
+// Topics returns the topics of the recorded messages in order
+func (f *FakeOutboxRepository) Topics() []string {
+	topics := make([]string, len(f.Messages))
+	for i, message := range f.Messages {
+		topics[i] = message.Topic
+	}
+	return topics
+}
+
 func TestGetRecommendations(t *testing.T) {
 	// Setup mocks
 	mockEventRepo := new(MockEventRepository)
@@ -170,6 +338,11 @@ func TestGetRecommendations(t *testing.T) {
 		mockTimeSlotRepo,
 		mockAvailabilityRepo,
 		mockUserRepo,
+		&FakeGuestRepository{},
+		&FakeVoteRepository{},
+		&FakeParticipantRepository{},
+		emptyResourceService(),
+		emptyGroupService(),
 	)
 
 	ctx := context.Background()
@@ -259,10 +432,11 @@ func TestGetRecommendations(t *testing.T) {
 	testUsers := []*models.User{user1, user2}
 
 	// Setup expectations
-	mockEventRepo.On("GetByID", ctx, eventID).Return(testEvent, nil)
-	mockTimeSlotRepo.On("GetByEventID", ctx, eventID).Return(testTimeSlots, nil)
-	mockAvailabilityRepo.On("GetByEventID", ctx, eventID).Return(allAvailability, nil)
-	mockUserRepo.On("GetByIDs", ctx, mock.MatchedBy(func(ids []uuid.UUID) bool {
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(testEvent, nil)
+	mockEventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	mockTimeSlotRepo.On("GetByEventID", mock.Anything, eventID).Return(testTimeSlots, nil)
+	mockAvailabilityRepo.On("GetByEventID", mock.Anything, eventID).Return(allAvailability, nil)
+	mockUserRepo.On("GetByIDs", mock.Anything, mock.MatchedBy(func(ids []uuid.UUID) bool {
 		// Check if the slice contains both user IDs, regardless of order
 		if len(ids) != 2 {
 			return false
@@ -305,3 +479,165 @@ func TestGetRecommendations(t *testing.T) {
 	mockAvailabilityRepo.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
 }
+
+func TestGetRecommendationsRespectsBuffersAndConfirmedMeetings(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	userRepo := new(MockUserRepository)
+	recommendationService := service.NewRecommendationService(eventRepo, timeslotRepo, availabilityRepo, userRepo, &FakeGuestRepository{}, &FakeVoteRepository{}, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+
+	at := func(hour, minute int) time.Time { return time.Date(2025, 1, 15, hour, minute, 0, 0, time.UTC) }
+	event := &models.Event{ID: uuid.New(), CreatorID: uuid.New(), Duration: 60, Quorum: 2, BufferBefore: 15, BufferAfter: 15, Status: models.EventStatusActive}
+	slot := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: at(10, 0), EndTime: at(11, 0)}
+
+	alice := &models.User{ID: uuid.New(), Name: "Alice"}                  // Free for the meeting and its buffers
+	bob := &models.User{ID: uuid.New(), Name: "Bob"}                      // Free for the meeting only
+	carol := &models.User{ID: uuid.New(), Name: "Carol"}                  // Has a meeting right after
+	dave := &models.User{ID: uuid.New(), Name: "Dave"}                    // Has a meeting during
+	erin := &models.User{ID: uuid.New(), Name: "Erin", DefaultBuffer: 60} // Needs longer buffers than the event's
+	users := []*models.User{alice, bob, carol, dave, erin}
+
+	free := map[*models.User][2]time.Time{
+		alice: {at(9, 45), at(11, 15)},
+		bob:   {at(10, 0), at(11, 0)},
+		carol: {at(9, 0), at(12, 0)},
+		dave:  {at(9, 0), at(12, 0)},
+		erin:  {at(9, 30), at(11, 30)},
+	}
+	var availabilities []*models.Availability
+	for _, user := range users {
+		availabilities = append(availabilities, &models.Availability{ID: uuid.New(), EventID: event.ID, UserID: user.ID, StartTime: free[user][0], EndTime: free[user][1]})
+	}
+
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	// Confirmed meetings are looked up as far around the slots as the longest buffer reaches
+	eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, event.ID, at(9, 0), at(12, 0)).Return([]*models.ConfirmedMeeting{
+		{UserID: carol.ID, EventID: uuid.New(), StartTime: at(11, 0), EndTime: at(12, 0)},
+		{UserID: dave.ID, EventID: uuid.New(), StartTime: at(10, 30), EndTime: at(11, 30)},
+	}, nil)
+	timeslotRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.TimeSlot{slot}, nil)
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return(availabilities, nil)
+	userRepo.On("GetByIDs", mock.Anything, mock.Anything).Return(users, nil)
+
+	result, err := recommendationService.GetRecommendations(context.Background(), event.ID)
+	require.NoError(t, err)
+	require.Len(t, result.Recommendations, 1)
+
+	recommendation := result.Recommendations[0]
+	names := func(responses []models.UserResponse) []string {
+		var names []string
+		for _, response := range responses {
+			names = append(names, response.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"Alice"}, names(recommendation.Attendees))
+	assert.Equal(t, []string{"Bob", "Carol", "Erin"}, names(recommendation.Tight))
+	assert.Equal(t, []string{"Dave"}, names(recommendation.NonAttendees))
+	assert.Equal(t, 1, recommendation.Score)
+	assert.Equal(t, 4, recommendation.ScoreWithoutBuffers)
+	assert.True(t, recommendation.OnlyViableWithoutBuffers)
+}
+
+func TestGetRecommendationsAllowsBackToBackMeetingsWithoutBuffers(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	userRepo := new(MockUserRepository)
+	recommendationService := service.NewRecommendationService(eventRepo, timeslotRepo, availabilityRepo, userRepo, &FakeGuestRepository{}, &FakeVoteRepository{}, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+
+	at := func(hour int) time.Time { return time.Date(2025, 1, 15, hour, 0, 0, 0, time.UTC) }
+	event := &models.Event{ID: uuid.New(), CreatorID: uuid.New(), Duration: 60, Quorum: 1, Status: models.EventStatusActive}
+	slot := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: at(10), EndTime: at(11)}
+	alice := &models.User{ID: uuid.New(), Name: "Alice"}
+
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	// Alice's other meetings end as this one starts and start as it ends
+	eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, event.ID, mock.Anything, mock.Anything).Return([]*models.ConfirmedMeeting{
+		{UserID: alice.ID, EventID: uuid.New(), StartTime: at(9), EndTime: at(10)},
+		{UserID: alice.ID, EventID: uuid.New(), StartTime: at(11), EndTime: at(12)},
+	}, nil)
+	timeslotRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.TimeSlot{slot}, nil)
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.Availability{
+		{ID: uuid.New(), EventID: event.ID, UserID: alice.ID, StartTime: at(9), EndTime: at(12)},
+	}, nil)
+	userRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*models.User{alice}, nil)
+
+	result, err := recommendationService.GetRecommendations(context.Background(), event.ID)
+	require.NoError(t, err)
+	require.Len(t, result.Recommendations, 1)
+
+	recommendation := result.Recommendations[0]
+	require.Len(t, recommendation.Attendees, 1)
+	assert.Equal(t, alice.ID, recommendation.Attendees[0].ID)
+	assert.Empty(t, recommendation.Tight)
+	assert.Equal(t, 1, recommendation.Score)
+}
+
+func TestRecommendationsAreClassifiedByQuorumAndRequiredGroups(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	userRepo := new(MockUserRepository)
+	participantRepo := &FakeParticipantRepository{}
+	recommendationService := service.NewRecommendationService(eventRepo, timeslotRepo, availabilityRepo, userRepo, &FakeGuestRepository{}, &FakeVoteRepository{}, participantRepo, emptyResourceService(), emptyGroupService())
+
+	at := func(hour int) time.Time { return time.Date(2025, 1, 15, hour, 0, 0, 0, time.UTC) }
+	web := &models.User{ID: uuid.New(), Name: "Wendy"}
+	mobile := &models.User{ID: uuid.New(), Name: "Mo"}
+	platform := &models.User{ID: uuid.New(), Name: "Pat"}
+	users := []*models.User{web, mobile, platform}
+
+	// Half of five invited participants rounds up to three attendees, one of them from Platform
+	event := &models.Event{
+		ID: uuid.New(), CreatorID: uuid.New(), Duration: 60, QuorumPercent: 50, Status: models.EventStatusActive,
+		RequiredGroups: []models.RequiredGroup{{Name: "Platform", UserIDs: []uuid.UUID{platform.ID}, Min: 1}},
+	}
+	for i := 0; i < 5; i++ {
+		require.NoError(t, participantRepo.Create(context.Background(), &models.EventParticipant{EventID: event.ID, UserID: uuid.New()}))
+	}
+
+	morning := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: at(9), EndTime: at(10)}
+	noon := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: at(12), EndTime: at(13)}
+	afternoon := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: at(15), EndTime: at(16)}
+	free := func(user *models.User, start, end int) *models.Availability {
+		return &models.Availability{ID: uuid.New(), EventID: event.ID, UserID: user.ID, StartTime: at(start), EndTime: at(end)}
+	}
+	availabilities := []*models.Availability{
+		free(web, 9, 16),
+		free(mobile, 9, 13),
+		free(platform, 12, 16),
+	}
+
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	timeslotRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.TimeSlot{morning, noon, afternoon}, nil)
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return(availabilities, nil)
+	userRepo.On("GetByIDs", mock.Anything, mock.Anything).Return(users, nil)
+
+	all, err := recommendationService.FindRecommendations(context.Background(), event.ID, service.QuorumAny)
+	require.NoError(t, err)
+	assert.Equal(t, 3, all.Quorum)
+	require.Len(t, all.Recommendations, 3)
+
+	// Only noon has everyone; the morning lacks Platform and the afternoon has too few attendees
+	assert.Equal(t, noon.ID, all.Recommendations[0].TimeSlot.ID)
+	assert.True(t, all.Recommendations[0].MeetsQuorum)
+	assert.Empty(t, all.Recommendations[0].MissingGroups)
+	assert.Equal(t, morning.ID, all.Recommendations[1].TimeSlot.ID)
+	assert.False(t, all.Recommendations[1].MeetsQuorum)
+	assert.Equal(t, []string{"Platform"}, all.Recommendations[1].MissingGroups)
+	assert.Equal(t, afternoon.ID, all.Recommendations[2].TimeSlot.ID)
+	assert.False(t, all.Recommendations[2].MeetsQuorum)
+	assert.Empty(t, all.Recommendations[2].MissingGroups)
+
+	met, err := recommendationService.FindRecommendations(context.Background(), event.ID, service.QuorumMet)
+	require.NoError(t, err)
+	require.Len(t, met.Recommendations, 1)
+	assert.Equal(t, noon.ID, met.Recommendations[0].TimeSlot.ID)
+
+	unmet, err := recommendationService.FindRecommendations(context.Background(), event.ID, service.QuorumUnmet)
+	require.NoError(t, err)
+	assert.Len(t, unmet.Recommendations, 2)
+}
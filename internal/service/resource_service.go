@@ -0,0 +1,340 @@
+// internal/service/resource_service.go
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ResourceService manages the resource catalog and resource bookings
+type ResourceService struct {
+	resourceRepo repository.ResourceRepository
+	bookingRepo  repository.ResourceBookingRepository
+	transactor   repository.Transactor
+}
+
+// NewResourceService creates a new ResourceService
+func NewResourceService(
+	resourceRepo repository.ResourceRepository,
+	bookingRepo repository.ResourceBookingRepository,
+	transactor repository.Transactor,
+) *ResourceService {
+	return &ResourceService{
+		resourceRepo: resourceRepo,
+		bookingRepo:  bookingRepo,
+		transactor:   transactor,
+	}
+}
+
+// CreateResource adds a resource to the catalog
+func (s *ResourceService) CreateResource(ctx context.Context, req *models.ResourceRequest) (*models.Resource, error) {
+	ctx, span := startSpan(ctx, "ResourceService.CreateResource")
+	defer span.End()
+
+	now := time.Now()
+	resource := &models.Resource{
+		Name:       req.Name,
+		Kind:       req.Kind,
+		Capacity:   req.Capacity,
+		Attributes: normalizeAttributes(req.Attributes),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.resourceRepo.Create(ctx, resource); err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("resource.id", resource.ID.String()))
+
+	return resource, nil
+}
+
+// GetResource retrieves a resource by ID
+func (s *ResourceService) GetResource(ctx context.Context, id uuid.UUID) (*models.Resource, error) {
+	ctx, span := startSpan(ctx, "ResourceService.GetResource", attribute.String("resource.id", id.String()))
+	defer span.End()
+
+	return s.resourceRepo.GetByID(ctx, id)
+}
+
+// UpdateResource changes a resource. Existing bookings are kept.
+func (s *ResourceService) UpdateResource(ctx context.Context, id uuid.UUID, req *models.ResourceRequest) (*models.Resource, error) {
+	ctx, span := startSpan(ctx, "ResourceService.UpdateResource", attribute.String("resource.id", id.String()))
+	defer span.End()
+
+	resource, err := s.resourceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resource.Name = req.Name
+	resource.Kind = req.Kind
+	resource.Capacity = req.Capacity
+	resource.Attributes = normalizeAttributes(req.Attributes)
+	resource.UpdatedAt = time.Now()
+	if err := s.resourceRepo.Update(ctx, resource); err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// DeleteResource removes a resource together with its bookings
+func (s *ResourceService) DeleteResource(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "ResourceService.DeleteResource", attribute.String("resource.id", id.String()))
+	defer span.End()
+
+	if _, err := s.resourceRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.resourceRepo.Delete(ctx, id)
+}
+
+// ListResources returns the resources of a kind, or all resources when kind is empty
+func (s *ResourceService) ListResources(ctx context.Context, kind models.ResourceKind) ([]*models.Resource, error) {
+	ctx, span := startSpan(ctx, "ResourceService.ListResources", attribute.String("resource.kind", string(kind)))
+	defer span.End()
+
+	resources, err := s.resourceRepo.List(ctx, kind)
+	span.SetAttributes(attribute.Int("resources.count", len(resources)))
+	return resources, err
+}
+
+// BlockResource books a resource for a period outside of any event, such as maintenance
+func (s *ResourceService) BlockResource(ctx context.Context, resourceID uuid.UUID, req *models.ResourceBookingRequest) (*models.ResourceBooking, error) {
+	ctx, span := startSpan(ctx, "ResourceService.BlockResource", attribute.String("resource.id", resourceID.String()))
+	defer span.End()
+
+	if !req.EndTime.After(req.StartTime) {
+		return nil, errors.ErrInvalidTimeRange
+	}
+
+	booking := &models.ResourceBooking{
+		ResourceID: resourceID,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+		Note:       req.Note,
+		CreatedAt:  time.Now(),
+	}
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		free, err := s.lockIfFree(ctx, resourceID, nil, req.StartTime, req.EndTime)
+		if err != nil {
+			return err
+		}
+		if !free {
+			return errors.ErrResourceUnavailable
+		}
+		return s.bookingRepo.Create(ctx, booking)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+// ListBookings returns the bookings of a resource in time order
+func (s *ResourceService) ListBookings(ctx context.Context, resourceID uuid.UUID) ([]*models.ResourceBooking, error) {
+	ctx, span := startSpan(ctx, "ResourceService.ListBookings", attribute.String("resource.id", resourceID.String()))
+	defer span.End()
+
+	if _, err := s.resourceRepo.GetByID(ctx, resourceID); err != nil {
+		return nil, err
+	}
+	return s.bookingRepo.ListByResourceID(ctx, resourceID)
+}
+
+// CancelBooking removes a booking, freeing the resource
+func (s *ResourceService) CancelBooking(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "ResourceService.CancelBooking", attribute.String("booking.id", id.String()))
+	defer span.End()
+
+	booking, err := s.bookingRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	// Bookings of another organization's resources are not found either
+	if _, err := s.resourceRepo.GetByID(ctx, booking.ResourceID); err != nil {
+		if stderrors.Is(err, errors.ErrResourceNotFound) {
+			return errors.ErrResourceBookingNotFound
+		}
+		return err
+	}
+	return s.bookingRepo.Delete(ctx, id)
+}
+
+// ReleaseForEvent frees the resources booked for an event. Run it inside the
+// transaction that deletes the event.
+func (s *ResourceService) ReleaseForEvent(ctx context.Context, eventID uuid.UUID) error {
+	ctx, span := startSpan(ctx, "ResourceService.ReleaseForEvent", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	return s.bookingRepo.DeleteByEventID(ctx, eventID)
+}
+
+// ResourceSchedule is a snapshot of the resources suitable for an event and
+// what they are booked for
+type ResourceSchedule struct {
+	resources []*models.Resource
+	bookings  map[uuid.UUID][]*models.ResourceBooking
+}
+
+// Schedule loads the resources meeting a requirement and their bookings in
+// [from, to). Bookings of the event itself are ignored, so an event's own
+// reservation does not hide the slot it was made for.
+func (s *ResourceService) Schedule(ctx context.Context, eventID uuid.UUID, req *models.ResourceRequirement, from, to time.Time) (*ResourceSchedule, error) {
+	resources, err := s.suitableResources(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(resources))
+	for i, resource := range resources {
+		ids[i] = resource.ID
+	}
+	bookings, err := s.bookingRepo.ListOverlapping(ctx, ids, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &ResourceSchedule{resources: resources, bookings: make(map[uuid.UUID][]*models.ResourceBooking)}
+	for _, booking := range bookings {
+		if booking.EventID != nil && *booking.EventID == eventID {
+			continue
+		}
+		schedule.bookings[booking.ResourceID] = append(schedule.bookings[booking.ResourceID], booking)
+	}
+	return schedule, nil
+}
+
+// Free returns the smallest suitable resource that is free for [start, end), or nil
+func (rs *ResourceSchedule) Free(start, end time.Time) *models.Resource {
+	for _, resource := range rs.resources {
+		if !overlapsAny(rs.bookings[resource.ID], start, end) {
+			return resource
+		}
+	}
+	return nil
+}
+
+// BookForEvent books the smallest suitable resource that is free for an
+// event's time slot. Each candidate is locked before it is checked, so
+// concurrent bookings cannot take the same resource; run it inside the
+// transaction that finalizes the event.
+func (s *ResourceService) BookForEvent(ctx context.Context, event *models.Event, slot *models.TimeSlot, start, end time.Time) (*models.ResourceBooking, error) {
+	ctx, span := startSpan(ctx, "ResourceService.BookForEvent",
+		attribute.String("event.id", event.ID.String()),
+		attribute.String("timeslot.id", slot.ID.String()),
+	)
+	defer span.End()
+
+	resources, err := s.suitableResources(ctx, event.ResourceRequirement)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resource := range resources {
+		free, err := s.lockIfFree(ctx, resource.ID, &event.ID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if !free {
+			continue
+		}
+
+		booking := &models.ResourceBooking{
+			ResourceID: resource.ID,
+			EventID:    &event.ID,
+			TimeSlotID: &slot.ID,
+			StartTime:  start,
+			EndTime:    end,
+			CreatedAt:  time.Now(),
+		}
+		if err := s.bookingRepo.Create(ctx, booking); err != nil {
+			return nil, err
+		}
+		span.SetAttributes(attribute.String("resource.id", resource.ID.String()))
+		return booking, nil
+	}
+	return nil, errors.ErrResourceUnavailable
+}
+
+// lockIfFree locks a resource and reports whether it has no booking
+// overlapping [start, end) other than those of eventID
+func (s *ResourceService) lockIfFree(ctx context.Context, resourceID uuid.UUID, eventID *uuid.UUID, start, end time.Time) (bool, error) {
+	if err := s.resourceRepo.Lock(ctx, resourceID); err != nil {
+		return false, err
+	}
+	bookings, err := s.bookingRepo.ListOverlapping(ctx, []uuid.UUID{resourceID}, start, end)
+	if err != nil {
+		return false, err
+	}
+	for _, booking := range bookings {
+		if eventID == nil || booking.EventID == nil || *booking.EventID != *eventID {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// suitableResources returns the resources meeting a requirement, smallest first
+func (s *ResourceService) suitableResources(ctx context.Context, req *models.ResourceRequirement) ([]*models.Resource, error) {
+	resources, err := s.resourceRepo.ListSuitable(ctx, req.Kind, req.MinCapacity)
+	if err != nil {
+		return nil, err
+	}
+
+	required := normalizeAttributes(req.Attributes)
+	suitable := resources[:0]
+	for _, resource := range resources {
+		if hasAttributes(resource, required) {
+			suitable = append(suitable, resource)
+		}
+	}
+	return suitable, nil
+}
+
+// hasAttributes reports whether a resource has every required attribute
+func hasAttributes(resource *models.Resource, required []string) bool {
+	has := make(map[string]bool, len(resource.Attributes))
+	for _, attr := range resource.Attributes {
+		has[strings.ToLower(attr)] = true
+	}
+	for _, attr := range required {
+		if !has[attr] {
+			return false
+		}
+	}
+	return true
+}
+
+// overlapsAny reports whether any booking overlaps [start, end)
+func overlapsAny(bookings []*models.ResourceBooking, start, end time.Time) bool {
+	for _, booking := range bookings {
+		if booking.StartTime.Before(end) && booking.EndTime.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAttributes trims, lowercases and de-duplicates attributes
+func normalizeAttributes(attrs []string) []string {
+	normalized := make([]string, 0, len(attrs))
+	seen := make(map[string]bool, len(attrs))
+	for _, attr := range attrs {
+		attr = strings.ToLower(strings.TrimSpace(attr))
+		if attr != "" && !seen[attr] {
+			seen[attr] = true
+			normalized = append(normalized, attr)
+		}
+	}
+	return normalized
+}
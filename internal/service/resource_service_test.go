@@ -0,0 +1,274 @@
+package service_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/search"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeResourceRepository keeps resources in memory
+type FakeResourceRepository struct {
+	Resources []*models.Resource
+}
+
+func (f *FakeResourceRepository) Create(ctx context.Context, resource *models.Resource) error {
+	if resource.ID == uuid.Nil {
+		resource.ID = uuid.New()
+	}
+	f.Resources = append(f.Resources, resource)
+	return nil
+}
+
+func (f *FakeResourceRepository) Update(ctx context.Context, resource *models.Resource) error {
+	return nil
+}
+
+func (f *FakeResourceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (f *FakeResourceRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Resource, error) {
+	for _, resource := range f.Resources {
+		if resource.ID == id {
+			return resource, nil
+		}
+	}
+	return nil, apperrors.ErrResourceNotFound
+}
+
+func (f *FakeResourceRepository) List(ctx context.Context, kind models.ResourceKind) ([]*models.Resource, error) {
+	return f.Resources, nil
+}
+
+func (f *FakeResourceRepository) ListSuitable(ctx context.Context, kind models.ResourceKind, minCapacity int) ([]*models.Resource, error) {
+	var resources []*models.Resource
+	for _, resource := range f.Resources {
+		if resource.Kind == kind && resource.Capacity >= minCapacity {
+			resources = append(resources, resource)
+		}
+	}
+	sort.SliceStable(resources, func(i, j int) bool { return resources[i].Capacity < resources[j].Capacity })
+	return resources, nil
+}
+
+func (f *FakeResourceRepository) Lock(ctx context.Context, id uuid.UUID) error {
+	_, err := f.GetByID(ctx, id)
+	return err
+}
+
+// FakeResourceBookingRepository keeps resource bookings in memory
+type FakeResourceBookingRepository struct {
+	Bookings []*models.ResourceBooking
+}
+
+func (f *FakeResourceBookingRepository) Create(ctx context.Context, booking *models.ResourceBooking) error {
+	if booking.ID == uuid.Nil {
+		booking.ID = uuid.New()
+	}
+	f.Bookings = append(f.Bookings, booking)
+	return nil
+}
+
+func (f *FakeResourceBookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (f *FakeResourceBookingRepository) DeleteByEventID(ctx context.Context, eventID uuid.UUID) error {
+	var kept []*models.ResourceBooking
+	for _, booking := range f.Bookings {
+		if booking.EventID == nil || *booking.EventID != eventID {
+			kept = append(kept, booking)
+		}
+	}
+	f.Bookings = kept
+	return nil
+}
+
+func (f *FakeResourceBookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ResourceBooking, error) {
+	for _, booking := range f.Bookings {
+		if booking.ID == id {
+			return booking, nil
+		}
+	}
+	return nil, apperrors.ErrResourceBookingNotFound
+}
+
+func (f *FakeResourceBookingRepository) ListByResourceID(ctx context.Context, resourceID uuid.UUID) ([]*models.ResourceBooking, error) {
+	return f.ListOverlapping(ctx, []uuid.UUID{resourceID}, time.Time{}, time.Unix(1<<40, 0))
+}
+
+func (f *FakeResourceBookingRepository) ListOverlapping(ctx context.Context, resourceIDs []uuid.UUID, from, to time.Time) ([]*models.ResourceBooking, error) {
+	var bookings []*models.ResourceBooking
+	for _, booking := range f.Bookings {
+		for _, id := range resourceIDs {
+			if booking.ResourceID == id && booking.StartTime.Before(to) && booking.EndTime.After(from) {
+				bookings = append(bookings, booking)
+			}
+		}
+	}
+	return bookings, nil
+}
+
+// emptyResourceService returns a ResourceService with an empty catalog
+func emptyResourceService() *service.ResourceService {
+	return service.NewResourceService(&FakeResourceRepository{}, &FakeResourceBookingRepository{}, FakeTransactor{})
+}
+
+// createRooms fills the catalog of resourceService with rooms of which only
+// the two returned suit a meeting of five needing a projector, orchid being
+// the smaller one
+func createRooms(t *testing.T, resourceService *service.ResourceService) (orchid, lotus *models.Resource) {
+	t.Helper()
+	create := func(name string, capacity int, attributes ...string) *models.Resource {
+		resource, err := resourceService.CreateResource(context.Background(), &models.ResourceRequest{
+			Name: name, Kind: models.ResourceRoom, Capacity: capacity, Attributes: attributes,
+		})
+		require.NoError(t, err)
+		return resource
+	}
+	create("Huddle", 4, "projector") // Too small
+	lotus = create("Lotus", 10, "Projector ", "whiteboard")
+	orchid = create("Orchid", 8, "projector")
+	create("Atrium", 12, "whiteboard") // No projector
+	return orchid, lotus
+}
+
+func TestRecommendationsOnlyOfferSlotsWithAFreeResource(t *testing.T) {
+	bookingRepo := &FakeResourceBookingRepository{}
+	resourceService := service.NewResourceService(&FakeResourceRepository{}, bookingRepo, FakeTransactor{})
+	orchid, lotus := createRooms(t, resourceService)
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	event := &models.Event{ID: uuid.New(), Duration: 60, ResourceRequirement: &models.ResourceRequirement{
+		Kind: models.ResourceRoom, MinCapacity: 5, Attributes: []string{"projector"},
+	}}
+	morning := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+	afternoon := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: start.Add(4 * time.Hour), EndTime: start.Add(5 * time.Hour)}
+
+	// Both suitable rooms are taken in the morning
+	otherEvent := uuid.New()
+	require.NoError(t, bookingRepo.Create(ctx, &models.ResourceBooking{ResourceID: orchid.ID, EventID: &otherEvent, StartTime: start.Add(-30 * time.Minute), EndTime: start.Add(30 * time.Minute)}))
+	_, err := resourceService.BlockResource(ctx, lotus.ID, &models.ResourceBookingRequest{StartTime: start, EndTime: start.Add(2 * time.Hour), Note: "Maintenance"})
+	require.NoError(t, err)
+
+	// Blocks cannot overlap other bookings
+	_, err = resourceService.BlockResource(ctx, lotus.ID, &models.ResourceBookingRequest{StartTime: start.Add(time.Hour), EndTime: start.Add(3 * time.Hour)})
+	assert.ErrorIs(t, err, apperrors.ErrResourceUnavailable)
+
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	timeslotRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.TimeSlot{morning, afternoon}, nil)
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.Availability{}, nil)
+	userRepo := new(MockUserRepository)
+	userRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*models.User{}, nil)
+	recommendationService := service.NewRecommendationService(eventRepo, timeslotRepo, availabilityRepo, userRepo, &FakeGuestRepository{}, &FakeVoteRepository{}, &FakeParticipantRepository{}, resourceService, emptyGroupService())
+
+	response, err := recommendationService.GetRecommendations(ctx, event.ID)
+	require.NoError(t, err)
+	require.Len(t, response.Recommendations, 1)
+	assert.Equal(t, afternoon.ID, response.Recommendations[0].TimeSlot.ID)
+	require.NotNil(t, response.Recommendations[0].Resource)
+	assert.Equal(t, orchid.ID, response.Recommendations[0].Resource.ID, "the smallest suitable room is preferred")
+}
+
+func TestFinalizeBooksASuitableResource(t *testing.T) {
+	bookingRepo := &FakeResourceBookingRepository{}
+	resourceService := service.NewResourceService(&FakeResourceRepository{}, bookingRepo, FakeTransactor{})
+	orchid, lotus := createRooms(t, resourceService)
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	outbox := &FakeOutboxRepository{}
+	timeslotService := service.NewTimeSlotService(timeslotRepo, eventRepo, FakeTransactor{}, outbox, &FakeAuditRepository{}, resourceService)
+	eventRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	// Three events want a projector room at the same time; only two exist
+	finalize := func() (*models.Event, error) {
+		event := &models.Event{ID: uuid.New(), Duration: 60, Status: models.EventStatusActive, ResourceRequirement: &models.ResourceRequirement{
+			Kind: models.ResourceRoom, MinCapacity: 5, Attributes: []string{"projector"},
+		}}
+		slot := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: start, EndTime: start.Add(2 * time.Hour)}
+		eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+		eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		timeslotRepo.On("GetByID", mock.Anything, slot.ID).Return(slot, nil)
+		return timeslotService.FinalizeTimeSlot(ctx, slot.ID)
+	}
+
+	first, err := finalize()
+	require.NoError(t, err)
+	require.NotNil(t, first.ResourceID)
+	assert.Equal(t, orchid.ID, *first.ResourceID)
+	require.Len(t, bookingRepo.Bookings, 1)
+	booking := bookingRepo.Bookings[0]
+	assert.Equal(t, first.ID, *booking.EventID)
+	assert.Equal(t, start.Add(time.Hour), booking.EndTime, "the booking covers the meeting, not the whole slot")
+
+	second, err := finalize()
+	require.NoError(t, err)
+	assert.Equal(t, lotus.ID, *second.ResourceID)
+
+	_, err = finalize()
+	assert.ErrorIs(t, err, apperrors.ErrResourceUnavailable)
+	assert.Len(t, bookingRepo.Bookings, 2)
+	assert.Equal(t, []string{models.TopicTimeSlotFinalized, models.TopicTimeSlotFinalized}, outbox.Topics())
+}
+
+func TestDeletedEventFreesItsResourceUntilRestored(t *testing.T) {
+	resourceService := service.NewResourceService(&FakeResourceRepository{}, &FakeResourceBookingRepository{}, FakeTransactor{})
+	orchid, lotus := createRooms(t, resourceService)
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	slotID := uuid.New()
+	event := &models.Event{ID: uuid.New(), CreatorID: uuid.New(), Duration: 60, Status: models.EventStatusFinalized, FinalTimeSlotID: &slotID,
+		ResourceRequirement: &models.ResourceRequirement{Kind: models.ResourceRoom, MinCapacity: 5, Attributes: []string{"projector"}}}
+	slot := &models.TimeSlot{ID: slotID, EventID: event.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+	booking, err := resourceService.BookForEvent(ctx, event, slot, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	event.ResourceID = &booking.ResourceID
+
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	eventService := service.NewEventService(eventRepo, timeslotRepo, FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, search.NewMemoryIndex(), resourceService)
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	eventRepo.On("Delete", mock.Anything, event.ID, event.Version).Return(nil)
+	require.NoError(t, eventService.DeleteEvent(ctx, event.ID, models.Precondition{}))
+
+	// Another meeting takes the room while the event is deleted
+	other := &models.Event{ID: uuid.New(), ResourceRequirement: event.ResourceRequirement}
+	taken, err := resourceService.BookForEvent(ctx, other, &models.TimeSlot{ID: uuid.New()}, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, orchid.ID, taken.ResourceID)
+
+	// Restoring books the next suitable room for the final slot
+	eventRepo.On("GetDeletedByID", mock.Anything, event.ID).Return(event, nil)
+	eventRepo.On("Restore", mock.Anything, event.ID).Return(nil)
+	eventRepo.On("Update", mock.Anything, event).Return(nil)
+	timeslotRepo.On("GetByID", mock.Anything, slotID).Return(slot, nil)
+	restored, err := eventService.RestoreEvent(ctx, event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &lotus.ID, restored.ResourceID)
+
+	// With no suitable room free, the event stays deleted
+	require.NoError(t, eventService.DeleteEvent(ctx, event.ID, models.Precondition{}))
+	_, err = resourceService.BlockResource(ctx, lotus.ID, &models.ResourceBookingRequest{StartTime: start, EndTime: start.Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = eventService.RestoreEvent(ctx, event.ID)
+	assert.ErrorIs(t, err, apperrors.ErrResourceUnavailable)
+}
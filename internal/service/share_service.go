@@ -0,0 +1,358 @@
+// internal/service/share_service.go
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	stderrors "errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// shareTokenPayloadSize is the link ID, event ID and expiry carried by a share token
+const shareTokenPayloadSize = 16 + 16 + 8
+
+// ShareConfig configures share links
+type ShareConfig struct {
+	Secret     string        // Key share tokens are signed with
+	DefaultTTL time.Duration // Lifetime of links created without an expiry
+	MaxTTL     time.Duration // Longest lifetime a link may be given
+}
+
+// ShareService manages share links and the guests responding through them
+type ShareService struct {
+	shareLinkRepo    repository.ShareLinkRepository
+	guestRepo        repository.GuestRepository
+	eventRepo        repository.EventRepository
+	timeslotRepo     repository.TimeSlotRepository
+	availabilityRepo repository.AvailabilityRepository
+	transactor       repository.Transactor
+	outboxRepo       repository.OutboxRepository
+	auditRepo        repository.AuditRepository
+	cfg              ShareConfig
+}
+
+// NewShareService creates a new ShareService
+func NewShareService(
+	shareLinkRepo repository.ShareLinkRepository,
+	guestRepo repository.GuestRepository,
+	eventRepo repository.EventRepository,
+	timeslotRepo repository.TimeSlotRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository,
+	auditRepo repository.AuditRepository,
+	cfg ShareConfig,
+) *ShareService {
+	return &ShareService{
+		shareLinkRepo:    shareLinkRepo,
+		guestRepo:        guestRepo,
+		eventRepo:        eventRepo,
+		timeslotRepo:     timeslotRepo,
+		availabilityRepo: availabilityRepo,
+		transactor:       transactor,
+		outboxRepo:       outboxRepo,
+		auditRepo:        auditRepo,
+		cfg:              cfg,
+	}
+}
+
+// CreateLink creates a share link for an event. The response carries the
+// token, which cannot be retrieved again.
+func (s *ShareService) CreateLink(ctx context.Context, eventID uuid.UUID, req *models.CreateShareLinkRequest) (*models.ShareLinkResponse, error) {
+	ctx, span := startSpan(ctx, "ShareService.CreateLink", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.cfg.DefaultTTL)
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+	// Tokens carry the expiry in whole seconds
+	expiresAt = expiresAt.UTC().Truncate(time.Second)
+	if !expiresAt.After(now) || expiresAt.After(now.Add(s.cfg.MaxTTL)) {
+		return nil, errors.ErrInvalidShareLinkExpiry
+	}
+
+	link := &models.ShareLink{
+		EventID:   eventID,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	if err := s.shareLinkRepo.Create(ctx, link); err != nil {
+		return nil, err
+	}
+
+	token := s.signToken(link)
+	response := shareLinkResponse(link)
+	response.Token = token
+	response.URL = "/share/" + token
+	return response, nil
+}
+
+// ListLinks returns the share links of an event, newest first
+func (s *ShareService) ListLinks(ctx context.Context, eventID uuid.UUID) ([]*models.ShareLinkResponse, error) {
+	ctx, span := startSpan(ctx, "ShareService.ListLinks", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	links, err := s.shareLinkRepo.ListByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.ShareLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = shareLinkResponse(link)
+	}
+	return responses, nil
+}
+
+// RevokeLink stops a share link from being used. Guests who already responded
+// through it keep their availability.
+func (s *ShareService) RevokeLink(ctx context.Context, eventID, linkID uuid.UUID) error {
+	ctx, span := startSpan(ctx, "ShareService.RevokeLink",
+		attribute.String("event.id", eventID.String()),
+		attribute.String("share_link.id", linkID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return err
+	}
+	link, err := s.shareLinkRepo.GetByID(ctx, linkID)
+	if err != nil {
+		return err
+	}
+	if link.EventID != eventID {
+		return errors.ErrShareLinkNotFound
+	}
+	if link.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	link.RevokedAt = &now
+	return s.shareLinkRepo.Update(ctx, link)
+}
+
+// ResolveLink returns the link a token was issued for if it is still usable at now
+func (s *ShareService) ResolveLink(ctx context.Context, token string, now time.Time) (*models.ShareLink, error) {
+	linkID, eventID, expiresAt, err := s.parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if !now.Before(expiresAt) {
+		return nil, errors.ErrShareLinkExpired
+	}
+
+	link, err := s.shareLinkRepo.GetByID(ctx, linkID)
+	if err != nil {
+		// The link is gone together with its event
+		if stderrors.Is(err, errors.ErrShareLinkNotFound) {
+			return nil, errors.ErrShareLinkExpired
+		}
+		return nil, err
+	}
+	if link.EventID != eventID || link.RevokedAt != nil {
+		return nil, errors.ErrShareLinkExpired
+	}
+	return link, nil
+}
+
+// GetSharedEvent returns the event and time slots a share link gives access to
+func (s *ShareService) GetSharedEvent(ctx context.Context, token string) (*models.SharedEventResponse, error) {
+	ctx, span := startSpan(ctx, "ShareService.GetSharedEvent")
+	defer span.End()
+
+	link, err := s.ResolveLink(ctx, token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("event.id", link.EventID.String()))
+
+	ctx, event, err := s.sharedEvent(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	slots, err := s.timeslotRepo.GetByEventID(ctx, link.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeSlots := make([]models.TimeSlotResponse, len(slots))
+	for i, slot := range slots {
+		timeSlots[i] = models.TimeSlotResponse{ID: slot.ID, StartTime: slot.StartTime, EndTime: slot.EndTime}
+	}
+	return &models.SharedEventResponse{
+		ID:               event.ID,
+		Title:            event.Title,
+		Description:      event.Description,
+		Duration:         event.Duration,
+		Status:           event.Status,
+		ResponseDeadline: event.ResponseDeadline,
+		TimeSlots:        timeSlots,
+		LinkExpiresAt:    link.ExpiresAt,
+	}, nil
+}
+
+// SubmitAvailability records availability for a guest of the event a share
+// link was issued for. The guest is created on their first response and
+// recognized by email afterwards.
+func (s *ShareService) SubmitAvailability(ctx context.Context, token string, req *models.GuestAvailabilityRequest) (*models.GuestAvailabilityResponse, error) {
+	ctx, span := startSpan(ctx, "ShareService.SubmitAvailability")
+	defer span.End()
+
+	link, err := s.ResolveLink(ctx, token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("event.id", link.EventID.String()))
+
+	ctx, event, err := s.sharedEvent(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	if !req.EndTime.After(req.StartTime) {
+		return nil, errors.ErrInvalidTimeRange
+	}
+
+	now := time.Now()
+	var guest *models.Guest
+	availability := &models.Availability{
+		EventID:   link.EventID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		guest, err = s.upsertGuest(ctx, link, req.Name, req.Email, now)
+		if err != nil {
+			return err
+		}
+		availability.UserID = guest.ID
+		if err := s.availabilityRepo.Create(ctx, availability); err != nil {
+			return err
+		}
+		err := recordLifecycle(ctx, s.outboxRepo, models.TopicAvailabilitySubmitted, event.ID, &event.CreatorID, map[string]interface{}{
+			"availability": availability,
+			"guest":        guest,
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(withGuestActor(ctx, guest.ID), s.auditRepo, models.TopicAvailabilitySubmitted,
+			models.AuditEntityAvailability, availability.ID, &event.ID, nil, availability)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GuestAvailabilityResponse{Guest: guest, Availability: availability}, nil
+}
+
+// sharedEvent returns the event a link was issued for and a copy of ctx acting
+// for its organization. Guests have no organization of their own, so the
+// event is looked up across organizations.
+func (s *ShareService) sharedEvent(ctx context.Context, link *models.ShareLink) (context.Context, *models.Event, error) {
+	event, err := s.eventRepo.GetByID(tenant.System(ctx), link.EventID)
+	if err != nil {
+		return ctx, nil, err
+	}
+	return tenant.WithOrganization(ctx, event.OrganizationID), event, nil
+}
+
+// upsertGuest returns the guest of the link's event with the given email,
+// creating it or updating its name
+func (s *ShareService) upsertGuest(ctx context.Context, link *models.ShareLink, name, email string, now time.Time) (*models.Guest, error) {
+	name = strings.TrimSpace(name)
+	guest, err := s.guestRepo.GetByEmail(ctx, link.EventID, email)
+	switch {
+	case err == nil:
+		if guest.Name == name {
+			return guest, nil
+		}
+		guest.Name = name
+		guest.UpdatedAt = now
+		return guest, s.guestRepo.Update(ctx, guest)
+	case !stderrors.Is(err, errors.ErrGuestNotFound):
+		return nil, err
+	}
+
+	guest = &models.Guest{
+		EventID:     link.EventID,
+		ShareLinkID: link.ID,
+		Name:        name,
+		Email:       email,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	return guest, s.guestRepo.Create(ctx, guest)
+}
+
+// signToken encodes the link ID, event ID and expiry of a link and signs them
+func (s *ShareService) signToken(link *models.ShareLink) string {
+	payload := make([]byte, 0, shareTokenPayloadSize)
+	payload = append(payload, link.ID[:]...)
+	payload = append(payload, link.EventID[:]...)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(link.ExpiresAt.Unix()))
+
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(s.mac(payload))
+}
+
+// parseToken checks the signature of a token and decodes it
+func (s *ShareService) parseToken(token string) (linkID, eventID uuid.UUID, expiresAt time.Time, err error) {
+	encoding := base64.RawURLEncoding
+	payloadStr, sigStr, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, uuid.Nil, time.Time{}, errors.ErrInvalidShareToken
+	}
+	payload, err := encoding.DecodeString(payloadStr)
+	if err != nil || len(payload) != shareTokenPayloadSize {
+		return uuid.Nil, uuid.Nil, time.Time{}, errors.ErrInvalidShareToken
+	}
+	sig, err := encoding.DecodeString(sigStr)
+	if err != nil || !hmac.Equal(sig, s.mac(payload)) {
+		return uuid.Nil, uuid.Nil, time.Time{}, errors.ErrInvalidShareToken
+	}
+
+	copy(linkID[:], payload[:16])
+	copy(eventID[:], payload[16:32])
+	expiresAt = time.Unix(int64(binary.BigEndian.Uint64(payload[32:])), 0).UTC()
+	return linkID, eventID, expiresAt, nil
+}
+
+func (s *ShareService) mac(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(s.cfg.Secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func shareLinkResponse(link *models.ShareLink) *models.ShareLinkResponse {
+	return &models.ShareLinkResponse{
+		ID:        link.ID,
+		EventID:   link.EventID,
+		ExpiresAt: link.ExpiresAt,
+		RevokedAt: link.RevokedAt,
+		CreatedAt: link.CreatedAt,
+	}
+}
@@ -0,0 +1,233 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeShareLinkRepository keeps share links in memory
+type FakeShareLinkRepository struct {
+	Links []*models.ShareLink
+}
+
+func (f *FakeShareLinkRepository) Create(ctx context.Context, link *models.ShareLink) error {
+	if link.ID == uuid.Nil {
+		link.ID = uuid.New()
+	}
+	f.Links = append(f.Links, link)
+	return nil
+}
+
+func (f *FakeShareLinkRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ShareLink, error) {
+	for _, link := range f.Links {
+		if link.ID == id {
+			return link, nil
+		}
+	}
+	return nil, apperrors.ErrShareLinkNotFound
+}
+
+func (f *FakeShareLinkRepository) ListByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.ShareLink, error) {
+	var links []*models.ShareLink
+	for _, link := range f.Links {
+		if link.EventID == eventID {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+func (f *FakeShareLinkRepository) Update(ctx context.Context, link *models.ShareLink) error {
+	return nil
+}
+
+// FakeGuestRepository keeps guests in memory
+type FakeGuestRepository struct {
+	Guests []*models.Guest
+}
+
+func (f *FakeGuestRepository) Create(ctx context.Context, guest *models.Guest) error {
+	if guest.ID == uuid.Nil {
+		guest.ID = uuid.New()
+	}
+	f.Guests = append(f.Guests, guest)
+	return nil
+}
+
+func (f *FakeGuestRepository) Update(ctx context.Context, guest *models.Guest) error {
+	return nil
+}
+
+func (f *FakeGuestRepository) GetByEmail(ctx context.Context, eventID uuid.UUID, email string) (*models.Guest, error) {
+	for _, guest := range f.Guests {
+		if guest.EventID == eventID && strings.EqualFold(guest.Email, email) {
+			return guest, nil
+		}
+	}
+	return nil, apperrors.ErrGuestNotFound
+}
+
+func (f *FakeGuestRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.Guest, error) {
+	var guests []*models.Guest
+	for _, guest := range f.Guests {
+		if guest.EventID == eventID {
+			guests = append(guests, guest)
+		}
+	}
+	return guests, nil
+}
+
+func TestGuestAvailabilityThroughShareLinkShowsUpInRecommendations(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	guestRepo := &FakeGuestRepository{}
+	outboxRepo := &FakeOutboxRepository{}
+	auditRepo := &FakeAuditRepository{}
+	shareService := service.NewShareService(
+		&FakeShareLinkRepository{}, guestRepo, eventRepo, new(MockTimeSlotRepository), availabilityRepo, FakeTransactor{}, outboxRepo, auditRepo,
+		service.ShareConfig{Secret: strings.Repeat("s", 32), DefaultTTL: 24 * time.Hour, MaxTTL: 7 * 24 * time.Hour},
+	)
+	ctx := context.Background()
+
+	event := &models.Event{ID: uuid.New(), CreatorID: uuid.New(), Duration: 60, Status: models.EventStatusActive}
+	var submitted []*models.Availability
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	availabilityRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		submitted = append(submitted, args.Get(1).(*models.Availability))
+	}).Return(nil)
+
+	link, err := shareService.CreateLink(ctx, event.ID, &models.CreateShareLinkRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, link.Token)
+	assert.Equal(t, "/share/"+link.Token, link.URL)
+
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	response, err := shareService.SubmitAvailability(ctx, link.Token, &models.GuestAvailabilityRequest{
+		Name:      "Grace Guest",
+		Email:     "grace@example.com",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, event.ID, response.Guest.EventID)
+	assert.Equal(t, link.ID, response.Guest.ShareLinkID)
+	assert.Equal(t, response.Guest.ID, response.Availability.UserID)
+	assert.Equal(t, event.ID, response.Availability.EventID)
+	assert.Equal(t, []string{models.TopicAvailabilitySubmitted}, outboxRepo.Topics())
+	require.Len(t, auditRepo.Entries, 1)
+	assert.Equal(t, models.AuditActorGuest, auditRepo.Entries[0].ActorType)
+	assert.Equal(t, &response.Guest.ID, auditRepo.Entries[0].ActorID)
+
+	// A second response with the same email, in any case, belongs to the same guest
+	again, err := shareService.SubmitAvailability(ctx, link.Token, &models.GuestAvailabilityRequest{
+		Name:      "Grace G.",
+		Email:     "GRACE@example.com",
+		StartTime: start.Add(4 * time.Hour),
+		EndTime:   start.Add(5 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, response.Guest.ID, again.Guest.ID)
+	assert.Len(t, guestRepo.Guests, 1)
+	assert.Equal(t, "Grace G.", guestRepo.Guests[0].Name)
+
+	// Recommendations list the guest next to regular users
+	timeslotRepo := new(MockTimeSlotRepository)
+	userRepo := new(MockUserRepository)
+	recommendationService := service.NewRecommendationService(eventRepo, timeslotRepo, availabilityRepo, userRepo, guestRepo, &FakeVoteRepository{}, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+
+	user := &models.User{ID: uuid.New(), Name: "Alice", Email: "alice@example.com"}
+	early := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+	late := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour)}
+	availabilities := append([]*models.Availability{
+		{ID: uuid.New(), EventID: event.ID, UserID: user.ID, StartTime: start, EndTime: start.Add(3 * time.Hour)},
+	}, submitted...)
+	timeslotRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.TimeSlot{early, late}, nil)
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return(availabilities, nil)
+	userRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*models.User{user}, nil)
+
+	recommendations, err := recommendationService.GetRecommendations(ctx, event.ID)
+	require.NoError(t, err)
+	require.Len(t, recommendations.Recommendations, 2)
+
+	guest := models.UserResponse{ID: response.Guest.ID, Name: "Grace G.", Email: "grace@example.com", Guest: true}
+	assert.Equal(t, early.ID, recommendations.Recommendations[0].TimeSlot.ID)
+	assert.Contains(t, recommendations.Recommendations[0].Attendees, guest)
+	assert.Equal(t, late.ID, recommendations.Recommendations[1].TimeSlot.ID)
+	assert.Contains(t, recommendations.Recommendations[1].NonAttendees, guest)
+}
+
+func TestShareTokensAreCheckedBeforeGrantingAccess(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	guestRepo := &FakeGuestRepository{}
+	newShareService := func(secret string) *service.ShareService {
+		return service.NewShareService(
+			&FakeShareLinkRepository{}, guestRepo, eventRepo, new(MockTimeSlotRepository), new(MockAvailabilityRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{},
+			service.ShareConfig{Secret: secret, DefaultTTL: 24 * time.Hour, MaxTTL: 7 * 24 * time.Hour},
+		)
+	}
+	shareService := newShareService(strings.Repeat("s", 32))
+	ctx := context.Background()
+
+	event := &models.Event{ID: uuid.New(), CreatorID: uuid.New(), Duration: 60, Status: models.EventStatusActive}
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+
+	link, err := shareService.CreateLink(ctx, event.ID, &models.CreateShareLinkRequest{})
+	require.NoError(t, err)
+
+	resolved, err := shareService.ResolveLink(ctx, link.Token, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, event.ID, resolved.EventID)
+
+	// Tampered tokens and tokens signed with another secret are rejected
+	payload, _, _ := strings.Cut(link.Token, ".")
+	_, err = shareService.ResolveLink(ctx, payload+".forged", time.Now())
+	assert.ErrorIs(t, err, apperrors.ErrInvalidShareToken)
+	other := newShareService(strings.Repeat("o", 32))
+	_, err = other.ResolveLink(ctx, link.Token, time.Now())
+	assert.ErrorIs(t, err, apperrors.ErrInvalidShareToken)
+
+	// Links stop working once they expire
+	_, err = shareService.ResolveLink(ctx, link.Token, link.ExpiresAt)
+	assert.ErrorIs(t, err, apperrors.ErrShareLinkExpired)
+
+	// and once they are revoked
+	require.NoError(t, shareService.RevokeLink(ctx, event.ID, link.ID))
+	_, err = shareService.SubmitAvailability(ctx, link.Token, &models.GuestAvailabilityRequest{
+		Name:      "Grace Guest",
+		Email:     "grace@example.com",
+		StartTime: time.Now(),
+		EndTime:   time.Now().Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, apperrors.ErrShareLinkExpired)
+	assert.Empty(t, guestRepo.Guests)
+}
+
+func TestCreateShareLinkLimitsLifetime(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	shareService := service.NewShareService(
+		&FakeShareLinkRepository{}, &FakeGuestRepository{}, eventRepo, new(MockTimeSlotRepository), new(MockAvailabilityRepository), FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{},
+		service.ShareConfig{Secret: strings.Repeat("s", 32), DefaultTTL: 24 * time.Hour, MaxTTL: 7 * 24 * time.Hour},
+	)
+
+	event := &models.Event{ID: uuid.New(), CreatorID: uuid.New(), Duration: 60, Status: models.EventStatusActive}
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+
+	tooLate := time.Now().Add(30 * 24 * time.Hour)
+	_, err := shareService.CreateLink(context.Background(), event.ID, &models.CreateShareLinkRequest{ExpiresAt: &tooLate})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidShareLinkExpiry)
+
+	past := time.Now().Add(-time.Minute)
+	_, err = shareService.CreateLink(context.Background(), event.ID, &models.CreateShareLinkRequest{ExpiresAt: &past})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidShareLinkExpiry)
+}
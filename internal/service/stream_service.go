@@ -0,0 +1,159 @@
+// internal/service/stream_service.go
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/pubsub"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// StreamRecommendations is the type of stream updates carrying recomputed recommendations
+const StreamRecommendations = "recommendations"
+
+// recomputeTopics are the lifecycle topics that can change an event's recommendations
+var recomputeTopics = map[string]bool{
+	models.TopicEventUpdated:          true,
+	models.TopicTimeSlotCreated:       true,
+	models.TopicTimeSlotUpdated:       true,
+	models.TopicTimeSlotDeleted:       true,
+	models.TopicTimeSlotRestored:      true,
+	models.TopicAvailabilitySubmitted: true,
+	models.TopicAvailabilityUpdated:   true,
+	models.TopicAvailabilityDeleted:   true,
+	models.TopicAvailabilityRestored:  true,
+	models.TopicVoteCast:              true,
+	models.TopicVoteRetracted:         true,
+	models.TopicParticipantAdded:      true,
+	models.TopicParticipantRemoved:    true,
+}
+
+// StreamUpdate is one update pushed to the live stream of an event
+type StreamUpdate struct {
+	Type string      // Lifecycle topic, or StreamRecommendations
+	Data interface{} // Lifecycle payload, or the recommendations
+}
+
+// StreamService pushes event changes to live subscribers. It consumes the
+// outbox, so updates are only published once the change has committed.
+type StreamService struct {
+	broker                pubsub.Broker
+	recommendationService *RecommendationService
+}
+
+// NewStreamService creates a new StreamService
+func NewStreamService(broker pubsub.Broker, recommendationService *RecommendationService) *StreamService {
+	return &StreamService{
+		broker:                broker,
+		recommendationService: recommendationService,
+	}
+}
+
+// HandleOutboxMessage publishes a committed lifecycle message to the subscribers of its event
+func (s *StreamService) HandleOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	return s.broker.Publish(ctx, pubsub.Message{
+		Topic:   message.Topic,
+		EventID: message.EventID,
+		Data:    message.Payload,
+	})
+}
+
+// Subscribe streams updates about an event until ctx is done, the event is
+// deleted or the subscription is dropped, then closes the channel. The first
+// update holds the current recommendations, and fresh ones follow every
+// change that can affect them.
+func (s *StreamService) Subscribe(ctx context.Context, eventID uuid.UUID) (<-chan StreamUpdate, error) {
+	ctx, span := startSpan(ctx, "StreamService.Subscribe", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	// Subscribe before computing the snapshot so no change falls in between
+	sub := s.broker.Subscribe(eventID)
+	recommendations, err := s.recommendationService.GetRecommendations(ctx, eventID)
+	if err != nil {
+		sub.Close()
+		return nil, err
+	}
+
+	updates := make(chan StreamUpdate)
+	go func() {
+		defer close(updates)
+		defer sub.Close()
+
+		if !sendUpdate(ctx, updates, StreamUpdate{Type: StreamRecommendations, Data: recommendations}) {
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-sub.C:
+				if !ok || !s.forward(ctx, eventID, msg, sub, updates) {
+					return
+				}
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// forward sends msg and any messages already queued behind it, then one set
+// of recommendations if they may have changed. It reports whether the stream
+// should continue.
+func (s *StreamService) forward(ctx context.Context, eventID uuid.UUID, msg pubsub.Message, sub *pubsub.Subscription, updates chan<- StreamUpdate) bool {
+	recompute := false
+	for {
+		if !sendUpdate(ctx, updates, lifecycleUpdate(msg)) || msg.Topic == models.TopicEventDeleted {
+			return false
+		}
+		recompute = recompute || recomputeTopics[msg.Topic]
+
+		// Bursts of changes share a single recomputation
+		var ok bool
+		select {
+		case msg, ok = <-sub.C:
+			if !ok {
+				return false
+			}
+			continue
+		default:
+		}
+		break
+	}
+	if !recompute {
+		return true
+	}
+
+	recommendations, err := s.recommendationService.GetRecommendations(ctx, eventID)
+	if err != nil {
+		if stderrors.Is(err, errors.ErrEventNotFound) {
+			return false
+		}
+		log.Printf("recomputing recommendations for event %s failed: %v", eventID, err)
+		return ctx.Err() == nil
+	}
+	return sendUpdate(ctx, updates, StreamUpdate{Type: StreamRecommendations, Data: recommendations})
+}
+
+// lifecycleUpdate turns a broker message into a stream update. Messages whose
+// payload did not fit the broker only identify the event.
+func lifecycleUpdate(msg pubsub.Message) StreamUpdate {
+	if len(msg.Data) == 0 {
+		return StreamUpdate{Type: msg.Topic, Data: map[string]interface{}{"event_id": msg.EventID}}
+	}
+	return StreamUpdate{Type: msg.Topic, Data: msg.Data}
+}
+
+// sendUpdate sends update unless ctx is done first
+func sendUpdate(ctx context.Context, updates chan<- StreamUpdate, update StreamUpdate) bool {
+	select {
+	case updates <- update:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/pubsub"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// nextUpdate waits for the next stream update
+func nextUpdate(t *testing.T, updates <-chan service.StreamUpdate) service.StreamUpdate {
+	t.Helper()
+	select {
+	case update, ok := <-updates:
+		require.True(t, ok, "stream closed")
+		return update
+	case <-time.After(time.Second):
+		t.Fatal("no stream update")
+		return service.StreamUpdate{}
+	}
+}
+
+func TestStreamServicePushesChangesAndRecomputedRecommendations(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	userRepo := new(MockUserRepository)
+	recommendationService := service.NewRecommendationService(eventRepo, timeslotRepo, availabilityRepo, userRepo, &FakeGuestRepository{}, &FakeVoteRepository{}, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+	streamService := service.NewStreamService(pubsub.NewMemoryBroker(), recommendationService)
+
+	event := &models.Event{ID: uuid.New(), Duration: 60, Status: models.EventStatusActive}
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	slot := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+	user := &models.User{ID: uuid.New(), Name: "Alice", Email: "alice@example.com"}
+	availability := &models.Availability{ID: uuid.New(), EventID: event.ID, UserID: user.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	timeslotRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.TimeSlot{slot}, nil)
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.Availability{}, nil).Once()
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.Availability{availability}, nil)
+	userRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*models.User{user}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates, err := streamService.Subscribe(ctx, event.ID)
+	require.NoError(t, err)
+
+	// The stream opens with the current recommendations
+	update := nextUpdate(t, updates)
+	assert.Equal(t, service.StreamRecommendations, update.Type)
+	snapshot := update.Data.(*models.RecommendationResponse)
+	require.Len(t, snapshot.Recommendations, 1)
+	assert.Empty(t, snapshot.Recommendations[0].Attendees)
+
+	payload, err := json.Marshal(map[string]interface{}{"availability": availability})
+	require.NoError(t, err)
+	require.NoError(t, streamService.HandleOutboxMessage(context.Background(), &models.OutboxMessage{
+		Topic:   models.TopicAvailabilitySubmitted,
+		EventID: event.ID,
+		Payload: payload,
+	}))
+
+	update = nextUpdate(t, updates)
+	assert.Equal(t, models.TopicAvailabilitySubmitted, update.Type)
+	assert.JSONEq(t, string(payload), string(update.Data.(json.RawMessage)))
+
+	update = nextUpdate(t, updates)
+	assert.Equal(t, service.StreamRecommendations, update.Type)
+	recomputed := update.Data.(*models.RecommendationResponse)
+	require.Len(t, recomputed.Recommendations, 1)
+	assert.Len(t, recomputed.Recommendations[0].Attendees, 1)
+
+	// Deleting the event ends the stream
+	require.NoError(t, streamService.HandleOutboxMessage(context.Background(), &models.OutboxMessage{
+		Topic:   models.TopicEventDeleted,
+		EventID: event.ID,
+		Payload: json.RawMessage(`{}`),
+	}))
+	assert.Equal(t, models.TopicEventDeleted, nextUpdate(t, updates).Type)
+	_, ok := <-updates
+	assert.False(t, ok)
+}
+
+func TestStreamServiceRejectsUnknownEvents(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	recommendationService := service.NewRecommendationService(eventRepo, new(MockTimeSlotRepository), new(MockAvailabilityRepository), new(MockUserRepository), &FakeGuestRepository{}, &FakeVoteRepository{}, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+	streamService := service.NewStreamService(pubsub.NewMemoryBroker(), recommendationService)
+
+	eventID := uuid.New()
+	eventRepo.On("GetByID", mock.Anything, eventID).Return(nil, errors.ErrEventNotFound)
+
+	_, err := streamService.Subscribe(context.Background(), eventID)
+	assert.ErrorIs(t, err, errors.ErrEventNotFound)
+}
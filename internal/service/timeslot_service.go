@@ -9,29 +9,45 @@ import (
 	"github.com/npkanaka/meeting-scheduler/internal/errors"
 	"github.com/npkanaka/meeting-scheduler/internal/models"
 	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // TimeSlotService handles time slot business logic
 type TimeSlotService struct {
-	timeslotRepo repository.TimeSlotRepository
-	eventRepo    repository.EventRepository
+	timeslotRepo    repository.TimeSlotRepository
+	eventRepo       repository.EventRepository
+	transactor      repository.Transactor
+	outboxRepo      repository.OutboxRepository
+	auditRepo       repository.AuditRepository
+	resourceService *ResourceService
 }
 
 // NewTimeSlotService creates a new TimeSlotService
 func NewTimeSlotService(
 	timeslotRepo repository.TimeSlotRepository,
 	eventRepo repository.EventRepository,
+	transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository,
+	auditRepo repository.AuditRepository,
+	resourceService *ResourceService,
 ) *TimeSlotService {
 	return &TimeSlotService{
-		timeslotRepo: timeslotRepo,
-		eventRepo:    eventRepo,
+		timeslotRepo:    timeslotRepo,
+		eventRepo:       eventRepo,
+		transactor:      transactor,
+		outboxRepo:      outboxRepo,
+		auditRepo:       auditRepo,
+		resourceService: resourceService,
 	}
 }
 
 // CreateTimeSlot creates a new time slot for an event
 func (s *TimeSlotService) CreateTimeSlot(ctx context.Context, eventID uuid.UUID, req *models.TimeSlotRequest) (*models.TimeSlot, error) {
+	ctx, span := startSpan(ctx, "TimeSlotService.CreateTimeSlot", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
 	// Verify the event exists
-	_, err := s.eventRepo.GetByID(ctx, eventID)
+	event, err := s.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +77,13 @@ func (s *TimeSlotService) CreateTimeSlot(ctx context.Context, eventID uuid.UUID,
 		UpdatedAt: now,
 	}
 
-	if err := s.timeslotRepo.Create(ctx, slot); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.timeslotRepo.Create(ctx, slot); err != nil {
+			return err
+		}
+		return s.recordTimeSlot(ctx, models.TopicTimeSlotCreated, nil, slot, &event.CreatorID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -70,15 +92,25 @@ func (s *TimeSlotService) CreateTimeSlot(ctx context.Context, eventID uuid.UUID,
 
 // GetTimeSlot retrieves a time slot by ID
 func (s *TimeSlotService) GetTimeSlot(ctx context.Context, id uuid.UUID) (*models.TimeSlot, error) {
+	ctx, span := startSpan(ctx, "TimeSlotService.GetTimeSlot", attribute.String("timeslot.id", id.String()))
+	defer span.End()
+
 	return s.timeslotRepo.GetByID(ctx, id)
 }
 
-// UpdateTimeSlot updates an existing time slot
-func (s *TimeSlotService) UpdateTimeSlot(ctx context.Context, id uuid.UUID, req *models.TimeSlotRequest) (*models.TimeSlot, error) {
+// UpdateTimeSlot updates an existing time slot that meets the precondition
+func (s *TimeSlotService) UpdateTimeSlot(ctx context.Context, id uuid.UUID, req *models.TimeSlotRequest, pre models.Precondition) (*models.TimeSlot, error) {
+	ctx, span := startSpan(ctx, "TimeSlotService.UpdateTimeSlot", attribute.String("timeslot.id", id.String()))
+	defer span.End()
+
 	slot, err := s.timeslotRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if !pre.Allows(slot.ETag()) {
+		return nil, errors.ErrStaleVersion
+	}
+	before := *slot
 
 	// Parse time strings
 	startTime, err := time.Parse(time.RFC3339, req.StartTime)
@@ -100,19 +132,152 @@ func (s *TimeSlotService) UpdateTimeSlot(ctx context.Context, id uuid.UUID, req
 	slot.EndTime = endTime
 	slot.UpdatedAt = time.Now()
 
-	if err := s.timeslotRepo.Update(ctx, slot); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.timeslotRepo.Update(ctx, slot); err != nil {
+			return err
+		}
+		return s.recordTimeSlot(ctx, models.TopicTimeSlotUpdated, &before, slot, nil)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return slot, nil
 }
 
-// DeleteTimeSlot removes a time slot
-func (s *TimeSlotService) DeleteTimeSlot(ctx context.Context, id uuid.UUID) error {
-	return s.timeslotRepo.Delete(ctx, id)
+// DeleteTimeSlot deletes a time slot that meets the precondition until it is
+// restored or purged
+func (s *TimeSlotService) DeleteTimeSlot(ctx context.Context, id uuid.UUID, pre models.Precondition) error {
+	ctx, span := startSpan(ctx, "TimeSlotService.DeleteTimeSlot", attribute.String("timeslot.id", id.String()))
+	defer span.End()
+
+	slot, err := s.timeslotRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !pre.Allows(slot.ETag()) {
+		return errors.ErrStaleVersion
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.timeslotRepo.Delete(ctx, id, slot.Version); err != nil {
+			return err
+		}
+		return s.recordTimeSlot(ctx, models.TopicTimeSlotDeleted, slot, nil, nil)
+	})
+}
+
+// RestoreTimeSlot brings back a deleted time slot of an event that is not deleted
+func (s *TimeSlotService) RestoreTimeSlot(ctx context.Context, id uuid.UUID) (*models.TimeSlot, error) {
+	ctx, span := startSpan(ctx, "TimeSlotService.RestoreTimeSlot", attribute.String("timeslot.id", id.String()))
+	defer span.End()
+
+	before, err := s.timeslotRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Slots deleted with their event come back when the event is restored
+	event, err := s.eventRepo.GetByID(ctx, before.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var slot *models.TimeSlot
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.timeslotRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		if slot, err = s.timeslotRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return s.recordTimeSlot(ctx, models.TopicTimeSlotRestored, before, slot, &event.CreatorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slot, nil
 }
 
-// GetEventTimeSlots retrieves all time slots for an event
-func (s *TimeSlotService) GetEventTimeSlots(ctx context.Context, eventID uuid.UUID) ([]*models.TimeSlot, error) {
-	return s.timeslotRepo.GetByEventID(ctx, eventID)
+// FinalizeTimeSlot picks a time slot as the final meeting time for its event.
+// Events that require a resource get one booked in the same transaction, and
+// are not finalized when none is free.
+func (s *TimeSlotService) FinalizeTimeSlot(ctx context.Context, id uuid.UUID) (*models.Event, error) {
+	ctx, span := startSpan(ctx, "TimeSlotService.FinalizeTimeSlot", attribute.String("timeslot.id", id.String()))
+	defer span.End()
+
+	slot, err := s.timeslotRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, slot.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status == models.EventStatusCanceled || event.Status == models.EventStatusFinalized {
+		return nil, errors.ErrInvalidStatusTransition
+	}
+	before := *event
+
+	event.Status = models.EventStatusFinalized
+	event.FinalTimeSlotID = &slot.ID
+	event.UpdatedAt = time.Now()
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		payload := map[string]interface{}{
+			"event":     event,
+			"time_slot": slot,
+		}
+		if event.ResourceRequirement != nil {
+			end := slot.StartTime.Add(time.Duration(event.Duration) * time.Minute)
+			booking, err := s.resourceService.BookForEvent(ctx, event, slot, slot.StartTime, end)
+			if err != nil {
+				return err
+			}
+			event.ResourceID = &booking.ResourceID
+			payload["booking"] = booking
+		}
+
+		if err := s.eventRepo.Update(ctx, event); err != nil {
+			return err
+		}
+		if err := recordLifecycle(ctx, s.outboxRepo, models.TopicTimeSlotFinalized, event.ID, &event.CreatorID, payload); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, models.TopicTimeSlotFinalized, models.AuditEntityEvent, event.ID, &event.ID, &before, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// GetEventTimeSlots retrieves a page of the time slots for an event
+func (s *TimeSlotService) GetEventTimeSlots(ctx context.Context, eventID uuid.UUID, page models.PageRequest) ([]*models.TimeSlot, models.PageInfo, error) {
+	ctx, span := startSpan(ctx, "TimeSlotService.GetEventTimeSlots", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	slots, info, err := s.timeslotRepo.ListByEventID(ctx, eventID, page)
+	span.SetAttributes(attribute.Int("timeslots.count", len(slots)))
+	return slots, info, err
+}
+
+// recordTimeSlot writes a time slot lifecycle message to the outbox and the
+// change to the audit trail. before is nil for a new slot and after is nil for
+// a deleted one.
+func (s *TimeSlotService) recordTimeSlot(ctx context.Context, topic string, before, after *models.TimeSlot, creatorID *uuid.UUID) error {
+	slot := after
+	if slot == nil {
+		slot = before
+	}
+	err := recordLifecycle(ctx, s.outboxRepo, topic, slot.EventID, creatorID, map[string]interface{}{
+		"time_slot": slot,
+	})
+	if err != nil {
+		return err
+	}
+	return recordAudit(ctx, s.auditRepo, topic, models.AuditEntityTimeSlot, slot.ID, &slot.EventID, before, after)
 }
@@ -17,7 +17,7 @@ func TestCreateTimeSlot(t *testing.T) {
 	// Setup mocks
 	mockTimeSlotRepo := new(MockTimeSlotRepository)
 	mockEventRepo := new(MockEventRepository)
-	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo)
+	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, emptyResourceService())
 
 	// Prepare test data
 	eventID := uuid.New()
@@ -58,7 +58,7 @@ func TestCreateTimeSlotInvalidTimeRange(t *testing.T) {
 	// Setup mocks
 	mockTimeSlotRepo := new(MockTimeSlotRepository)
 	mockEventRepo := new(MockEventRepository)
-	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo)
+	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, emptyResourceService())
 
 	// Prepare test data
 	eventID := uuid.New()
@@ -94,7 +94,7 @@ func TestGetTimeSlot(t *testing.T) {
 	// Setup mocks
 	mockTimeSlotRepo := new(MockTimeSlotRepository)
 	mockEventRepo := new(MockEventRepository)
-	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo)
+	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, emptyResourceService())
 
 	// Prepare test data
 	timeSlotID := uuid.New()
@@ -124,7 +124,7 @@ func TestUpdateTimeSlot(t *testing.T) {
 	// Setup mocks
 	mockTimeSlotRepo := new(MockTimeSlotRepository)
 	mockEventRepo := new(MockEventRepository)
-	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo)
+	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, emptyResourceService())
 
 	// Prepare test data
 	timeSlotID := uuid.New()
@@ -150,7 +150,7 @@ func TestUpdateTimeSlot(t *testing.T) {
 	mockTimeSlotRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
 
 	// Execute the method
-	updatedTimeSlot, err := timeSlotService.UpdateTimeSlot(context.Background(), timeSlotID, req)
+	updatedTimeSlot, err := timeSlotService.UpdateTimeSlot(context.Background(), timeSlotID, req, models.Precondition{})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -167,16 +167,17 @@ func TestDeleteTimeSlot(t *testing.T) {
 	// Setup mocks
 	mockTimeSlotRepo := new(MockTimeSlotRepository)
 	mockEventRepo := new(MockEventRepository)
-	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo)
+	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, emptyResourceService())
 
 	// Prepare test data
 	timeSlotID := uuid.New()
 
 	// Set expectations
-	mockTimeSlotRepo.On("Delete", mock.Anything, timeSlotID).Return(nil)
+	mockTimeSlotRepo.On("GetByID", mock.Anything, timeSlotID).Return(&models.TimeSlot{ID: timeSlotID, EventID: uuid.New(), Version: 2}, nil)
+	mockTimeSlotRepo.On("Delete", mock.Anything, timeSlotID, 2).Return(nil)
 
 	// Execute the method
-	err := timeSlotService.DeleteTimeSlot(context.Background(), timeSlotID)
+	err := timeSlotService.DeleteTimeSlot(context.Background(), timeSlotID, models.Precondition{})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -189,7 +190,7 @@ func TestGetEventTimeSlots(t *testing.T) {
 	// Setup mocks
 	mockTimeSlotRepo := new(MockTimeSlotRepository)
 	mockEventRepo := new(MockEventRepository)
-	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo)
+	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, emptyResourceService())
 
 	// Prepare test data
 	eventID := uuid.New()
@@ -209,10 +210,11 @@ func TestGetEventTimeSlots(t *testing.T) {
 	}
 
 	// Set expectations
-	mockTimeSlotRepo.On("GetByEventID", mock.Anything, eventID).Return(expectedTimeSlots, nil)
+	page := models.PageRequest{Limit: 50}
+	mockTimeSlotRepo.On("ListByEventID", mock.Anything, eventID, page).Return(expectedTimeSlots, models.PageInfo{Total: 2, Limit: 50}, nil)
 
 	// Execute the method
-	timeSlots, err := timeSlotService.GetEventTimeSlots(context.Background(), eventID)
+	timeSlots, _, err := timeSlotService.GetEventTimeSlots(context.Background(), eventID, page)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -222,3 +224,40 @@ func TestGetEventTimeSlots(t *testing.T) {
 	// Verify mock expectations
 	mockTimeSlotRepo.AssertExpectations(t)
 }
+
+func TestRestoreTimeSlot(t *testing.T) {
+	mockTimeSlotRepo := new(MockTimeSlotRepository)
+	mockEventRepo := new(MockEventRepository)
+	outboxRepo := &FakeOutboxRepository{}
+	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, outboxRepo, &FakeAuditRepository{}, emptyResourceService())
+
+	event := &models.Event{ID: uuid.New(), CreatorID: uuid.New()}
+	slot := &models.TimeSlot{ID: uuid.New(), EventID: event.ID}
+	mockTimeSlotRepo.On("GetDeletedByID", mock.Anything, slot.ID).Return(slot, nil)
+	mockEventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	mockTimeSlotRepo.On("Restore", mock.Anything, slot.ID).Return(nil)
+	mockTimeSlotRepo.On("GetByID", mock.Anything, slot.ID).Return(slot, nil)
+
+	restored, err := timeSlotService.RestoreTimeSlot(context.Background(), slot.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, slot, restored)
+	assert.Equal(t, []string{models.TopicTimeSlotRestored}, outboxRepo.Topics())
+	mockTimeSlotRepo.AssertExpectations(t)
+}
+
+func TestRestoreTimeSlotOfDeletedEvent(t *testing.T) {
+	mockTimeSlotRepo := new(MockTimeSlotRepository)
+	mockEventRepo := new(MockEventRepository)
+	timeSlotService := service.NewTimeSlotService(mockTimeSlotRepo, mockEventRepo, FakeTransactor{}, &FakeOutboxRepository{}, &FakeAuditRepository{}, emptyResourceService())
+
+	slot := &models.TimeSlot{ID: uuid.New(), EventID: uuid.New()}
+	mockTimeSlotRepo.On("GetDeletedByID", mock.Anything, slot.ID).Return(slot, nil)
+	mockEventRepo.On("GetByID", mock.Anything, slot.EventID).Return(nil, errors.ErrEventNotFound)
+
+	// The slot comes back with its event instead
+	_, err := timeSlotService.RestoreTimeSlot(context.Background(), slot.ID)
+
+	assert.ErrorIs(t, err, errors.ErrEventNotFound)
+	mockTimeSlotRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,15 @@
+// internal/service/tracing.go
+package service
+
+import (
+	"context"
+
+	"github.com/npkanaka/meeting-scheduler/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts a child span of the span carried by ctx
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return telemetry.Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,126 @@
+// internal/service/trash_purger.go
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TrashPurgerConfig configures how long deleted rows are kept
+type TrashPurgerConfig struct {
+	Retention time.Duration // How long a deleted row can be restored
+}
+
+// TrashPurger permanently removes deleted events, time slots and availability
+// once they are past retention. It acts across organizations; the audit trail
+// keeps their history and records each removal as a system change.
+type TrashPurger struct {
+	eventRepo        repository.EventRepository
+	timeslotRepo     repository.TimeSlotRepository
+	availabilityRepo repository.AvailabilityRepository
+	transactor       repository.Transactor
+	auditRepo        repository.AuditRepository
+	cfg              TrashPurgerConfig
+}
+
+// NewTrashPurger creates a new TrashPurger
+func NewTrashPurger(
+	eventRepo repository.EventRepository,
+	timeslotRepo repository.TimeSlotRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	transactor repository.Transactor,
+	auditRepo repository.AuditRepository,
+	cfg TrashPurgerConfig,
+) *TrashPurger {
+	return &TrashPurger{
+		eventRepo:        eventRepo,
+		timeslotRepo:     timeslotRepo,
+		availabilityRepo: availabilityRepo,
+		transactor:       transactor,
+		auditRepo:        auditRepo,
+		cfg:              cfg,
+	}
+}
+
+// PurgeExpired removes the rows deleted more than Retention before now and
+// returns how many were removed. Events go first, taking everything about
+// them along, so only rows deleted on their own are left for the rest. Each
+// kind of row is removed in a transaction with its audit entries.
+func (p *TrashPurger) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	ctx, span := startSpan(tenant.System(ctx), "TrashPurger.PurgeExpired")
+	defer span.End()
+
+	before := now.Add(-p.cfg.Retention)
+	var purged int64
+	for _, purge := range []func(context.Context, time.Time) (int, error){
+		p.purgeEvents,
+		p.purgeTimeSlots,
+		p.purgeAvailability,
+	} {
+		var n int
+		err := p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			n, err = purge(ctx, before)
+			return err
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged += int64(n)
+	}
+	span.SetAttributes(attribute.Int64("purged.count", purged))
+	return purged, nil
+}
+
+// purgeEvents removes expired events and audits each removal
+func (p *TrashPurger) purgeEvents(ctx context.Context, before time.Time) (int, error) {
+	events, err := p.eventRepo.Purge(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	for _, event := range events {
+		if err := p.audit(ctx, event.OrganizationID, models.AuditEventPurged, models.AuditEntityEvent, event.ID, event.ID, event); err != nil {
+			return 0, err
+		}
+	}
+	return len(events), nil
+}
+
+// purgeTimeSlots removes expired time slots and audits each removal
+func (p *TrashPurger) purgeTimeSlots(ctx context.Context, before time.Time) (int, error) {
+	slots, err := p.timeslotRepo.Purge(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	for _, slot := range slots {
+		if err := p.audit(ctx, slot.OrganizationID, models.AuditTimeSlotPurged, models.AuditEntityTimeSlot, slot.ID, slot.EventID, slot); err != nil {
+			return 0, err
+		}
+	}
+	return len(slots), nil
+}
+
+// purgeAvailability removes expired availability and audits each removal
+func (p *TrashPurger) purgeAvailability(ctx context.Context, before time.Time) (int, error) {
+	availabilities, err := p.availabilityRepo.Purge(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	for _, availability := range availabilities {
+		if err := p.audit(ctx, availability.OrganizationID, models.AuditAvailabilityPurged, models.AuditEntityAvailability, availability.ID, availability.EventID, availability); err != nil {
+			return 0, err
+		}
+	}
+	return len(availabilities), nil
+}
+
+// audit records the removal of a row in the audit trail of its organization
+func (p *TrashPurger) audit(ctx context.Context, organizationID uuid.UUID, action, entityType string, entityID, eventID uuid.UUID, row interface{}) error {
+	return recordAudit(tenant.WithOrganization(ctx, organizationID), p.auditRepo, action, entityType, entityID, &eventID, row, nil)
+}
@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeExpiredRemovesRowsPastRetention(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	auditRepo := &FakeAuditRepository{}
+	purger := service.NewTrashPurger(eventRepo, timeslotRepo, availabilityRepo, FakeTransactor{}, auditRepo, service.TrashPurgerConfig{Retention: 30 * 24 * time.Hour})
+
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	orgID := uuid.New()
+	eventID := uuid.New()
+	eventRepo.On("Purge", mock.Anything, cutoff).Return([]*models.Event{{ID: eventID, OrganizationID: orgID}}, nil)
+	timeslotRepo.On("Purge", mock.Anything, cutoff).Return([]*models.TimeSlot{
+		{ID: uuid.New(), OrganizationID: orgID, EventID: uuid.New()},
+		{ID: uuid.New(), OrganizationID: orgID, EventID: uuid.New()},
+	}, nil)
+	availabilityRepo.On("Purge", mock.Anything, cutoff).Return([]*models.Availability{
+		{ID: uuid.New(), OrganizationID: orgID, EventID: uuid.New()},
+	}, nil)
+
+	purged, err := purger.PurgeExpired(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), purged)
+	eventRepo.AssertExpectations(t)
+	timeslotRepo.AssertExpectations(t)
+	availabilityRepo.AssertExpectations(t)
+
+	require.Len(t, auditRepo.Entries, 4)
+	entry := auditRepo.Entries[0]
+	assert.Equal(t, models.AuditEventPurged, entry.Action)
+	assert.Equal(t, eventID, entry.EntityID)
+	assert.Equal(t, orgID, entry.OrganizationID)
+	assert.Equal(t, models.AuditActorSystem, entry.ActorType)
+	assert.Nil(t, entry.ActorID)
+	assert.NotEmpty(t, entry.Before)
+	assert.Empty(t, entry.After)
+	assert.Equal(t, models.AuditTimeSlotPurged, auditRepo.Entries[1].Action)
+	assert.Equal(t, models.AuditAvailabilityPurged, auditRepo.Entries[3].Action)
+}
+
+func TestPurgeExpiredStopsOnError(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	purger := service.NewTrashPurger(eventRepo, timeslotRepo, availabilityRepo, FakeTransactor{}, &FakeAuditRepository{}, service.TrashPurgerConfig{Retention: time.Hour})
+
+	eventRepo.On("Purge", mock.Anything, mock.Anything).Return([]*models.Event{{ID: uuid.New(), OrganizationID: uuid.New()}}, nil)
+	timeslotRepo.On("Purge", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	purged, err := purger.PurgeExpired(context.Background(), time.Now())
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, int64(1), purged)
+	availabilityRepo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+}
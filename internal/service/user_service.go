@@ -0,0 +1,72 @@
+// internal/service/user_service.go
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// UserService handles user profiles and scheduling preferences
+type UserService struct {
+	userRepo   repository.UserRepository
+	transactor repository.Transactor
+	auditRepo  repository.AuditRepository
+}
+
+// NewUserService creates a new UserService
+func NewUserService(userRepo repository.UserRepository, transactor repository.Transactor, auditRepo repository.AuditRepository) *UserService {
+	return &UserService{
+		userRepo:   userRepo,
+		transactor: transactor,
+		auditRepo:  auditRepo,
+	}
+}
+
+// GetUser retrieves a user by ID
+func (s *UserService) GetUser(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	ctx, span := startSpan(ctx, "UserService.GetUser", attribute.String("user.id", id.String()))
+	defer span.End()
+
+	return s.userRepo.GetByID(ctx, id)
+}
+
+// GetUsers retrieves the users with the given IDs in one query. Users that
+// do not exist are left out.
+func (s *UserService) GetUsers(ctx context.Context, ids []uuid.UUID) ([]*models.User, error) {
+	ctx, span := startSpan(ctx, "UserService.GetUsers", attribute.Int("user.count", len(ids)))
+	defer span.End()
+
+	return s.userRepo.GetByIDs(ctx, ids)
+}
+
+// UpdatePreferences changes a user's scheduling preferences. They apply to
+// recommendations computed from then on.
+func (s *UserService) UpdatePreferences(ctx context.Context, id uuid.UUID, req *models.UserPreferencesRequest) (*models.User, error) {
+	ctx, span := startSpan(ctx, "UserService.UpdatePreferences", attribute.String("user.id", id.String()))
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *user
+
+	user.DefaultBuffer = *req.DefaultBuffer
+	user.UpdatedAt = time.Now()
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, models.AuditUserUpdated, models.AuditEntityUser, user.ID, nil, &before, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,151 @@
+// internal/service/vote_service.go
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// VoteService handles yes/no/maybe votes on proposed time slots
+type VoteService struct {
+	voteRepo     repository.VoteRepository
+	timeslotRepo repository.TimeSlotRepository
+	eventRepo    repository.EventRepository
+	userRepo     repository.UserRepository
+	transactor   repository.Transactor
+	outboxRepo   repository.OutboxRepository
+}
+
+// NewVoteService creates a new VoteService
+func NewVoteService(
+	voteRepo repository.VoteRepository,
+	timeslotRepo repository.TimeSlotRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository,
+) *VoteService {
+	return &VoteService{
+		voteRepo:     voteRepo,
+		timeslotRepo: timeslotRepo,
+		eventRepo:    eventRepo,
+		userRepo:     userRepo,
+		transactor:   transactor,
+		outboxRepo:   outboxRepo,
+	}
+}
+
+// CastVote records a user's vote on a time slot, replacing the vote they cast
+// on it before. It reports whether the vote is new.
+func (s *VoteService) CastVote(ctx context.Context, timeSlotID, userID uuid.UUID, req *models.VoteRequest) (*models.Vote, bool, error) {
+	ctx, span := startSpan(ctx, "VoteService.CastVote",
+		attribute.String("timeslot.id", timeSlotID.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	slot, err := s.timeslotRepo.GetByID(ctx, timeSlotID)
+	if err != nil {
+		return nil, false, err
+	}
+	event, err := s.eventRepo.GetByID(ctx, slot.EventID)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	vote, err := s.voteRepo.GetByTimeSlotAndUser(ctx, timeSlotID, userID)
+	switch {
+	case err == nil:
+		if vote.Choice == req.Choice {
+			return vote, false, nil
+		}
+	case stderrors.Is(err, errors.ErrVoteNotFound):
+		vote = nil
+	default:
+		return nil, false, err
+	}
+
+	created := vote == nil
+	if created {
+		vote = &models.Vote{
+			EventID:    slot.EventID,
+			TimeSlotID: timeSlotID,
+			UserID:     userID,
+			CreatedAt:  now,
+		}
+	}
+	vote.Choice = req.Choice
+	vote.UpdatedAt = now
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if created {
+			err = s.voteRepo.Create(ctx, vote)
+		} else {
+			err = s.voteRepo.Update(ctx, vote)
+		}
+		if err != nil {
+			return err
+		}
+		return s.recordVote(ctx, models.TopicVoteCast, vote, &event.CreatorID)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return vote, created, nil
+}
+
+// RetractVote removes the vote a user cast on a time slot
+func (s *VoteService) RetractVote(ctx context.Context, timeSlotID, userID uuid.UUID) error {
+	ctx, span := startSpan(ctx, "VoteService.RetractVote",
+		attribute.String("timeslot.id", timeSlotID.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.timeslotRepo.GetByID(ctx, timeSlotID); err != nil {
+		return err
+	}
+	vote, err := s.voteRepo.GetByTimeSlotAndUser(ctx, timeSlotID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.voteRepo.Delete(ctx, vote.ID); err != nil {
+			return err
+		}
+		return s.recordVote(ctx, models.TopicVoteRetracted, vote, nil)
+	})
+}
+
+// ListVotes returns the votes cast on the time slots of an event
+func (s *VoteService) ListVotes(ctx context.Context, eventID uuid.UUID) ([]*models.Vote, error) {
+	ctx, span := startSpan(ctx, "VoteService.ListVotes", attribute.String("event.id", eventID.String()))
+	defer span.End()
+
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	votes, err := s.voteRepo.GetByEventID(ctx, eventID)
+	span.SetAttributes(attribute.Int("votes.count", len(votes)))
+	return votes, err
+}
+
+// recordVote writes a vote lifecycle message to the outbox
+func (s *VoteService) recordVote(ctx context.Context, topic string, vote *models.Vote, creatorID *uuid.UUID) error {
+	return recordLifecycle(ctx, s.outboxRepo, topic, vote.EventID, creatorID, map[string]interface{}{
+		"vote": vote,
+	})
+}
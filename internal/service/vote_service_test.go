@@ -0,0 +1,176 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeVoteRepository keeps votes in memory
+type FakeVoteRepository struct {
+	Votes []*models.Vote
+}
+
+func (f *FakeVoteRepository) Create(ctx context.Context, vote *models.Vote) error {
+	if vote.ID == uuid.Nil {
+		vote.ID = uuid.New()
+	}
+	f.Votes = append(f.Votes, vote)
+	return nil
+}
+
+func (f *FakeVoteRepository) Update(ctx context.Context, vote *models.Vote) error {
+	return nil
+}
+
+func (f *FakeVoteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	for i, vote := range f.Votes {
+		if vote.ID == id {
+			f.Votes = append(f.Votes[:i], f.Votes[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (f *FakeVoteRepository) GetByTimeSlotAndUser(ctx context.Context, timeSlotID, userID uuid.UUID) (*models.Vote, error) {
+	for _, vote := range f.Votes {
+		if vote.TimeSlotID == timeSlotID && vote.UserID == userID {
+			return vote, nil
+		}
+	}
+	return nil, apperrors.ErrVoteNotFound
+}
+
+func (f *FakeVoteRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*models.Vote, error) {
+	var votes []*models.Vote
+	for _, vote := range f.Votes {
+		if vote.EventID == eventID {
+			votes = append(votes, vote)
+		}
+	}
+	return votes, nil
+}
+
+func TestCastVoteCreatesThenChangesTheUsersVote(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	userRepo := new(MockUserRepository)
+	voteRepo := &FakeVoteRepository{}
+	outbox := &FakeOutboxRepository{}
+	voteService := service.NewVoteService(voteRepo, timeslotRepo, eventRepo, userRepo, FakeTransactor{}, outbox)
+
+	event := &models.Event{ID: uuid.New(), CreatorID: uuid.New()}
+	slot := &models.TimeSlot{ID: uuid.New(), EventID: event.ID}
+	userID := uuid.New()
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	timeslotRepo.On("GetByID", mock.Anything, slot.ID).Return(slot, nil)
+	userRepo.On("GetByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
+	ctx := context.Background()
+
+	vote, created, err := voteService.CastVote(ctx, slot.ID, userID, &models.VoteRequest{Choice: models.VoteYes})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, event.ID, vote.EventID)
+	assert.Equal(t, models.VoteYes, vote.Choice)
+
+	// Casting the same choice again changes nothing
+	_, created, err = voteService.CastVote(ctx, slot.ID, userID, &models.VoteRequest{Choice: models.VoteYes})
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Len(t, outbox.Topics(), 1)
+
+	changed, created, err := voteService.CastVote(ctx, slot.ID, userID, &models.VoteRequest{Choice: models.VoteMaybe})
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, vote.ID, changed.ID)
+	assert.Equal(t, models.VoteMaybe, changed.Choice)
+	assert.Len(t, voteRepo.Votes, 1)
+
+	require.NoError(t, voteService.RetractVote(ctx, slot.ID, userID))
+	assert.Empty(t, voteRepo.Votes)
+	assert.ErrorIs(t, voteService.RetractVote(ctx, slot.ID, userID), apperrors.ErrVoteNotFound)
+	assert.Equal(t, []string{models.TopicVoteCast, models.TopicVoteCast, models.TopicVoteRetracted}, outbox.Topics())
+}
+
+func TestRecommendationsScoreFromVotesAvailabilityOrBoth(t *testing.T) {
+	event := &models.Event{ID: uuid.New(), Duration: 60}
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	morning := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+	afternoon := &models.TimeSlot{ID: uuid.New(), EventID: event.ID, StartTime: start.Add(4 * time.Hour), EndTime: start.Add(5 * time.Hour)}
+
+	alice := &models.User{ID: uuid.New(), Name: "Alice", Email: "alice@example.com"}
+	bob := &models.User{ID: uuid.New(), Name: "Bob", Email: "bob@example.com"}
+	carol := &models.User{ID: uuid.New(), Name: "Carol", Email: "carol@example.com"}
+	user := func(u *models.User) models.UserResponse {
+		return models.UserResponse{ID: u.ID, Name: u.Name, Email: u.Email}
+	}
+
+	eventRepo := new(MockEventRepository)
+	timeslotRepo := new(MockTimeSlotRepository)
+	availabilityRepo := new(MockAvailabilityRepository)
+	userRepo := new(MockUserRepository)
+	eventRepo.On("GetByID", mock.Anything, event.ID).Return(event, nil)
+	eventRepo.On("ListConfirmedMeetings", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	timeslotRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.TimeSlot{morning, afternoon}, nil)
+	// Alice and Carol are free in the morning
+	availabilityRepo.On("GetByEventID", mock.Anything, event.ID).Return([]*models.Availability{
+		{UserID: alice.ID, EventID: event.ID, StartTime: start, EndTime: start.Add(2 * time.Hour)},
+		{UserID: carol.ID, EventID: event.ID, StartTime: start, EndTime: start.Add(2 * time.Hour)},
+	}, nil)
+	userRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*models.User{alice, bob, carol}, nil)
+	// Bob prefers the afternoon, and Carol votes against the morning after all
+	voteRepo := &FakeVoteRepository{Votes: []*models.Vote{
+		{EventID: event.ID, TimeSlotID: morning.ID, UserID: bob.ID, Choice: models.VoteMaybe},
+		{EventID: event.ID, TimeSlotID: afternoon.ID, UserID: bob.ID, Choice: models.VoteYes},
+		{EventID: event.ID, TimeSlotID: morning.ID, UserID: carol.ID, Choice: models.VoteNo},
+	}}
+	recommendationService := service.NewRecommendationService(eventRepo, timeslotRepo, availabilityRepo, userRepo, &FakeGuestRepository{}, voteRepo, &FakeParticipantRepository{}, emptyResourceService(), emptyGroupService())
+
+	// Availability only; votes are tallied but do not count
+	event.Scoring = models.ScoringAvailability
+	response, err := recommendationService.GetRecommendations(context.Background(), event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ScoringAvailability, response.Scoring)
+	require.Len(t, response.Recommendations, 2)
+	assert.Equal(t, morning.ID, response.Recommendations[0].TimeSlot.ID)
+	assert.Equal(t, []models.UserResponse{user(alice), user(carol)}, response.Recommendations[0].Attendees)
+	assert.Equal(t, models.VoteTally{No: 1, Maybe: 1}, response.Recommendations[0].Votes)
+	assert.Equal(t, models.VoteTally{Yes: 1}, response.Recommendations[1].Votes)
+
+	// Votes only
+	event.Scoring = models.ScoringVotes
+	response, err = recommendationService.GetRecommendations(context.Background(), event.ID)
+	require.NoError(t, err)
+	require.Len(t, response.Recommendations, 2)
+	assert.Equal(t, afternoon.ID, response.Recommendations[0].TimeSlot.ID)
+	assert.Equal(t, []models.UserResponse{user(bob)}, response.Recommendations[0].Attendees)
+	assert.Equal(t, []models.UserResponse{user(carol)}, response.Recommendations[0].NonAttendees)
+	assert.Equal(t, []models.UserResponse{user(bob)}, response.Recommendations[1].Maybe)
+	assert.Equal(t, 0, response.Recommendations[1].Score)
+
+	// Combined; Carol's vote overrides her availability, and Bob's maybe
+	// breaks the tie in favour of the morning
+	event.Scoring = models.ScoringCombined
+	response, err = recommendationService.GetRecommendations(context.Background(), event.ID)
+	require.NoError(t, err)
+	require.Len(t, response.Recommendations, 2)
+	top := response.Recommendations[0]
+	assert.Equal(t, morning.ID, top.TimeSlot.ID)
+	assert.Equal(t, 1, top.Score)
+	assert.Equal(t, []models.UserResponse{user(alice)}, top.Attendees)
+	assert.Equal(t, []models.UserResponse{user(bob)}, top.Maybe)
+	assert.Equal(t, []models.UserResponse{user(carol)}, top.NonAttendees)
+	assert.Equal(t, afternoon.ID, response.Recommendations[1].TimeSlot.ID)
+	assert.Equal(t, []models.UserResponse{user(bob)}, response.Recommendations[1].Attendees)
+	assert.Equal(t, []models.UserResponse{user(alice), user(carol)}, response.Recommendations[1].NonAttendees)
+}
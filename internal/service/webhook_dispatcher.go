@@ -0,0 +1,160 @@
+// internal/service/webhook_dispatcher.go
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/npkanaka/meeting-scheduler/pkg/timeutil"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookSignatureHeader = "Webhook-Signature"
+	WebhookIDHeader        = "Webhook-Id"
+	WebhookTopicHeader     = "Webhook-Topic"
+)
+
+// WebhookDispatcherConfig configures webhook delivery
+type WebhookDispatcherConfig struct {
+	BatchSize         int
+	RequestTimeout    time.Duration
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	AllowInsecureURLs bool // Development only: deliver over http and to private addresses
+}
+
+// WebhookDispatcher sends queued webhook deliveries and retries failures with exponential backoff
+type WebhookDispatcher struct {
+	webhookRepo repository.WebhookRepository
+	client      *http.Client
+	cfg         WebhookDispatcherConfig
+}
+
+// NewWebhookDispatcher creates a new WebhookDispatcher
+func NewWebhookDispatcher(webhookRepo repository.WebhookRepository, cfg WebhookDispatcherConfig) *WebhookDispatcher {
+	client := publicClient(cfg.RequestTimeout)
+	if cfg.AllowInsecureURLs {
+		client = &http.Client{Timeout: cfg.RequestTimeout}
+	}
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		client:      client,
+		cfg:         cfg,
+	}
+}
+
+// SignWebhookPayload computes the signature sent in the Webhook-Signature header:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">"
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeliverDue sends one batch of due deliveries and returns how many were
+// attempted. Deliveries of every organization are sent.
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (int, error) {
+	ctx = tenant.System(ctx)
+
+	// Lease the batch for longer than the attempts can take
+	lease := 2 * d.cfg.RequestTimeout * time.Duration(d.cfg.BatchSize)
+	deliveries, err := d.webhookRepo.ClaimDueDeliveries(ctx, time.Now(), lease, d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		if err := d.deliver(ctx, delivery); err != nil {
+			return 0, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// deliver makes one attempt at a delivery and records the outcome
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) error {
+	ctx, span := startSpan(ctx, "WebhookDispatcher.deliver",
+		attribute.String("webhook.delivery_id", delivery.ID.String()),
+		attribute.String("webhook.topic", delivery.Topic),
+	)
+	defer span.End()
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+
+	subscription, err := d.webhookRepo.GetSubscription(ctx, delivery.SubscriptionID)
+	switch {
+	case stderrors.Is(err, errors.ErrWebhookSubscriptionNotFound) || (err == nil && !subscription.Active):
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = "subscription removed or inactive"
+		return d.webhookRepo.UpdateDelivery(ctx, delivery)
+	case err != nil:
+		return err
+	}
+
+	status, sendErr := d.send(ctx, subscription, delivery, now)
+	delivery.ResponseStatus = status
+	span.SetAttributes(attribute.Int("http.response.status_code", status), attribute.Int("webhook.attempts", delivery.Attempts))
+
+	if sendErr == nil {
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	} else {
+		delivery.LastError = sendErr.Error()
+		if delivery.Attempts >= d.cfg.MaxAttempts {
+			delivery.Status = models.WebhookDeliveryFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(timeutil.Backoff(delivery.Attempts, d.cfg.InitialBackoff, d.cfg.MaxBackoff))
+		}
+	}
+
+	return d.webhookRepo.UpdateDelivery(ctx, delivery)
+}
+
+// send POSTs the signed payload and treats any 2xx response as success
+func (d *WebhookDispatcher) send(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	if req.URL.Scheme != "https" && !d.cfg.AllowInsecureURLs {
+		return 0, errors.ErrInvalidWebhookURL
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "meeting-scheduler-webhooks")
+	req.Header.Set(WebhookIDHeader, delivery.MessageID.String())
+	req.Header.Set(WebhookTopicHeader, delivery.Topic)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(subscription.Secret, now, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,286 @@
+// internal/service/webhook_service.go
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WebhookConfig configures webhook subscriptions
+type WebhookConfig struct {
+	AllowInsecureURLs bool // Development only: accept http URLs and private addresses
+}
+
+// WebhookService handles webhook subscriptions and turns lifecycle messages into deliveries
+type WebhookService struct {
+	webhookRepo repository.WebhookRepository
+	eventRepo   repository.EventRepository
+	userRepo    repository.UserRepository
+	cfg         WebhookConfig
+}
+
+// NewWebhookService creates a new WebhookService
+func NewWebhookService(
+	webhookRepo repository.WebhookRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	cfg WebhookConfig,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		eventRepo:   eventRepo,
+		userRepo:    userRepo,
+		cfg:         cfg,
+	}
+}
+
+// webhookEnvelope is the body POSTed to subscribers
+type webhookEnvelope struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	EventID   uuid.UUID       `json:"event_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// CreateSubscription registers a new webhook subscription. The URL must use
+// https and resolve to public addresses, and the event or creator it targets
+// must belong to the caller's organization.
+func (s *WebhookService) CreateSubscription(ctx context.Context, req *models.WebhookSubscriptionRequest) (*models.WebhookSubscriptionResponse, error) {
+	ctx, span := startSpan(ctx, "WebhookService.CreateSubscription")
+	defer span.End()
+
+	if (req.EventID == nil) == (req.CreatorID == nil) {
+		return nil, errors.ErrInvalidWebhookSubscription
+	}
+	if !s.cfg.AllowInsecureURLs {
+		if err := checkWebhookURL(ctx, req.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.EventID != nil {
+		// Verify the event exists
+		if _, err := s.eventRepo.GetByID(ctx, *req.EventID); err != nil {
+			return nil, err
+		}
+	} else {
+		// Verify the creator is a user of the caller's organization
+		if _, err := s.userRepo.GetByID(ctx, *req.CreatorID); err != nil {
+			return nil, err
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = generateWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	subscription := &models.WebhookSubscription{
+		EventID:    req.EventID,
+		CreatorID:  req.CreatorID,
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: strings.Join(req.EventTypes, ","),
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.webhookRepo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	response := toWebhookSubscriptionResponse(subscription)
+	// The secret is only revealed once so the subscriber can verify signatures
+	response.Secret = secret
+	return response, nil
+}
+
+// ListSubscriptions returns subscriptions, optionally filtered by event or creator
+func (s *WebhookService) ListSubscriptions(ctx context.Context, eventID, creatorID *uuid.UUID) ([]*models.WebhookSubscriptionResponse, error) {
+	ctx, span := startSpan(ctx, "WebhookService.ListSubscriptions")
+	defer span.End()
+
+	subscriptions, err := s.webhookRepo.ListSubscriptions(ctx, eventID, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.WebhookSubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = toWebhookSubscriptionResponse(subscription)
+	}
+	return responses, nil
+}
+
+// DeleteSubscription removes a webhook subscription
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "WebhookService.DeleteSubscription", attribute.String("webhook.id", id.String()))
+	defer span.End()
+
+	return s.webhookRepo.DeleteSubscription(ctx, id)
+}
+
+// ListDeliveries returns the delivery log of a subscription, most recent first
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, "WebhookService.ListDeliveries", attribute.String("webhook.id", subscriptionID.String()))
+	defer span.End()
+
+	if _, err := s.webhookRepo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.webhookRepo.ListDeliveries(ctx, subscriptionID, limit)
+}
+
+// ReplayDelivery queues a new attempt of a logged delivery with the original payload
+func (s *WebhookService) ReplayDelivery(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, "WebhookService.ReplayDelivery", attribute.String("webhook.delivery_id", deliveryID.String()))
+	defer span.End()
+
+	original, err := s.webhookRepo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	// Deliveries of another organization's subscriptions are not found either
+	if _, err := s.webhookRepo.GetSubscription(ctx, original.SubscriptionID); err != nil {
+		if stderrors.Is(err, errors.ErrWebhookSubscriptionNotFound) {
+			return nil, errors.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	replay := &models.WebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		MessageID:      original.MessageID,
+		Topic:          original.Topic,
+		Payload:        original.Payload,
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := s.webhookRepo.CreateDelivery(ctx, replay); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// HandleOutboxMessage implements OutboxHandler by queuing a delivery for every
+// subscription interested in the message
+func (s *WebhookService) HandleOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	ctx, span := startSpan(ctx, "WebhookService.HandleOutboxMessage",
+		attribute.String("event.id", message.EventID.String()),
+		attribute.String("outbox.topic", message.Topic),
+	)
+	defer span.End()
+
+	creatorID := uuid.Nil
+	if message.CreatorID != nil {
+		creatorID = *message.CreatorID
+	} else {
+		event, err := s.eventRepo.GetByID(ctx, message.EventID)
+		switch {
+		case err == nil:
+			creatorID = event.CreatorID
+		case !stderrors.Is(err, errors.ErrEventNotFound):
+			return err
+		}
+	}
+
+	subscriptions, err := s.webhookRepo.FindActiveSubscriptions(ctx, message.EventID, creatorID)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(webhookEnvelope{
+		ID:        message.ID,
+		Type:      message.Topic,
+		EventID:   message.EventID,
+		CreatedAt: message.CreatedAt,
+		Data:      message.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	queued := 0
+	now := time.Now()
+	for _, subscription := range subscriptions {
+		if !subscribesTo(subscription, message.Topic) {
+			continue
+		}
+		delivery := &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			MessageID:      message.ID,
+			Topic:          message.Topic,
+			Payload:        body,
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+		queued++
+	}
+	span.SetAttributes(attribute.Int("webhook.deliveries", queued))
+
+	return nil
+}
+
+// subscribesTo reports whether a subscription wants messages of the given topic
+func subscribesTo(subscription *models.WebhookSubscription, topic string) bool {
+	if subscription.EventTypes == "" {
+		return true
+	}
+	for _, eventType := range strings.Split(subscription.EventTypes, ",") {
+		if eventType == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// generateWebhookSecret creates a random signing secret
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func toWebhookSubscriptionResponse(subscription *models.WebhookSubscription) *models.WebhookSubscriptionResponse {
+	eventTypes := []string{}
+	if subscription.EventTypes != "" {
+		eventTypes = strings.Split(subscription.EventTypes, ",")
+	}
+	return &models.WebhookSubscriptionResponse{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventID:    subscription.EventID,
+		CreatorID:  subscription.CreatorID,
+		EventTypes: eventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
@@ -0,0 +1,545 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+	"github.com/npkanaka/meeting-scheduler/internal/models"
+	"github.com/npkanaka/meeting-scheduler/internal/search"
+	"github.com/npkanaka/meeting-scheduler/internal/service"
+	"github.com/npkanaka/meeting-scheduler/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeWebhookRepository is an in-memory WebhookRepository
+type FakeWebhookRepository struct {
+	mu            sync.Mutex
+	subscriptions []*models.WebhookSubscription
+	deliveries    []*models.WebhookDelivery
+}
+
+func (f *FakeWebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if subscription.ID == uuid.Nil {
+		subscription.ID = uuid.New()
+	}
+	f.subscriptions = append(f.subscriptions, subscription)
+	return nil
+}
+
+func (f *FakeWebhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, subscription := range f.subscriptions {
+		if subscription.ID == id {
+			return subscription, nil
+		}
+	}
+	return nil, errors.ErrWebhookSubscriptionNotFound
+}
+
+func (f *FakeWebhookRepository) ListSubscriptions(ctx context.Context, eventID, creatorID *uuid.UUID) ([]*models.WebhookSubscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.subscriptions, nil
+}
+
+func (f *FakeWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, subscription := range f.subscriptions {
+		if subscription.ID == id {
+			f.subscriptions = append(f.subscriptions[:i], f.subscriptions[i+1:]...)
+			return nil
+		}
+	}
+	return errors.ErrWebhookSubscriptionNotFound
+}
+
+func (f *FakeWebhookRepository) FindActiveSubscriptions(ctx context.Context, eventID, creatorID uuid.UUID) ([]*models.WebhookSubscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matches []*models.WebhookSubscription
+	for _, subscription := range f.subscriptions {
+		if !subscription.Active {
+			continue
+		}
+		if (subscription.EventID != nil && *subscription.EventID == eventID) ||
+			(subscription.CreatorID != nil && *subscription.CreatorID == creatorID) {
+			matches = append(matches, subscription)
+		}
+	}
+	return matches, nil
+}
+
+func (f *FakeWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if delivery.ID == uuid.Nil {
+		delivery.ID = uuid.New()
+	}
+	f.deliveries = append(f.deliveries, delivery)
+	return nil
+}
+
+func (f *FakeWebhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, delivery := range f.deliveries {
+		if delivery.ID == id {
+			return delivery, nil
+		}
+	}
+	return nil, errors.ErrWebhookDeliveryNotFound
+}
+
+func (f *FakeWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var deliveries []*models.WebhookDelivery
+	for _, delivery := range f.deliveries {
+		if delivery.SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (f *FakeWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var due []*models.WebhookDelivery
+	for _, delivery := range f.deliveries {
+		if delivery.Status == models.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) && len(due) < limit {
+			delivery.NextAttemptAt = now.Add(lease)
+			due = append(due, delivery)
+		}
+	}
+	return due, nil
+}
+
+func (f *FakeWebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return nil
+}
+
+func TestCreateEventWritesOutboxMessage(t *testing.T) {
+	// Setup mocks
+	mockEventRepo := new(MockEventRepository)
+	outbox := &FakeOutboxRepository{}
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, outbox, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
+
+	creatorID := uuid.New()
+	mockEventRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	// Execute the method
+	event, err := eventService.CreateEvent(context.Background(), &models.CreateEventRequest{Title: "Sync", Duration: 30}, creatorID)
+
+	// Assertions
+	require.NoError(t, err)
+	require.Len(t, outbox.Messages, 1)
+	assert.Equal(t, models.TopicEventCreated, outbox.Messages[0].Topic)
+	assert.Equal(t, event.ID, outbox.Messages[0].EventID)
+	assert.Equal(t, &creatorID, outbox.Messages[0].CreatorID)
+}
+
+// testRelayConfig relays batches of ten and dead-letters after three attempts
+var testRelayConfig = service.OutboxRelayConfig{BatchSize: 10, MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Minute}
+
+// outboxHandlerFunc adapts a function to service.OutboxHandler
+type outboxHandlerFunc func(ctx context.Context, message *models.OutboxMessage) error
+
+func (f outboxHandlerFunc) HandleOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	return f(ctx, message)
+}
+
+func TestRelayRunsHandlersInMessageTenant(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	outbox := &FakeOutboxRepository{}
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, outbox, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
+	mockEventRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	orgID := uuid.New()
+	ctx := tenant.WithOrganization(context.Background(), orgID)
+	_, err := eventService.CreateEvent(ctx, &models.CreateEventRequest{Title: "Sync", Duration: 30}, uuid.New())
+	require.NoError(t, err)
+	require.Len(t, outbox.Messages, 1)
+	assert.Equal(t, &orgID, outbox.Messages[0].OrganizationID)
+
+	// The relay itself runs outside any tenant
+	var seen uuid.UUID
+	relay := service.NewOutboxRelay(FakeTransactor{}, outbox, testRelayConfig, outboxHandlerFunc(func(ctx context.Context, message *models.OutboxMessage) error {
+		seen, _ = tenant.OrganizationID(ctx)
+		return nil
+	}))
+	n, err := relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, orgID, seen)
+}
+
+func TestRelayIsolatesFailingMessages(t *testing.T) {
+	outbox := &FakeOutboxRepository{}
+	bad, good := uuid.New(), uuid.New()
+	for _, eventID := range []uuid.UUID{bad, good, good} {
+		require.NoError(t, outbox.Create(context.Background(), &models.OutboxMessage{
+			Topic:     models.TopicEventUpdated,
+			EventID:   eventID,
+			Payload:   json.RawMessage(`{}`),
+			CreatedAt: time.Now(),
+		}))
+	}
+
+	var handled []uuid.UUID
+	relay := service.NewOutboxRelay(FakeTransactor{}, outbox, testRelayConfig, outboxHandlerFunc(func(ctx context.Context, message *models.OutboxMessage) error {
+		if message.EventID == bad {
+			return assert.AnError
+		}
+		handled = append(handled, message.ID)
+		return nil
+	}))
+
+	// The failing message at the head of the batch does not hold up the others
+	n, err := relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []uuid.UUID{outbox.Messages[1].ID, outbox.Messages[2].ID}, handled)
+	assert.NotNil(t, outbox.Messages[1].ProcessedAt)
+	assert.NotNil(t, outbox.Messages[2].ProcessedAt)
+
+	failed := outbox.Messages[0]
+	assert.Nil(t, failed.ProcessedAt)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, assert.AnError.Error(), failed.LastError)
+	assert.True(t, failed.NextAttemptAt.After(time.Now()))
+	assert.Nil(t, failed.DeadAt)
+
+	// It is backed off rather than claimed again straight away
+	n, err = relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// and dead-lettered once it used up its attempts
+	for i := 1; i < testRelayConfig.MaxAttempts; i++ {
+		failed.NextAttemptAt = time.Now()
+		_, err = relay.RelayOnce(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, testRelayConfig.MaxAttempts, failed.Attempts)
+	assert.Nil(t, failed.ProcessedAt)
+	assert.Len(t, handled, 2)
+}
+
+func TestPublishEventRequiresDraft(t *testing.T) {
+	// Setup mocks
+	mockEventRepo := new(MockEventRepository)
+	outbox := &FakeOutboxRepository{}
+	eventService := service.NewEventService(mockEventRepo, new(MockTimeSlotRepository), FakeTransactor{}, outbox, &FakeAuditRepository{}, search.NewMemoryIndex(), emptyResourceService())
+
+	eventID := uuid.New()
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&models.Event{ID: eventID, Status: models.EventStatusActive}, nil)
+
+	// Execute the method
+	event, err := eventService.PublishEvent(context.Background(), eventID)
+
+	// Assertions
+	assert.ErrorIs(t, err, errors.ErrInvalidStatusTransition)
+	assert.Nil(t, event)
+	assert.Empty(t, outbox.Messages)
+}
+
+func TestWebhookSubscriptionRequiresSingleTarget(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	webhookService := service.NewWebhookService(&FakeWebhookRepository{}, new(MockEventRepository), mockUserRepo, service.WebhookConfig{})
+	eventID, creatorID := uuid.New(), uuid.New()
+	mockUserRepo.On("GetByID", mock.Anything, creatorID).Return(&models.User{ID: creatorID}, nil)
+
+	_, err := webhookService.CreateSubscription(context.Background(), &models.WebhookSubscriptionRequest{URL: "https://203.0.113.10/hooks"})
+	assert.ErrorIs(t, err, errors.ErrInvalidWebhookSubscription)
+
+	_, err = webhookService.CreateSubscription(context.Background(), &models.WebhookSubscriptionRequest{
+		URL:       "https://203.0.113.10/hooks",
+		EventID:   &eventID,
+		CreatorID: &creatorID,
+	})
+	assert.ErrorIs(t, err, errors.ErrInvalidWebhookSubscription)
+
+	subscription, err := webhookService.CreateSubscription(context.Background(), &models.WebhookSubscriptionRequest{
+		URL:       "https://203.0.113.10/hooks",
+		CreatorID: &creatorID,
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(subscription.Secret, "whsec_"))
+}
+
+func TestWebhookSubscriptionRejectsInternalURLs(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	webhookService := service.NewWebhookService(&FakeWebhookRepository{}, new(MockEventRepository), mockUserRepo, service.WebhookConfig{})
+	creatorID := uuid.New()
+	mockUserRepo.On("GetByID", mock.Anything, creatorID).Return(&models.User{ID: creatorID}, nil)
+
+	for _, url := range []string{
+		"http://203.0.113.10/hooks",
+		"https://127.0.0.1/hooks",
+		"https://localhost:8443/hooks",
+		"https://10.1.2.3/hooks",
+		"https://192.168.0.10/hooks",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/hooks",
+		"https://[fe80::1]/hooks",
+		"https://0.0.0.0/hooks",
+	} {
+		_, err := webhookService.CreateSubscription(context.Background(), &models.WebhookSubscriptionRequest{URL: url, CreatorID: &creatorID})
+		assert.ErrorIs(t, err, errors.ErrInvalidWebhookURL, url)
+	}
+
+	// Local receivers are allowed in development
+	webhookService = service.NewWebhookService(&FakeWebhookRepository{}, new(MockEventRepository), mockUserRepo, service.WebhookConfig{AllowInsecureURLs: true})
+	_, err := webhookService.CreateSubscription(context.Background(), &models.WebhookSubscriptionRequest{URL: "http://localhost:8080/hooks", CreatorID: &creatorID})
+	assert.NoError(t, err)
+}
+
+func TestWebhookSubscriptionRequiresCreatorInOrganization(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	webhookRepo := &FakeWebhookRepository{}
+	webhookService := service.NewWebhookService(webhookRepo, new(MockEventRepository), mockUserRepo, service.WebhookConfig{})
+	// The user repository only finds users of the caller's organization
+	outsiderID := uuid.New()
+	mockUserRepo.On("GetByID", mock.Anything, outsiderID).Return(nil, errors.ErrUserNotFound)
+
+	_, err := webhookService.CreateSubscription(context.Background(), &models.WebhookSubscriptionRequest{
+		URL:       "https://203.0.113.10/hooks",
+		CreatorID: &outsiderID,
+	})
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+	assert.Empty(t, webhookRepo.subscriptions)
+}
+
+func TestRelayQueuesDeliveriesForMatchingSubscriptions(t *testing.T) {
+	// Setup mocks
+	mockEventRepo := new(MockEventRepository)
+	webhookRepo := &FakeWebhookRepository{}
+	outbox := &FakeOutboxRepository{}
+	webhookService := service.NewWebhookService(webhookRepo, mockEventRepo, new(MockUserRepository), service.WebhookConfig{})
+	relay := service.NewOutboxRelay(FakeTransactor{}, outbox, testRelayConfig, webhookService)
+
+	eventID, creatorID, otherID := uuid.New(), uuid.New(), uuid.New()
+	subscribe := func(eventID, creatorID *uuid.UUID, types string) *models.WebhookSubscription {
+		subscription := &models.WebhookSubscription{EventID: eventID, CreatorID: creatorID, URL: "https://example.com", EventTypes: types, Active: true}
+		require.NoError(t, webhookRepo.CreateSubscription(context.Background(), subscription))
+		return subscription
+	}
+	byEvent := subscribe(&eventID, nil, "")
+	byCreator := subscribe(nil, &creatorID, models.TopicTimeSlotFinalized)
+	subscribe(nil, &creatorID, models.TopicEventDeleted)
+	subscribe(nil, &otherID, "")
+
+	// The creator is unknown when the message is written and resolved from the event
+	mockEventRepo.On("GetByID", mock.Anything, eventID).Return(&models.Event{ID: eventID, CreatorID: creatorID}, nil)
+	require.NoError(t, outbox.Create(context.Background(), &models.OutboxMessage{
+		Topic:     models.TopicTimeSlotFinalized,
+		EventID:   eventID,
+		Payload:   json.RawMessage(`{"time_slot":{"id":"slot"}}`),
+		CreatedAt: time.Now(),
+	}))
+
+	// Execute the method
+	relayed, err := relay.RelayOnce(context.Background())
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Equal(t, 1, relayed)
+	assert.NotNil(t, outbox.Messages[0].ProcessedAt)
+
+	var subscriptionIDs []string
+	for _, delivery := range webhookRepo.deliveries {
+		subscriptionIDs = append(subscriptionIDs, delivery.SubscriptionID.String())
+		assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+
+		var envelope map[string]interface{}
+		require.NoError(t, json.Unmarshal(delivery.Payload, &envelope))
+		assert.Equal(t, models.TopicTimeSlotFinalized, envelope["type"])
+		assert.Equal(t, eventID.String(), envelope["event_id"])
+		assert.Equal(t, map[string]interface{}{"time_slot": map[string]interface{}{"id": "slot"}}, envelope["data"])
+	}
+	expected := []string{byEvent.ID.String(), byCreator.ID.String()}
+	sort.Strings(expected)
+	sort.Strings(subscriptionIDs)
+	assert.Equal(t, expected, subscriptionIDs)
+}
+
+func TestWebhookDispatcherSignsAndRetriesWithBackoff(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []*http.Request
+		bodies   [][]byte
+	)
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	webhookRepo := &FakeWebhookRepository{}
+	subscription := &models.WebhookSubscription{URL: server.URL, Secret: "whsec_test", Active: true}
+	require.NoError(t, webhookRepo.CreateSubscription(context.Background(), subscription))
+	delivery := &models.WebhookDelivery{
+		SubscriptionID: subscription.ID,
+		MessageID:      uuid.New(),
+		Topic:          models.TopicEventCreated,
+		Payload:        json.RawMessage(`{"type":"event.created"}`),
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  time.Now(),
+	}
+	require.NoError(t, webhookRepo.CreateDelivery(context.Background(), delivery))
+
+	dispatcher := service.NewWebhookDispatcher(webhookRepo, service.WebhookDispatcherConfig{
+		BatchSize:      10,
+		RequestTimeout: time.Second,
+		MaxAttempts:    3,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+		// The test server listens on loopback over http
+		AllowInsecureURLs: true,
+	})
+
+	// The first attempt fails and is rescheduled after the initial backoff
+	attempted, err := dispatcher.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), delivery.NextAttemptAt, 5*time.Second)
+
+	// Nothing is due until the backoff has passed
+	attempted, err = dispatcher.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, attempted)
+
+	delivery.NextAttemptAt = time.Now()
+	attempted, err = dispatcher.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	assert.Equal(t, models.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.NotNil(t, delivery.DeliveredAt)
+
+	// Every request carries a signature the subscriber can verify
+	require.Len(t, requests, 2)
+	for i, r := range requests {
+		assert.Equal(t, delivery.MessageID.String(), r.Header.Get(service.WebhookIDHeader))
+		assert.Equal(t, models.TopicEventCreated, r.Header.Get(service.WebhookTopicHeader))
+
+		signature := r.Header.Get(service.WebhookSignatureHeader)
+		timestamp, _, ok := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+		require.True(t, ok)
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, service.SignWebhookPayload("whsec_test", time.Unix(unix, 0), bodies[i]), signature)
+	}
+}
+
+func TestWebhookDispatcherGivesUpAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhookRepo := &FakeWebhookRepository{}
+	subscription := &models.WebhookSubscription{URL: server.URL, Secret: "whsec_test", Active: true}
+	require.NoError(t, webhookRepo.CreateSubscription(context.Background(), subscription))
+	delivery := &models.WebhookDelivery{
+		SubscriptionID: subscription.ID,
+		Payload:        json.RawMessage(`{}`),
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  time.Now(),
+	}
+	require.NoError(t, webhookRepo.CreateDelivery(context.Background(), delivery))
+
+	dispatcher := service.NewWebhookDispatcher(webhookRepo, service.WebhookDispatcherConfig{
+		BatchSize:      10,
+		RequestTimeout: time.Second,
+		MaxAttempts:    2,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+		// The test server listens on loopback over http
+		AllowInsecureURLs: true,
+	})
+
+	for i := 0; i < 2; i++ {
+		delivery.NextAttemptAt = time.Now()
+		_, err := dispatcher.DeliverDue(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, models.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Contains(t, delivery.LastError, "500")
+
+	// A failed delivery can be replayed with the original payload
+	webhookService := service.NewWebhookService(webhookRepo, new(MockEventRepository), new(MockUserRepository), service.WebhookConfig{})
+	replay, err := webhookService.ReplayDelivery(context.Background(), delivery.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, delivery.ID, replay.ID)
+	assert.Equal(t, models.WebhookDeliveryPending, replay.Status)
+	assert.Zero(t, replay.Attempts)
+	assert.JSONEq(t, string(delivery.Payload), string(replay.Payload))
+}
+
+func TestWebhookDispatcherRefusesInternalAddresses(t *testing.T) {
+	var reached bool
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+	defer server.Close()
+
+	webhookRepo := &FakeWebhookRepository{}
+	subscription := &models.WebhookSubscription{URL: server.URL, Secret: "whsec_test", Active: true}
+	require.NoError(t, webhookRepo.CreateSubscription(context.Background(), subscription))
+	delivery := &models.WebhookDelivery{
+		SubscriptionID: subscription.ID,
+		Payload:        json.RawMessage(`{}`),
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  time.Now(),
+	}
+	require.NoError(t, webhookRepo.CreateDelivery(context.Background(), delivery))
+
+	dispatcher := service.NewWebhookDispatcher(webhookRepo, service.WebhookDispatcherConfig{
+		BatchSize:      10,
+		RequestTimeout: time.Second,
+		MaxAttempts:    3,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+	})
+
+	// The https loopback address is refused when the connection is dialed
+	_, err := dispatcher.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.False(t, reached)
+	assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+	assert.Contains(t, delivery.LastError, errors.ErrInvalidWebhookURL.Error())
+}
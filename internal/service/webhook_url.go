@@ -0,0 +1,81 @@
+// internal/service/webhook_url.go
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/npkanaka/meeting-scheduler/internal/errors"
+)
+
+// checkWebhookURL rejects a webhook URL that is not https or whose host
+// resolves to an address webhooks must not reach
+func checkWebhookURL(ctx context.Context, rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil || target.Scheme != "https" || target.Hostname() == "" {
+		return errors.ErrInvalidWebhookURL
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, target.Hostname())
+	if err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrInvalidWebhookURL, err)
+	}
+	for _, addr := range addrs {
+		if !publicAddress(addr.IP) {
+			return errors.ErrInvalidWebhookURL
+		}
+	}
+	return nil
+}
+
+// publicAddress reports whether ip is outside the loopback, private,
+// link-local and unspecified ranges
+func publicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsUnspecified()
+}
+
+// publicClient returns an HTTP client that only connects to public addresses
+// over https. The address is checked after it is resolved, so a host that
+// passed checkWebhookURL cannot later be pointed inside the network.
+func publicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicAddress(ip) {
+				return fmt.Errorf("%w: %s is not a public address", errors.ErrInvalidWebhookURL, host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would connect on our behalf, past the dialer's check
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return errors.ErrInvalidWebhookURL
+			}
+			if len(via) >= 10 {
+				return stderrors.New("stopped after 10 redirects")
+			}
+			return nil
+		},
+	}
+}
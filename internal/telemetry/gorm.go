@@ -0,0 +1,91 @@
+// internal/telemetry/gorm.go
+package telemetry
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "telemetry:span"
+
+// GormPlugin creates a span for every GORM query executed with a context
+type GormPlugin struct{}
+
+// NewGormPlugin creates a new GormPlugin
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+// Name implements gorm.Plugin
+func (p *GormPlugin) Name() string {
+	return "telemetry"
+}
+
+// Initialize registers the before and after callbacks for every GORM operation
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	registrations := []error{
+		cb.Create().Before("gorm:create").Register("telemetry:before_create", p.before("create")),
+		cb.Create().After("gorm:create").Register("telemetry:after_create", p.after),
+		cb.Query().Before("gorm:query").Register("telemetry:before_query", p.before("query")),
+		cb.Query().After("gorm:query").Register("telemetry:after_query", p.after),
+		cb.Update().Before("gorm:update").Register("telemetry:before_update", p.before("update")),
+		cb.Update().After("gorm:update").Register("telemetry:after_update", p.after),
+		cb.Delete().Before("gorm:delete").Register("telemetry:before_delete", p.before("delete")),
+		cb.Delete().After("gorm:delete").Register("telemetry:after_delete", p.after),
+		cb.Row().Before("gorm:row").Register("telemetry:before_row", p.before("row")),
+		cb.Row().After("gorm:row").Register("telemetry:after_row", p.after),
+		cb.Raw().Before("gorm:raw").Register("telemetry:before_raw", p.before("raw")),
+		cb.Raw().After("gorm:raw").Register("telemetry:after_raw", p.after),
+	}
+
+	for _, err := range registrations {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// before starts a span for the operation and stores it on the statement
+func (p *GormPlugin) before(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		if tx.Statement == nil || tx.Statement.Context == nil {
+			return
+		}
+		ctx, span := Tracer().Start(tx.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "postgresql"),
+				attribute.String("db.operation", operation),
+			),
+		)
+		tx.Statement.Context = ctx
+		tx.InstanceSet(gormSpanKey, span)
+	}
+}
+
+// after records the statement, table and row count and ends the span
+func (p *GormPlugin) after(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.sql.table", tx.Statement.Table),
+		attribute.String("db.statement", tx.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+	)
+	if tx.Error != nil && tx.Error != gorm.ErrRecordNotFound {
+		span.RecordError(tx.Error)
+		span.SetStatus(codes.Error, tx.Error.Error())
+	}
+}
@@ -0,0 +1,40 @@
+// Package tenant carries the organization a request or background task acts
+// for. Repositories of tenant-owned data restrict every query to it.
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ctxKey is the context key for the organization ID
+type ctxKey struct{}
+
+// systemKey is the context key marking contexts that act for the system
+type systemKey struct{}
+
+// WithOrganization returns a copy of ctx acting for the given organization
+func WithOrganization(ctx context.Context, organizationID uuid.UUID) context.Context {
+	return context.WithValue(ctx, ctxKey{}, organizationID)
+}
+
+// OrganizationID returns the organization ctx acts for
+func OrganizationID(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(ctxKey{}).(uuid.UUID)
+	return id, ok
+}
+
+// System returns a copy of ctx acting for the system rather than for one
+// organization. Background tasks and admin routes use it to reach the data of
+// every organization; any other context without an organization reaches none.
+func System(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemKey{}, true)
+}
+
+// IsSystem reports whether ctx acts for the system. An organization set on top
+// of a system context still restricts it to that organization.
+func IsSystem(ctx context.Context) bool {
+	system, _ := ctx.Value(systemKey{}).(bool)
+	return system
+}
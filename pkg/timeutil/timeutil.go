@@ -22,6 +22,20 @@ func (tr TimeRange) Contains(other TimeRange) bool {
 		(tr.End.After(other.End) || tr.End.Equal(other.End))
 }
 
+// Intersects checks if this time range shares a period of time with another.
+// Unlike Overlaps, ranges that only touch do not intersect.
+func (tr TimeRange) Intersects(other TimeRange) bool {
+	return tr.Start.Before(other.End) && tr.End.After(other.Start)
+}
+
+// Pad returns the time range extended by before at the start and after at the end
+func (tr TimeRange) Pad(before, after time.Duration) TimeRange {
+	return TimeRange{
+		Start: tr.Start.Add(-before),
+		End:   tr.End.Add(after),
+	}
+}
+
 // Duration returns the duration of the time range
 func (tr TimeRange) Duration() time.Duration {
 	return tr.End.Sub(tr.Start)
@@ -78,6 +92,19 @@ func FindCommonAvailability(availabilities []TimeRange) []TimeRange {
 	return commonRanges
 }
 
+// Backoff returns the delay before the next attempt of a retried operation:
+// initial doubled for every attempt after the first, capped at max
+func Backoff(attempts int, initial, max time.Duration) time.Duration {
+	delay := initial
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
+
 // FormatTime formats a time in RFC3339 format
 func FormatTime(t time.Time) string {
 	return t.Format(time.RFC3339)
@@ -13,55 +13,393 @@ echo "Running migrations..."
 echo "Creating database tables..."
 psql -h postgres -p 5432 -U postgres -d meeting-scheduler -c "
 -- Drop tables if they exist with cascade to avoid dependency issues
+DROP TABLE IF EXISTS resource_bookings CASCADE;
+DROP TABLE IF EXISTS resources CASCADE;
+DROP TABLE IF EXISTS votes CASCADE;
+DROP TABLE IF EXISTS guests CASCADE;
+DROP TABLE IF EXISTS share_links CASCADE;
+DROP TABLE IF EXISTS idempotency_records CASCADE;
+DROP TABLE IF EXISTS job_schedules CASCADE;
+DROP TABLE IF EXISTS jobs CASCADE;
+DROP TABLE IF EXISTS event_decisions CASCADE;
+DROP TABLE IF EXISTS notifications CASCADE;
+DROP TABLE IF EXISTS event_participants CASCADE;
+DROP TABLE IF EXISTS event_groups CASCADE;
+DROP TABLE IF EXISTS group_members CASCADE;
+DROP TABLE IF EXISTS groups CASCADE;
+DROP TABLE IF EXISTS webhook_deliveries CASCADE;
+DROP TABLE IF EXISTS webhook_subscriptions CASCADE;
+DROP TABLE IF EXISTS outbox_messages CASCADE;
+DROP TABLE IF EXISTS audit_entries CASCADE;
+DROP FUNCTION IF EXISTS audit_entries_append_only() CASCADE;
 DROP TABLE IF EXISTS availabilities CASCADE;
 DROP TABLE IF EXISTS time_slots CASCADE;
 DROP TABLE IF EXISTS users CASCADE;
 DROP TABLE IF EXISTS events CASCADE;
+DROP TABLE IF EXISTS organizations CASCADE;
 
 -- Create tables in correct order
+-- Tenants; every tenant-owned row names its organization and is only
+-- visible to callers acting for it
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
 CREATE TABLE events (
     id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
     title VARCHAR(255) NOT NULL,
     description TEXT,
     creator_id UUID NOT NULL,
     duration INT NOT NULL,
     status VARCHAR(50) NOT NULL,
+    final_time_slot_id UUID,
+    response_deadline TIMESTAMP,
+    auto_finalize VARCHAR(50) NOT NULL DEFAULT 'none',
+    quorum INT NOT NULL DEFAULT 1,
+    quorum_percent INT NOT NULL DEFAULT 0,
+    required_groups JSONB,
+    scoring VARCHAR(50) NOT NULL DEFAULT 'availability',
+    resource_requirement JSONB,
+    buffer_before INT NOT NULL DEFAULT 0,
+    buffer_after INT NOT NULL DEFAULT 0,
+    resource_id UUID,
+    deadline_processed_at TIMESTAMP,
+    version INT NOT NULL DEFAULT 1,
+    search_vector TSVECTOR, -- Weighted title and description words, kept current by the application
     created_at TIMESTAMP NOT NULL,
-    updated_at TIMESTAMP NOT NULL
+    updated_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP,
+    UNIQUE (id, organization_id)
 );
 
+-- Time slots and availability always belong to the organization of their event.
+-- Deleting an event sets deleted_at on it and its children; the purge job
+-- removes rows once they are past retention
 CREATE TABLE time_slots (
     id UUID PRIMARY KEY,
-    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL,
+    event_id UUID NOT NULL,
     start_time TIMESTAMP NOT NULL,
     end_time TIMESTAMP NOT NULL,
+    version INT NOT NULL DEFAULT 1,
     created_at TIMESTAMP NOT NULL,
-    updated_at TIMESTAMP NOT NULL
+    updated_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP,
+    FOREIGN KEY (event_id, organization_id) REFERENCES events(id, organization_id) ON DELETE CASCADE
 );
 
 CREATE TABLE users (
     id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
     name VARCHAR(255) NOT NULL,
-    email VARCHAR(255) UNIQUE NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    default_buffer INT NOT NULL DEFAULT 0,
     created_at TIMESTAMP NOT NULL,
-    updated_at TIMESTAMP NOT NULL
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (organization_id, email)
 );
 
+-- user_id is a users.id, or a guests.id for responses through a share link
 CREATE TABLE availabilities (
     id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    version INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP,
+    FOREIGN KEY (event_id, organization_id) REFERENCES events(id, organization_id) ON DELETE CASCADE
+);
+
+-- Teams that can be invited as a unit; members are users or other groups
+CREATE TABLE groups (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE group_members (
+    id UUID PRIMARY KEY,
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    member_group_id UUID REFERENCES groups(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    CHECK ((user_id IS NULL) <> (member_group_id IS NULL)),
+    UNIQUE (group_id, user_id),
+    UNIQUE (group_id, member_group_id)
+);
+
+-- Groups invited to an event; their members are kept in event_participants
+CREATE TABLE event_groups (
+    id UUID PRIMARY KEY,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (event_id, group_id)
+);
+
+CREATE TABLE event_participants (
+    id UUID PRIMARY KEY,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    group_id UUID REFERENCES groups(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (event_id, user_id)
+);
+
+-- Yes/no/maybe answers to proposed time slots, one per user and slot
+CREATE TABLE votes (
+    id UUID PRIMARY KEY,
     event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    time_slot_id UUID NOT NULL REFERENCES time_slots(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    choice VARCHAR(10) NOT NULL CHECK (choice IN ('yes', 'no', 'maybe')),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (time_slot_id, user_id)
+);
+
+-- Rooms, equipment and video bridges events can require
+CREATE TABLE resources (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    capacity INT NOT NULL DEFAULT 0,
+    attributes JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Reservations of a resource; the exclusion constraint backs up the row lock
+-- taken while booking, so a resource can never be double-booked
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+CREATE TABLE resource_bookings (
+    id UUID PRIMARY KEY,
+    resource_id UUID NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+    event_id UUID REFERENCES events(id) ON DELETE CASCADE,
+    time_slot_id UUID REFERENCES time_slots(id) ON DELETE CASCADE,
     start_time TIMESTAMP NOT NULL,
     end_time TIMESTAMP NOT NULL,
+    note TEXT,
+    created_at TIMESTAMP NOT NULL,
+    EXCLUDE USING gist (resource_id WITH =, tsrange(start_time, end_time) WITH &&)
+);
+
+-- Share links let people without an account respond to one event
+CREATE TABLE share_links (
+    id UUID PRIMARY KEY,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Participants without an account, identified by email within their event
+CREATE TABLE guests (
+    id UUID PRIMARY KEY,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    share_link_id UUID NOT NULL REFERENCES share_links(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Rendered emails waiting to be sent; dedupe_key keeps each notification from being queued twice
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    event_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    recipient VARCHAR(512) NOT NULL,
+    dedupe_key VARCHAR(255) NOT NULL UNIQUE,
+    subject TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    html_body TEXT NOT NULL DEFAULT '',
+    calendar TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- What the deadline scheduler decided when an event's response deadline passed
+CREATE TABLE event_decisions (
+    id UUID PRIMARY KEY,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    policy VARCHAR(50) NOT NULL,
+    outcome VARCHAR(50) NOT NULL,
+    time_slot_id UUID,
+    attendees INT NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL,
+    decided_at TIMESTAMP NOT NULL
+);
+
+-- Background jobs; rows are deleted when a job succeeds and kept as dead letters when it runs out of attempts
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL,
+    run_at TIMESTAMP NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Next run of each recurring job, shared by all replicas
+CREATE TABLE job_schedules (
+    name VARCHAR(100) PRIMARY KEY,
+    next_run_at TIMESTAMP NOT NULL
+);
+
+-- First responses to POST requests sent with an Idempotency-Key, replayed to
+-- retries until they expire. Callers are user IDs or client IPs.
+CREATE TABLE idempotency_records (
+    caller VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    status_code INT NOT NULL DEFAULT 0,
+    header JSONB,
+    body BYTEA,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (caller, key)
+);
+
+-- Lifecycle messages written in the same transaction as the change they describe
+CREATE TABLE outbox_messages (
+    id UUID PRIMARY KEY,
+    organization_id UUID,
+    topic VARCHAR(100) NOT NULL,
+    event_id UUID NOT NULL,
+    creator_id UUID,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    processed_at TIMESTAMP,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    dead_at TIMESTAMP
+);
+
+-- Append-only trail of changes to scheduling data; no foreign keys, so
+-- entries outlive what they describe
+CREATE TABLE audit_entries (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    event_id UUID,
+    actor_type VARCHAR(20) NOT NULL,
+    actor_id UUID,
+    action VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    before JSONB,
+    after JSONB,
+    request_id VARCHAR(128),
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE FUNCTION audit_entries_append_only() RETURNS trigger AS \$\$
+BEGIN
+    RAISE EXCEPTION 'audit entries cannot be changed or removed';
+END;
+\$\$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_entries_no_change BEFORE UPDATE OR DELETE ON audit_entries
+    FOR EACH ROW EXECUTE FUNCTION audit_entries_append_only();
+CREATE TRIGGER audit_entries_no_truncate BEFORE TRUNCATE ON audit_entries
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_entries_append_only();
+
+CREATE TABLE webhook_subscriptions (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    event_id UUID REFERENCES events(id) ON DELETE CASCADE,
+    creator_id UUID,
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types TEXT NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CHECK ((event_id IS NULL) <> (creator_id IS NULL))
+);
+
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    message_id UUID NOT NULL,
+    topic VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    response_status INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    delivered_at TIMESTAMP,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL
 );
 
 -- Indexes for better query performance
-CREATE INDEX idx_time_slots_event_id ON time_slots(event_id);
+CREATE INDEX idx_events_organization_created ON events(organization_id, created_at, id);
+CREATE INDEX idx_users_organization_id ON users(organization_id);
+CREATE INDEX idx_groups_organization_id ON groups(organization_id);
+CREATE INDEX idx_resources_organization_id ON resources(organization_id);
+CREATE INDEX idx_webhook_subscriptions_organization_id ON webhook_subscriptions(organization_id);
+CREATE INDEX idx_time_slots_event_start ON time_slots(event_id, start_time, id);
 CREATE INDEX idx_availabilities_user_id ON availabilities(user_id);
-CREATE INDEX idx_availabilities_event_id ON availabilities(event_id);
+CREATE INDEX idx_availabilities_event_start ON availabilities(event_id, start_time, id);
 CREATE INDEX idx_availabilities_user_event ON availabilities(user_id, event_id);
+CREATE INDEX idx_events_deleted_at ON events(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_time_slots_deleted_at ON time_slots(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_availabilities_deleted_at ON availabilities(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_events_response_deadline ON events(response_deadline) WHERE status = 'active';
+CREATE INDEX idx_events_deadline_due ON events(response_deadline) WHERE status = 'active' AND deadline_processed_at IS NULL;
+CREATE INDEX idx_events_creator_id ON events(creator_id);
+CREATE INDEX idx_events_search_vector ON events USING GIN (search_vector);
+CREATE INDEX idx_events_finalized_creator_id ON events(creator_id) WHERE status = 'finalized';
+CREATE INDEX idx_jobs_due ON jobs(run_at) WHERE status IN ('pending', 'running');
+CREATE INDEX idx_jobs_kind ON jobs(kind) WHERE status IN ('pending', 'running');
+CREATE INDEX idx_jobs_dead ON jobs(updated_at DESC) WHERE status = 'dead';
+CREATE INDEX idx_event_decisions_event_id ON event_decisions(event_id, decided_at);
+CREATE INDEX idx_votes_event_id ON votes(event_id);
+CREATE INDEX idx_resources_kind ON resources(kind, capacity);
+CREATE INDEX idx_resource_bookings_event_id ON resource_bookings(event_id);
+CREATE INDEX idx_event_participants_user_id ON event_participants(user_id);
+CREATE INDEX idx_event_participants_group_id ON event_participants(group_id) WHERE group_id IS NOT NULL;
+CREATE INDEX idx_group_members_member_group_id ON group_members(member_group_id) WHERE member_group_id IS NOT NULL;
+CREATE INDEX idx_event_groups_group_id ON event_groups(group_id);
+CREATE INDEX idx_share_links_event_id ON share_links(event_id, created_at DESC);
+CREATE UNIQUE INDEX idx_guests_event_email ON guests(event_id, LOWER(email));
+CREATE INDEX idx_notifications_due ON notifications(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages(next_attempt_at) WHERE processed_at IS NULL AND dead_at IS NULL;
+CREATE INDEX idx_audit_entries_event_id ON audit_entries(event_id, created_at DESC) WHERE event_id IS NOT NULL;
+CREATE INDEX idx_audit_entries_organization_id ON audit_entries(organization_id, created_at DESC);
+CREATE INDEX idx_audit_entries_actor_id ON audit_entries(actor_id, created_at DESC) WHERE actor_id IS NOT NULL;
+CREATE INDEX idx_audit_entries_entity_id ON audit_entries(entity_id, created_at DESC);
+CREATE INDEX idx_webhook_subscriptions_event_id ON webhook_subscriptions(event_id);
+CREATE INDEX idx_webhook_subscriptions_creator_id ON webhook_subscriptions(creator_id);
+CREATE INDEX idx_webhook_deliveries_subscription_id ON webhook_deliveries(subscription_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_idempotency_records_expires_at ON idempotency_records(expires_at);
 "
 
 if [ $? -eq 0 ]; then
@@ -76,46 +414,71 @@ echo "Inserting sample data..."
 psql -h postgres -p 5432 -U postgres -d meeting-scheduler -c "
 -- Sample Data Insertion Script for Meeting Scheduler API
 
+-- Organizations for testing tenant isolation
+INSERT INTO organizations (id, name, created_at, updated_at) VALUES
+('00000000-0000-0000-0000-000000000001', 'Acme Engineering', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000002', 'Globex', NOW(), NOW());
+
 -- Users for testing different scenarios
-INSERT INTO users (id, name, email, created_at, updated_at) VALUES
-('00000000-0000-0000-0000-000000000001', 'Admin User', 'admin@example.com', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000002', 'John Doe', 'john.doe@example.com', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000003', 'Jane Smith', 'jane.smith@example.com', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000004', 'Alice Johnson', 'alice.johnson@example.com', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000005', 'Bob Williams', 'bob.williams@example.com', NOW(), NOW());
+INSERT INTO users (id, organization_id, name, email, created_at, updated_at) VALUES
+('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', 'Admin User', 'admin@example.com', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', 'John Doe', 'john.doe@example.com', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000001', 'Jane Smith', 'jane.smith@example.com', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000001', 'Alice Johnson', 'alice.johnson@example.com', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000005', '00000000-0000-0000-0000-000000000001', 'Bob Williams', 'bob.williams@example.com', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000006', '00000000-0000-0000-0000-000000000002', 'Hank Scorpio', 'hank.scorpio@example.com', NOW(), NOW());
 
 -- Events to test different scenarios
-INSERT INTO events (id, title, description, creator_id, duration, status, created_at, updated_at) VALUES
-('00000000-0000-0000-0000-000000000001', 'Team Brainstorming', 'Quarterly team brainstorming session', '00000000-0000-0000-0000-000000000001', 60, 'active', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000002', 'Project Kickoff', 'Kickoff meeting for new project', '00000000-0000-0000-0000-000000000002', 90, 'draft', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000003', 'Product Review', 'Monthly product review meeting', '00000000-0000-0000-0000-000000000003', 45, 'canceled', NOW(), NOW());
+INSERT INTO events (id, organization_id, title, description, creator_id, duration, status, created_at, updated_at) VALUES
+('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', 'Team Brainstorming', 'Quarterly team brainstorming session', '00000000-0000-0000-0000-000000000001', 60, 'active', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', 'Project Kickoff', 'Kickoff meeting for new project', '00000000-0000-0000-0000-000000000002', 90, 'draft', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000001', 'Product Review', 'Monthly product review meeting', '00000000-0000-0000-0000-000000000003', 45, 'canceled', NOW(), NOW());
+
+-- Index the sample events for search, as the application does on every change
+UPDATE events SET search_vector = setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', coalesce(description, '')), 'B');
 
 -- Time slots for testing different events
-INSERT INTO time_slots (id, event_id, start_time, end_time, created_at, updated_at) VALUES
+INSERT INTO time_slots (id, organization_id, event_id, start_time, end_time, created_at, updated_at) VALUES
 -- Time slots for Team Brainstorming event
-('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '2025-01-15 10:00:00', '2025-01-15 11:00:00', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '2025-01-15 14:00:00', '2025-01-15 15:00:00', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000001', '2025-01-16 10:00:00', '2025-01-16 11:00:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '2025-01-15 10:00:00', '2025-01-15 11:00:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '2025-01-15 14:00:00', '2025-01-15 15:00:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '2025-01-16 10:00:00', '2025-01-16 11:00:00', NOW(), NOW()),
 
 -- Time slots for Project Kickoff event
-('00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000002', '2025-01-20 09:00:00', '2025-01-20 10:30:00', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000005', '00000000-0000-0000-0000-000000000002', '2025-01-20 14:00:00', '2025-01-20 15:30:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', '2025-01-20 09:00:00', '2025-01-20 10:30:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000005', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', '2025-01-20 14:00:00', '2025-01-20 15:30:00', NOW(), NOW()),
 
 -- Time slots for Product Review event
-('00000000-0000-0000-0000-000000000006', '00000000-0000-0000-0000-000000000003', '2025-01-25 11:00:00', '2025-01-25 11:45:00', NOW(), NOW());
+('00000000-0000-0000-0000-000000000006', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003', '2025-01-25 11:00:00', '2025-01-25 11:45:00', NOW(), NOW());
+
+-- Participants invited to the events
+INSERT INTO event_participants (id, event_id, user_id, created_at) VALUES
+('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', NOW()),
+('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003', NOW()),
+('00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000004', NOW()),
+('00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000005', NOW()),
+('00000000-0000-0000-0000-000000000005', '00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', NOW()),
+('00000000-0000-0000-0000-000000000006', '00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000003', NOW()),
+('00000000-0000-0000-0000-000000000007', '00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000004', NOW());
+
+-- Rooms for testing resource requirements
+INSERT INTO resources (id, organization_id, name, kind, capacity, attributes, created_at, updated_at) VALUES
+('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', 'Orchid', 'room', 8, '[\"projector\", \"whiteboard\"]', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', 'Atrium', 'room', 20, '[\"whiteboard\"]', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000001', 'Bridge 1', 'video_bridge', 50, '[]', NOW(), NOW());
 
 -- Availability for testing recommendation scenarios
-INSERT INTO availabilities (id, user_id, event_id, start_time, end_time, created_at, updated_at) VALUES
+INSERT INTO availabilities (id, organization_id, user_id, event_id, start_time, end_time, created_at, updated_at) VALUES
 -- Availabilities for Team Brainstorming event
-('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '2025-01-15 09:00:00', '2025-01-15 12:00:00', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000001', '2025-01-15 13:00:00', '2025-01-15 16:00:00', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000001', '2025-01-15 10:00:00', '2025-01-15 15:00:00', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000005', '00000000-0000-0000-0000-000000000001', '2025-01-15 09:00:00', '2025-01-15 17:00:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '2025-01-15 09:00:00', '2025-01-15 12:00:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000001', '2025-01-15 13:00:00', '2025-01-15 16:00:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000001', '2025-01-15 10:00:00', '2025-01-15 15:00:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000005', '00000000-0000-0000-0000-000000000001', '2025-01-15 09:00:00', '2025-01-15 17:00:00', NOW(), NOW()),
 
 -- Availabilities for Project Kickoff event
-('00000000-0000-0000-0000-000000000005', '00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', '2025-01-20 08:00:00', '2025-01-20 11:00:00', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000006', '00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000002', '2025-01-20 13:00:00', '2025-01-20 16:00:00', NOW(), NOW()),
-('00000000-0000-0000-0000-000000000007', '00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000002', '2025-01-20 09:00:00', '2025-01-20 15:00:00', NOW(), NOW());
+('00000000-0000-0000-0000-000000000005', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', '2025-01-20 08:00:00', '2025-01-20 11:00:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000006', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000002', '2025-01-20 13:00:00', '2025-01-20 16:00:00', NOW(), NOW()),
+('00000000-0000-0000-0000-000000000007', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000002', '2025-01-20 09:00:00', '2025-01-20 15:00:00', NOW(), NOW());
 "
 
 if [ $? -eq 0 ]; then